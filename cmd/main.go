@@ -8,5 +8,12 @@ import (
 
 func main() {
 	app := app.New(os.Getenv("CONFIG_PATH"))
+
+	// musicshop purge - окончательное удаление мягко удаленных записей
+	if len(os.Args) > 1 && os.Args[1] == "purge" {
+		app.Purge()
+		return
+	}
+
 	app.Start()
 }
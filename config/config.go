@@ -13,6 +13,12 @@ type (
 		HTTP     HTTP     `yaml:"http"`
 		Postgres Postgres `yaml:"postgres"`
 		Log      Log      `yaml:"logger"`
+		Auth     Auth     `yaml:"auth"`
+		Purge    Purge    `yaml:"purge"`
+		Prices   Prices   `yaml:"prices"`
+		Currency Currency `yaml:"currency"`
+		Metrics  Metrics  `yaml:"metrics"`
+		Tracing  Tracing  `yaml:"tracing"`
 	}
 
 	App struct {
@@ -22,6 +28,8 @@ type (
 
 	HTTP struct {
 		Port string `env-required:"true" yaml:"port" env:"SERVER_PORT"`
+		// ShutdownDelay - пауза между снятием готовности (/readyz) и остановкой сервера
+		ShutdownDelay time.Duration `yaml:"shutdown_delay" env:"SERVER_SHUTDOWN_DELAY"`
 	}
 
 	Postgres struct {
@@ -30,6 +38,45 @@ type (
 	}
 	Log struct {
 		Level string `env-required:"true" yaml:"level" env:"LOG_LEVEL"`
+		// Format - json или text
+		Format string `env-default:"json" yaml:"format" env:"LOG_FORMAT"`
+		// AccessSampleEvery - писать каждый N-й журнал успешного запроса, 0 и 1 - все.
+		// Ошибочные запросы пишутся всегда.
+		AccessSampleEvery int `yaml:"access_sample_every" env:"LOG_ACCESS_SAMPLE_EVERY"`
+	}
+
+	Auth struct {
+		SigningKey string        `env-required:"true" yaml:"signing_key" env:"AUTH_SIGNING_KEY"`
+		AccessTTL  time.Duration `env-required:"true" yaml:"access_ttl" env:"AUTH_ACCESS_TTL"`
+		RefreshTTL time.Duration `env-required:"true" yaml:"refresh_ttl" env:"AUTH_REFRESH_TTL"`
+	}
+
+	// Purge - срок хранения мягко удаленных записей до окончательного удаления
+	Purge struct {
+		Retention time.Duration `env-required:"true" yaml:"retention" env:"PURGE_RETENTION"`
+	}
+
+	// Prices - как часто наступившие запланированные цены переносятся в albums.price
+	Prices struct {
+		SyncInterval time.Duration `env-default:"1m" yaml:"sync_interval" env:"PRICES_SYNC_INTERVAL"`
+	}
+
+	// Currency - курсы валют к базовой USD, например EUR: "0.92".
+	// Валюты без курса доступны только для альбомов с явной ценой в этой валюте.
+	Currency struct {
+		Rates map[string]string `yaml:"rates" env:"CURRENCY_RATES"`
+	}
+
+	// Metrics - отдельный адрес для /metrics, чтобы метрики не были доступны снаружи вместе с API
+	Metrics struct {
+		Addr string `env-required:"true" yaml:"addr" env:"METRICS_ADDR"`
+	}
+
+	// Tracing - экспорт трейсов: none, stdout или otlp (OTLP/HTTP на Endpoint)
+	Tracing struct {
+		Exporter string `env-default:"none" yaml:"exporter" env:"TRACING_EXPORTER"`
+		Endpoint string `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+		Insecure bool   `yaml:"insecure" env:"TRACING_INSECURE"`
 	}
 )
 
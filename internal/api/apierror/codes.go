@@ -0,0 +1,141 @@
+package apierror
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/service/albums"
+	"github.com/4udiwe/musicshop/internal/service/artists"
+	"github.com/4udiwe/musicshop/internal/service/audit"
+	"github.com/4udiwe/musicshop/internal/service/carts"
+	"github.com/4udiwe/musicshop/internal/service/genres"
+	"github.com/4udiwe/musicshop/internal/service/orders"
+	"github.com/4udiwe/musicshop/internal/service/prices"
+	"github.com/4udiwe/musicshop/internal/service/promotions"
+	"github.com/4udiwe/musicshop/internal/service/stock"
+	"github.com/4udiwe/musicshop/internal/service/users"
+)
+
+type mapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// mappings сопоставляет ошибкам сервисов HTTP-статус и стабильный код для клиентов.
+// Коды - часть публичного API, менять их нельзя.
+var mappings = []mapping{
+	// временные ошибки базы проверяются первыми: запрос можно повторить
+	{repo.ErrDeadlock, http.StatusServiceUnavailable, "transaction_conflict"},
+	{repo.ErrSerializationFailure, http.StatusServiceUnavailable, "transaction_conflict"},
+	{repo.ErrStatementTimeout, http.StatusServiceUnavailable, "database_timeout"},
+
+	// ошибки сумм и валют приходят обернутыми в ошибки любых сервисов
+	{entity.ErrInvalidMoney, http.StatusBadRequest, "invalid_money"},
+	{entity.ErrUnsupportedCurrency, http.StatusBadRequest, "unsupported_currency"},
+
+	// albums
+	{albums.ErrAlbumNotFound, http.StatusNotFound, "album_not_found"},
+	{albums.ErrAlbumAlreadyExists, http.StatusConflict, "album_already_exists"},
+	{albums.ErrGenreNotExists, http.StatusNotFound, "genre_not_found"},
+	{albums.ErrArtistNotExists, http.StatusNotFound, "artist_not_found"},
+	{albums.ErrPriceDerivedFromSKUs, http.StatusConflict, "price_derived_from_skus"},
+	{albums.ErrDuplicateTrackPosition, http.StatusBadRequest, "duplicate_track_position"},
+	{albums.ErrInvalidTrack, http.StatusBadRequest, "invalid_track"},
+	{albums.ErrCannotCreateAlbum, http.StatusInternalServerError, "album_create_failed"},
+	{albums.ErrCannotUpdateAlbum, http.StatusInternalServerError, "album_update_failed"},
+	{albums.ErrCannotFetchAlbums, http.StatusInternalServerError, "albums_fetch_failed"},
+	{albums.ErrCannotSearchAlbums, http.StatusInternalServerError, "albums_search_failed"},
+	{albums.ErrCannotRestoreAlbum, http.StatusInternalServerError, "album_restore_failed"},
+	{albums.ErrFindingAlbum, http.StatusInternalServerError, "album_fetch_failed"},
+	{albums.ErrCannotReplaceTracks, http.StatusInternalServerError, "tracks_replace_failed"},
+
+	// genres
+	{genres.ErrGenreNotFound, http.StatusNotFound, "genre_not_found"},
+	{genres.ErrGenreAlreadyExists, http.StatusConflict, "genre_already_exists"},
+	{genres.ErrParentGenreNotFound, http.StatusNotFound, "parent_genre_not_found"},
+	{genres.ErrGenreCycle, http.StatusConflict, "genre_cycle"},
+	{genres.ErrGenreHasChildren, http.StatusConflict, "genre_has_subgenres"},
+	{genres.ErrAlbumNotFound, http.StatusNotFound, "album_not_found"},
+	{genres.ErrAlbumGenreNotFound, http.StatusNotFound, "album_genre_not_found"},
+	{genres.ErrCannotAddEmptyGenres, http.StatusBadRequest, "genres_empty"},
+	{genres.ErrCannotCreateGenre, http.StatusInternalServerError, "genre_create_failed"},
+	{genres.ErrCannotFetchGenres, http.StatusInternalServerError, "genres_fetch_failed"},
+	{genres.ErrCannotDeleteGenre, http.StatusInternalServerError, "genre_delete_failed"},
+	{genres.ErrCannotUpdateGenre, http.StatusInternalServerError, "genre_update_failed"},
+	{genres.ErrCannotUpdateAlbumGenres, http.StatusInternalServerError, "album_genres_update_failed"},
+	{genres.ErrCannotRestoreGenre, http.StatusInternalServerError, "genre_restore_failed"},
+
+	// artists
+	{artists.ErrArtistNotFound, http.StatusNotFound, "artist_not_found"},
+	{artists.ErrArtistAlreadyExists, http.StatusConflict, "artist_already_exists"},
+	{artists.ErrArtistHasAlbums, http.StatusConflict, "artist_has_albums"},
+	{artists.ErrCannotCreateArtist, http.StatusInternalServerError, "artist_create_failed"},
+	{artists.ErrCannotFetchArtists, http.StatusInternalServerError, "artists_fetch_failed"},
+	{artists.ErrCannotUpdateArtist, http.StatusInternalServerError, "artist_update_failed"},
+	{artists.ErrCannotDeleteArtist, http.StatusInternalServerError, "artist_delete_failed"},
+
+	// stock
+	{stock.ErrAlbumNotFound, http.StatusNotFound, "album_not_found"},
+	{stock.ErrSKUNotFound, http.StatusNotFound, "sku_not_found"},
+	{stock.ErrInsufficientStock, http.StatusConflict, "insufficient_stock"},
+	{stock.ErrDigitalStock, http.StatusBadRequest, "digital_format_has_no_stock"},
+	{stock.ErrCannotSetPrice, http.StatusInternalServerError, "sku_price_update_failed"},
+	{stock.ErrCannotAdjustStock, http.StatusInternalServerError, "stock_adjust_failed"},
+	{stock.ErrCannotFetchAvailability, http.StatusInternalServerError, "availability_fetch_failed"},
+
+	// carts
+	{carts.ErrCartNotFound, http.StatusNotFound, "cart_not_found"},
+	{carts.ErrAlbumNotFound, http.StatusNotFound, "album_not_found"},
+	{carts.ErrSKUNotFound, http.StatusNotFound, "sku_not_found"},
+	{carts.ErrCannotCreateCart, http.StatusInternalServerError, "cart_create_failed"},
+	{carts.ErrCannotFetchCart, http.StatusInternalServerError, "cart_fetch_failed"},
+	{carts.ErrCannotUpdateCart, http.StatusInternalServerError, "cart_update_failed"},
+
+	// promotions: ошибки кода акции при оформлении приходят обернутыми
+	// в orders.ErrCannotCheckout, поэтому проверяются раньше ошибок заказов
+	{promotions.ErrPromotionNotFound, http.StatusNotFound, "promotion_not_found"},
+	{promotions.ErrPromotionNotActive, http.StatusUnprocessableEntity, "promotion_not_active"},
+	{promotions.ErrPromotionExhausted, http.StatusConflict, "promotion_exhausted"},
+
+	// orders
+	{orders.ErrCartNotFound, http.StatusNotFound, "cart_not_found"},
+	{orders.ErrCartIsEmpty, http.StatusBadRequest, "cart_is_empty"},
+	{orders.ErrSKUNotFound, http.StatusNotFound, "sku_not_found"},
+	{orders.ErrInsufficientStock, http.StatusConflict, "insufficient_stock"},
+	{orders.ErrOrderNotFound, http.StatusNotFound, "order_not_found"},
+	{orders.ErrInvalidStatusTransition, http.StatusConflict, "invalid_status_transition"},
+	{orders.ErrCannotCheckout, http.StatusInternalServerError, "checkout_failed"},
+	{orders.ErrCannotFetchOrder, http.StatusInternalServerError, "order_fetch_failed"},
+	{orders.ErrCannotChangeStatus, http.StatusInternalServerError, "order_status_update_failed"},
+
+	// users
+	{users.ErrUserAlreadyExists, http.StatusConflict, "user_already_exists"},
+	{users.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials"},
+	{users.ErrInvalidRefreshToken, http.StatusUnauthorized, "invalid_refresh_token"},
+	{users.ErrCannotRegister, http.StatusInternalServerError, "registration_failed"},
+	{users.ErrCannotLogin, http.StatusInternalServerError, "login_failed"},
+	{users.ErrCannotRefreshTokens, http.StatusInternalServerError, "token_refresh_failed"},
+
+	// audit
+	{audit.ErrCannotFetchAuditEvents, http.StatusInternalServerError, "audit_fetch_failed"},
+
+	// prices
+	{prices.ErrAlbumNotFound, http.StatusNotFound, "album_not_found"},
+	{prices.ErrPriceNotFound, http.StatusNotFound, "price_not_found"},
+	{prices.ErrPriceInPast, http.StatusBadRequest, "price_in_past"},
+	{prices.ErrPriceDerivedFromSKUs, http.StatusConflict, "price_derived_from_skus"},
+	{prices.ErrUnsupportedCurrency, http.StatusBadRequest, "unsupported_currency"},
+	{prices.ErrCannotSchedulePrice, http.StatusInternalServerError, "price_schedule_failed"},
+	{prices.ErrCannotFetchPrices, http.StatusInternalServerError, "prices_fetch_failed"},
+
+	// promotions
+	{promotions.ErrPromotionCodeExists, http.StatusConflict, "promotion_code_exists"},
+	{promotions.ErrInvalidPromotion, http.StatusBadRequest, "invalid_promotion"},
+	{promotions.ErrCartNotFound, http.StatusNotFound, "cart_not_found"},
+	{promotions.ErrCannotCreatePromotion, http.StatusInternalServerError, "promotion_create_failed"},
+	{promotions.ErrCannotFetchPromotions, http.StatusInternalServerError, "promotions_fetch_failed"},
+	{promotions.ErrCannotPriceCart, http.StatusInternalServerError, "cart_pricing_failed"},
+	{promotions.ErrCannotRedeemPromotion, http.StatusInternalServerError, "promotion_redeem_failed"},
+}
@@ -0,0 +1,116 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/4udiwe/musicshop/internal/logger"
+	"github.com/4udiwe/musicshop/pkg/validator"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	CodeValidationFailed = "validation_failed"
+	CodeInternal         = "internal_error"
+)
+
+// Response - единый формат ошибки для всех эндпоинтов
+type Response struct {
+	Code      string   `json:"code"`
+	Message   string   `json:"message"`
+	Details   []Detail `json:"details,omitempty"`
+	RequestID string   `json:"request_id,omitempty"`
+}
+
+// Detail описывает ошибку в конкретном поле запроса
+type Detail struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Handler - HTTPErrorHandler для Echo, отвечающий в формате Response
+func Handler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, response := Resolve(err)
+	response.RequestID = requestID(c)
+
+	// Клиенту уходит только публичное сообщение, причина пишется в лог.
+	// request_id, маршрут и пользователь уже есть в логгере запроса.
+	entry := logger.FromContext(c.Request().Context()).WithFields(log.Fields{
+		"status": status,
+		"code":   response.Code,
+	}).WithError(err)
+	if status >= http.StatusInternalServerError {
+		entry.Error("request failed")
+	} else {
+		entry.Debug("request rejected")
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, response)
+	}
+	if err != nil {
+		logger.FromContext(c.Request().Context()).WithError(err).Error("failed to write error response")
+	}
+}
+
+// Resolve определяет статус и тело ответа для ошибки. Известные ошибки сервисов
+// важнее статуса, выставленного обработчиком.
+func Resolve(err error) (int, Response) {
+	var validationErr *validator.ValidationError
+	if errors.As(err, &validationErr) {
+		details := make([]Detail, 0, len(validationErr.Fields))
+		for _, f := range validationErr.Fields {
+			details = append(details, Detail{Field: f.Field, Rule: f.Tag, Message: f.Message})
+		}
+		return http.StatusBadRequest, Response{
+			Code:    CodeValidationFailed,
+			Message: "request validation failed",
+			Details: details,
+		}
+	}
+
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			return m.status, Response{Code: m.code, Message: m.err.Error()}
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code, Response{
+			Code:    statusCode(httpErr.Code),
+			Message: fmt.Sprint(httpErr.Message),
+		}
+	}
+
+	return http.StatusInternalServerError, Response{
+		Code:    CodeInternal,
+		Message: "internal server error",
+	}
+}
+
+// statusCode строит код ошибки из текста статуса: 404 -> not_found
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return CodeInternal
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
+
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
@@ -0,0 +1,138 @@
+package apierror_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/api/apierror"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/service/albums"
+	"github.com/4udiwe/musicshop/internal/service/genres"
+	"github.com/4udiwe/musicshop/internal/service/orders"
+	"github.com/4udiwe/musicshop/internal/service/promotions"
+	"github.com/4udiwe/musicshop/pkg/validator"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantMsg    string
+	}{
+		{
+			name:       "service sentinel",
+			err:        albums.ErrAlbumNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "album_not_found",
+			wantMsg:    "album not found",
+		},
+		{
+			name:       "wrapped sentinel hides cause",
+			err:        fmt.Errorf("%w: %w", genres.ErrGenreAlreadyExists, errors.New("duplicate key value")),
+			wantStatus: http.StatusConflict,
+			wantCode:   "genre_already_exists",
+			wantMsg:    "genre already exists",
+		},
+		{
+			name:       "transient database error",
+			err:        fmt.Errorf("%w: %w", albums.ErrCannotFetchAlbums, repo.ClassifyPgError(context.Background(), &pgconn.PgError{Code: "40P01"})),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "transaction_conflict",
+			wantMsg:    "deadlock detected",
+		},
+		{
+			name:       "currency error wrapped by service error",
+			err:        fmt.Errorf("%w: %w", albums.ErrFindingAlbum, entity.ErrUnsupportedCurrency),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "unsupported_currency",
+			wantMsg:    "unsupported currency",
+		},
+		{
+			name:       "promotion error during checkout",
+			err:        fmt.Errorf("%w: %w", orders.ErrCannotCheckout, promotions.ErrPromotionExhausted),
+			wantStatus: http.StatusConflict,
+			wantCode:   "promotion_exhausted",
+			wantMsg:    "promotion usage limit reached",
+		},
+		{
+			name:       "http error",
+			err:        echo.NewHTTPError(http.StatusForbidden, "insufficient permissions"),
+			wantStatus: http.StatusForbidden,
+			wantCode:   "forbidden",
+			wantMsg:    "insufficient permissions",
+		},
+		{
+			name:       "unknown error",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   apierror.CodeInternal,
+			wantMsg:    "internal server error",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, out := apierror.Resolve(tc.err)
+
+			assert.Equal(t, tc.wantStatus, status)
+			assert.Equal(t, tc.wantCode, out.Code)
+			assert.Equal(t, tc.wantMsg, out.Message)
+		})
+	}
+}
+
+func TestHandlerValidation(t *testing.T) {
+	type Request struct {
+		Title    string  `json:"title" validate:"required,min=2"`
+		Subtitle string  `json:"subtitle" validate:"max=3"`
+		Price    float64 `json:"price" validate:"gte=0"`
+		Quantity int     `json:"quantity" validate:"min=1"`
+		Tags     []int64 `json:"tags" validate:"max=1"`
+		Format   string  `json:"format" validate:"omitempty,oneof=cd vinyl"`
+	}
+
+	e := echo.New()
+	e.Validator = validator.NewCustomValidator()
+	e.HTTPErrorHandler = apierror.Handler
+	e.POST("/", func(c echo.Context) error {
+		var in Request
+		if err := c.Bind(&in); err != nil {
+			return err
+		}
+		if err := c.Validate(in); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"subtitle": "long", "price": -1, "tags": [1, 2], "format": "tape"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	var out apierror.Response
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, apierror.CodeValidationFailed, out.Code)
+	assert.Equal(t, "req-1", out.RequestID)
+	assert.Equal(t, []apierror.Detail{
+		{Field: "title", Rule: "required", Message: "field title is required"},
+		{Field: "subtitle", Rule: "max", Message: "field subtitle must have at most 3 characters"},
+		{Field: "price", Rule: "gte", Message: "field price must be greater than or equal to 0"},
+		{Field: "quantity", Rule: "min", Message: "field quantity must be greater than or equal to 1"},
+		{Field: "tags", Rule: "max", Message: "field tags must have at most 1 item"},
+		{Field: "format", Rule: "oneof", Message: "field format must be one of: cd vinyl"},
+	}, out.Details)
+}
@@ -1,6 +1,8 @@
 package decorator
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/4udiwe/musicshop/internal/api"
@@ -23,11 +25,17 @@ func (d *bindAndValidateDecorator[T]) Handle(c echo.Context) error {
 	var in T
 
 	if err := c.Bind(&in); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		// Echo сам возвращает HTTPError, клиенту достаточно его сообщения
+		message := err.Error()
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			message = fmt.Sprint(httpErr.Message)
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, message).SetInternal(err)
 	}
 
 	if err := c.Validate(in); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 	}
 
 	return d.inner.Handle(c, in)
@@ -1,12 +1,10 @@
 package delete_album
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/decorator"
-	service "github.com/4udiwe/musicshop/internal/service/albums"
 	"github.com/labstack/echo/v4"
 )
 
@@ -25,10 +23,7 @@ type Request struct {
 func (h *handler) Handle(c echo.Context, in Request) error {
 	err := h.albumsService.DeleteById(c.Request().Context(), in.ID)
 	if err != nil {
-		if errors.Is(err, service.ErrAlbumNotFound) {
-			return echo.NewHTTPError(http.StatusNotFound, err.Error())
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	return c.NoContent(http.StatusNoContent)
 }
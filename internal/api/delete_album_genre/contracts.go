@@ -0,0 +1,7 @@
+package delete_album_genre
+
+import "context"
+
+type GenreService interface {
+	RemoveGenreFromAlbum(ctx context.Context, albumID, genreID int64) error
+}
@@ -0,0 +1,32 @@
+package delete_album_genre
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	genreService GenreService
+}
+
+func New(gs GenreService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		genreService: gs,
+	})
+}
+
+type Request struct {
+	AlbumID int64 `param:"id" validate:"required"`
+	GenreID int64 `param:"genre_id" validate:"required"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.genreService.RemoveGenreFromAlbum(c.Request().Context(), in.AlbumID, in.GenreID)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
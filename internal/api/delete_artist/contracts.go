@@ -0,0 +1,7 @@
+package delete_artist
+
+import "context"
+
+type ArtistService interface {
+	Delete(ctx context.Context, id int64) error
+}
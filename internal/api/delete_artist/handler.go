@@ -0,0 +1,31 @@
+package delete_artist
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	artistService ArtistService
+}
+
+func New(as ArtistService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		artistService: as,
+	})
+}
+
+type Request struct {
+	ID int64 `param:"id" validate:"required"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.artistService.Delete(c.Request().Context(), in.ID)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
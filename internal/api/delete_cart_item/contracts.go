@@ -0,0 +1,7 @@
+package delete_cart_item
+
+import "context"
+
+type CartService interface {
+	RemoveItem(ctx context.Context, cartID, albumID int64) error
+}
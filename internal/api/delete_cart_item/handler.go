@@ -0,0 +1,32 @@
+package delete_cart_item
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	cartService CartService
+}
+
+func New(cs CartService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		cartService: cs,
+	})
+}
+
+type Request struct {
+	CartID  int64 `param:"id" validate:"required"`
+	AlbumID int64 `param:"album_id" validate:"required"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.cartService.RemoveItem(c.Request().Context(), in.CartID, in.AlbumID)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -3,5 +3,5 @@ package delete_genre
 import "context"
 
 type GenreService interface {
-	DeleteGenre(ctx context.Context, genreID int64) error
+	DeleteGenre(ctx context.Context, genreID int64, policy string) error
 }
@@ -1,12 +1,10 @@
 package delete_genre
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/decorator"
-	service "github.com/4udiwe/musicshop/internal/service/genres"
 	"github.com/labstack/echo/v4"
 )
 
@@ -22,15 +20,14 @@ func New(gs GenreService) api.Handler {
 
 type Request struct {
 	ID int64 `param:"id" validate:"required"`
+	// Policy - что делать с поджанрами, по умолчанию удаление жанра с поджанрами запрещено
+	Policy string `query:"policy" validate:"omitempty,oneof=reject reparent cascade"`
 }
 
 func (h *handler) Handle(c echo.Context, in Request) error {
-	err := h.genreService.DeleteGenre(c.Request().Context(), in.ID)
+	err := h.genreService.DeleteGenre(c.Request().Context(), in.ID, in.Policy)
 	if err != nil {
-		if errors.Is(err, service.ErrGenreNotFound) {
-			return echo.NewHTTPError(http.StatusNotFound, err.Error())
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	return c.NoContent(http.StatusOK)
 }
@@ -0,0 +1,7 @@
+package delete_genre_legacy
+
+import "context"
+
+type GenreService interface {
+	DeleteGenre(ctx context.Context, genreID int64, policy string) error
+}
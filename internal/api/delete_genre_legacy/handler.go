@@ -0,0 +1,38 @@
+package delete_genre_legacy
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	genreService GenreService
+}
+
+func New(gs GenreService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		genreService: gs,
+	})
+}
+
+// Request - тело устаревшего DELETE /genres, где id жанра передается в JSON.
+// Маршрут оставлен для старых клиентов, замена - DELETE /genres/:id.
+type Request struct {
+	ID int64 `json:"id" validate:"required"`
+	// Policy - что делать с поджанрами, по умолчанию удаление жанра с поджанрами запрещено
+	Policy string `query:"policy" validate:"omitempty,oneof=reject reparent cascade"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	c.Response().Header().Set("Deprecation", "true")
+	c.Response().Header().Set("Link", `</genres/{id}>; rel="successor-version"`)
+
+	err := h.genreService.DeleteGenre(c.Request().Context(), in.ID, in.Policy)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
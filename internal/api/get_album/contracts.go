@@ -7,5 +7,5 @@ import (
 )
 
 type AlbumsService interface {
-	FindById(ctx context.Context, id int64) (entity.Album, error)
+	FindById(ctx context.Context, id int64, currency string) (entity.Album, error)
 }
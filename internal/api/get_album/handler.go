@@ -1,14 +1,16 @@
 package get_album
 
 import (
-	"errors"
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/api/get_album_tracks"
 	"github.com/4udiwe/musicshop/internal/entity"
-	service "github.com/4udiwe/musicshop/internal/service/albums"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 )
 
 type handler struct {
@@ -23,32 +25,57 @@ func New(albumsService AlbumsService) api.Handler {
 
 type Request struct {
 	ID int64 `param:"id" validate:"required"`
+	// Currency - валюта цены в ответе, по умолчанию базовая
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+type Genre struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
 }
 
 type Response struct {
-	ID     int64   `json:"id"`
-	Title  string  `json:"title"`
-	Artist string  `json:"artist"`
-	Price  float64 `json:"price"`
+	ID          int64                    `json:"id"`
+	Title       string                   `json:"title"`
+	ArtistID    int64                    `json:"artist_id"`
+	Artist      string                   `json:"artist"`
+	Price       json.Number              `json:"price"`
+	Currency    string                   `json:"currency"`
+	Genres      []Genre                  `json:"genres,omitempty"`
+	Tracks      []get_album_tracks.Track `json:"tracks"`
+	RunningTime int                      `json:"running_time"` // в секундах
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
 }
 
 // ToResponse преобразует entity в DTO
 func ToResponse(a entity.Album) Response {
 	return Response{
-		ID:     a.ID,
-		Title:  a.Title,
-		Artist: a.Artist,
-		Price:  a.Price,
+		ID:       a.ID,
+		Title:    a.Title,
+		ArtistID: a.ArtistID,
+		Artist:   a.Artist,
+		Price:    json.Number(a.Price.Decimal()),
+		Currency: a.Price.Currency,
+		Genres: lo.Map(a.Genres, func(g entity.Genre, i int) Genre {
+			return Genre{
+				ID:   g.ID,
+				Name: g.Name,
+			}
+		}),
+		Tracks: lo.Map(a.Tracks, func(t entity.Track, _ int) get_album_tracks.Track {
+			return get_album_tracks.ToTrack(t)
+		}),
+		RunningTime: int(a.RunningTime() / time.Second),
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
 	}
 }
 
 func (h *handler) Handle(c echo.Context, in Request) error {
-	album, err := h.albumsService.FindById(c.Request().Context(), in.ID)
+	album, err := h.albumsService.FindById(c.Request().Context(), in.ID, in.Currency)
 	if err != nil {
-		if errors.Is(err, service.ErrAlbumNotFound) {
-			return echo.NewHTTPError(http.StatusNotFound, err.Error())
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	return c.JSON(http.StatusOK, ToResponse(album))
 }
@@ -0,0 +1,11 @@
+package get_album_availability
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type StockService interface {
+	Availability(ctx context.Context, albumID int64) (entity.Availability, error)
+}
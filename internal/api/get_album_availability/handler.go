@@ -0,0 +1,68 @@
+package get_album_availability
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	stockService StockService
+}
+
+func New(ss StockService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		stockService: ss,
+	})
+}
+
+type Request struct {
+	AlbumID int64 `param:"id" validate:"required"`
+}
+
+// Цены SKU и альбома - в базовой валюте
+type SKU struct {
+	Format    string      `json:"format"`
+	Price     json.Number `json:"price"`
+	Quantity  int         `json:"quantity"`
+	Available bool        `json:"available"`
+}
+
+type Response struct {
+	AlbumID   int64       `json:"album_id"`
+	Price     json.Number `json:"price"`
+	Currency  string      `json:"currency"`
+	Available bool        `json:"available"`
+	SKUs      []SKU       `json:"skus"`
+}
+
+// ToResponse преобразует entity в DTO
+func ToResponse(a entity.Availability) Response {
+	return Response{
+		AlbumID:   a.AlbumID,
+		Price:     json.Number(a.Price.Decimal()),
+		Currency:  a.Price.Currency,
+		Available: a.Available(),
+		SKUs: lo.Map(a.SKUs, func(s entity.SKU, _ int) SKU {
+			return SKU{
+				Format:    s.Format,
+				Price:     json.Number(s.Price.Decimal()),
+				Quantity:  s.Quantity,
+				Available: s.Available(),
+			}
+		}),
+	}
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	availability, err := h.stockService.Availability(c.Request().Context(), in.AlbumID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, ToResponse(availability))
+}
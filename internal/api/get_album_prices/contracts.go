@@ -0,0 +1,11 @@
+package get_album_prices
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type PriceService interface {
+	FindAll(ctx context.Context, albumID int64) ([]entity.AlbumPrice, error)
+}
@@ -0,0 +1,84 @@
+package get_album_prices
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	priceService PriceService
+}
+
+func New(ps PriceService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		priceService: ps,
+	})
+}
+
+type Request struct {
+	AlbumID int64 `param:"id" validate:"required"`
+	// At - момент, на который нужна цена, по умолчанию текущий
+	At time.Time `query:"at"`
+	// Currency - валюта текущей цены, по умолчанию базовая. История содержит все валюты.
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+type Price struct {
+	Price          json.Number `json:"price"`
+	Currency       string      `json:"currency"`
+	EffectiveFrom  time.Time   `json:"effective_from"`
+	EffectiveUntil *time.Time  `json:"effective_until"`
+}
+
+type Response struct {
+	AlbumID int64     `json:"album_id"`
+	At      time.Time `json:"at"`
+	// Current - цена в валюте Currency, действующая в момент At,
+	// nil - если явной цены в этой валюте тогда не было
+	Current *Price  `json:"current"`
+	Prices  []Price `json:"prices"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	prices, err := h.priceService.FindAll(c.Request().Context(), in.AlbumID)
+	if err != nil {
+		return err
+	}
+
+	at := in.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	currency := in.Currency
+	if currency == "" {
+		currency = entity.BaseCurrency
+	}
+
+	response := Response{
+		AlbumID: in.AlbumID,
+		At:      at,
+		Prices:  lo.Map(prices, func(p entity.AlbumPrice, _ int) Price { return toPrice(p) }),
+	}
+	if current, ok := lo.Find(prices, func(p entity.AlbumPrice) bool { return p.Price.Currency == currency && p.ActiveAt(at) }); ok {
+		response.Current = lo.ToPtr(toPrice(current))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func toPrice(p entity.AlbumPrice) Price {
+	return Price{
+		Price:          json.Number(p.Price.Decimal()),
+		Currency:       p.Price.Currency,
+		EffectiveFrom:  p.EffectiveFrom,
+		EffectiveUntil: p.EffectiveUntil,
+	}
+}
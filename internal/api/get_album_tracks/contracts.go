@@ -0,0 +1,11 @@
+package get_album_tracks
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type AlbumsService interface {
+	FindTracks(ctx context.Context, albumID int64) ([]entity.Track, error)
+}
@@ -0,0 +1,64 @@
+package get_album_tracks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	albumsService AlbumsService
+}
+
+func New(albumsService AlbumsService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		albumsService: albumsService,
+	})
+}
+
+type Request struct {
+	AlbumID int64 `param:"id" validate:"required"`
+}
+
+type Track struct {
+	DiscNumber int    `json:"disc_number"`
+	Position   int    `json:"position"`
+	Title      string `json:"title"`
+	Duration   int    `json:"duration"` // в секундах
+	ISRC       string `json:"isrc,omitempty"`
+}
+
+type Response struct {
+	Tracks      []Track `json:"tracks"`
+	RunningTime int     `json:"running_time"` // в секундах
+}
+
+// ToTrack преобразует entity в DTO
+func ToTrack(t entity.Track) Track {
+	return Track{
+		DiscNumber: t.DiscNumber,
+		Position:   t.Position,
+		Title:      t.Title,
+		Duration:   int(t.Duration / time.Second),
+		ISRC:       t.ISRC,
+	}
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	tracks, err := h.albumsService.FindTracks(c.Request().Context(), in.AlbumID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, Response{
+		Tracks: lo.Map(tracks, func(t entity.Track, _ int) Track {
+			return ToTrack(t)
+		}),
+		RunningTime: int(entity.Album{Tracks: tracks}.RunningTime() / time.Second),
+	})
+}
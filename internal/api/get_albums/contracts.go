@@ -7,5 +7,5 @@ import (
 )
 
 type AlbumsService interface {
-	FindAll(ctx context.Context) ([]entity.Album, error)
+	FindAll(ctx context.Context, filter entity.AlbumFilter) (entity.AlbumPage, error)
 }
@@ -1,7 +1,10 @@
 package get_albums
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/decorator"
@@ -20,7 +23,26 @@ func New(albumsService AlbumsService) api.Handler {
 	})
 }
 
-type Request struct{}
+type Request struct {
+	Limit      int     `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor     int64   `query:"cursor" validate:"omitempty,min=1"`
+	ArtistID   int64   `query:"artist_id"`
+	Artist     string  `query:"artist"`
+	Title      string  `query:"title"`
+	GenreIDs   []int64 `query:"genre_id"`
+	GenreMatch string  `query:"genre_match" validate:"omitempty,oneof=any all"`
+	// IncludeSubgenres - жанр из genre_id совпадает и с любым из своих поджанров
+	IncludeSubgenres bool `query:"include_subgenres"`
+	// MinPrice и MaxPrice задаются в базовой валюте независимо от currency
+	MinPrice string `query:"min_price"`
+	MaxPrice string `query:"max_price"`
+	// Sort - поле сортировки, префикс "-" задает обратный порядок
+	Sort string `query:"sort" validate:"omitempty,oneof=title -title artist -artist price -price created_at -created_at"`
+	// IncludeDeleted доступен только администраторам, проверяется в роутере
+	IncludeDeleted bool `query:"include_deleted"`
+	// Currency - валюта цен в ответе, по умолчанию базовая
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
 
 type Genre struct {
 	ID   int64  `json:"id"`
@@ -28,39 +50,100 @@ type Genre struct {
 }
 
 type Album struct {
-	ID     int64   `json:"id"`
-	Title  string  `json:"title"`
-	Artist string  `json:"artist"`
-	Price  float64 `json:"price"`
-	Genres []Genre `json:"genres,omitempty"`
+	ID        int64       `json:"id"`
+	Title     string      `json:"title"`
+	ArtistID  int64       `json:"artist_id"`
+	Artist    string      `json:"artist"`
+	Price     json.Number `json:"price"`
+	Currency  string      `json:"currency"`
+	Genres    []Genre     `json:"genres,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	DeletedAt *time.Time  `json:"deleted_at,omitempty"`
 }
 
 type Response struct {
-	Albums []Album `json:"albums"`
+	Albums     []Album `json:"albums"`
+	NextCursor *int64  `json:"next_cursor,omitempty"`
+	Total      int64   `json:"total"`
 }
 
-func (h *Handler) Handle(c echo.Context, in Request) error {
-	out, err := h.albumsService.FindAll(c.Request().Context())
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-
-	albums := lo.Map(out, func(album entity.Album, i int) Album {
-		genres := lo.Map(album.Genres, func(genre entity.Genre, i int) Genre {
+// ToAlbum преобразует entity в DTO
+func ToAlbum(album entity.Album) Album {
+	return Album{
+		ID:       album.ID,
+		Title:    album.Title,
+		ArtistID: album.ArtistID,
+		Artist:   album.Artist,
+		Price:    json.Number(album.Price.Decimal()),
+		Currency: album.Price.Currency,
+		Genres: lo.Map(album.Genres, func(genre entity.Genre, _ int) Genre {
 			return Genre{
 				ID:   genre.ID,
 				Name: genre.Name,
 			}
-		})
-
-		return Album{
-			ID:     album.ID,
-			Title:  album.Title,
-			Artist: album.Artist,
-			Price:  album.Price,
-			Genres: genres,
+		}),
+		CreatedAt: album.CreatedAt,
+		UpdatedAt: album.UpdatedAt,
+		DeletedAt: album.DeletedAt,
+	}
+}
+
+// ToFilter преобразует параметры запроса в фильтр выборки
+func (r Request) ToFilter() (entity.AlbumFilter, error) {
+	filter := entity.AlbumFilter{
+		Limit:      r.Limit,
+		Cursor:     r.Cursor,
+		ArtistID:   r.ArtistID,
+		Artist:     r.Artist,
+		Title:      r.Title,
+		GenreIDs:   r.GenreIDs,
+		GenreMatch: r.GenreMatch,
+		SortBy:     strings.TrimPrefix(r.Sort, "-"),
+		SortDesc:   strings.HasPrefix(r.Sort, "-"),
+
+		IncludeSubgenres: r.IncludeSubgenres,
+		IncludeDeleted:   r.IncludeDeleted,
+		Currency:         r.Currency,
+	}
+
+	if r.MinPrice != "" {
+		price, err := entity.ParsePrice(r.MinPrice, entity.BaseCurrency)
+		if err != nil {
+			return entity.AlbumFilter{}, err
+		}
+		filter.MinPrice = &price
+	}
+	if r.MaxPrice != "" {
+		price, err := entity.ParsePrice(r.MaxPrice, entity.BaseCurrency)
+		if err != nil {
+			return entity.AlbumFilter{}, err
 		}
+		filter.MaxPrice = &price
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) Handle(c echo.Context, in Request) error {
+	filter, err := in.ToFilter()
+	if err != nil {
+		return err
+	}
+
+	out, err := h.albumsService.FindAll(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	albums := lo.Map(out.Albums, func(album entity.Album, _ int) Album {
+		return ToAlbum(album)
 	})
 
-	return c.JSON(http.StatusOK, Response{Albums: albums})
+	response := Response{Albums: albums, Total: out.Total}
+	if out.NextCursor != 0 {
+		response.NextCursor = &out.NextCursor
+	}
+
+	return c.JSON(http.StatusOK, response)
 }
@@ -0,0 +1,11 @@
+package get_artist
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type ArtistService interface {
+	FindById(ctx context.Context, id int64) (entity.Artist, error)
+}
@@ -0,0 +1,47 @@
+package get_artist
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	artistService ArtistService
+}
+
+func New(as ArtistService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		artistService: as,
+	})
+}
+
+type Request struct {
+	ID int64 `param:"id" validate:"required"`
+}
+
+type Response struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Bio  string `json:"bio"`
+}
+
+// ToResponse преобразует entity в DTO
+func ToResponse(a entity.Artist) Response {
+	return Response{
+		ID:   a.ID,
+		Name: a.Name,
+		Bio:  a.Bio,
+	}
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	artist, err := h.artistService.FindById(c.Request().Context(), in.ID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, ToResponse(artist))
+}
@@ -0,0 +1,15 @@
+package get_artist_albums
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type ArtistService interface {
+	FindById(ctx context.Context, id int64) (entity.Artist, error)
+}
+
+type AlbumsService interface {
+	FindAll(ctx context.Context, filter entity.AlbumFilter) (entity.AlbumPage, error)
+}
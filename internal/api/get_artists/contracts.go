@@ -0,0 +1,11 @@
+package get_artists
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type ArtistService interface {
+	FindAll(ctx context.Context) ([]entity.Artist, error)
+}
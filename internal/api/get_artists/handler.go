@@ -0,0 +1,48 @@
+package get_artists
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	artistService ArtistService
+}
+
+func New(as ArtistService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		artistService: as,
+	})
+}
+
+type Request struct{}
+
+type Response struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Bio  string `json:"bio,omitempty"`
+}
+
+// ToResponse преобразует entity в DTO
+func ToResponse(a entity.Artist) Response {
+	return Response{
+		ID:   a.ID,
+		Name: a.Name,
+		Bio:  a.Bio,
+	}
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	artists, err := h.artistService.FindAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, lo.Map(artists, func(a entity.Artist, _ int) Response {
+		return ToResponse(a)
+	}))
+}
@@ -0,0 +1,11 @@
+package get_audit
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type AuditService interface {
+	FindAll(ctx context.Context, filter entity.AuditFilter) (entity.AuditPage, error)
+}
@@ -0,0 +1,88 @@
+package get_audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	auditService AuditService
+}
+
+func New(auditService AuditService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		auditService: auditService,
+	})
+}
+
+type Request struct {
+	Entity string `query:"entity" validate:"omitempty,oneof=album genre"`
+	ID     int64  `query:"id" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=200"`
+	Cursor int64  `query:"cursor" validate:"omitempty,min=1"`
+}
+
+type Event struct {
+	ID         int64           `json:"id"`
+	ActorID    *int64          `json:"actor_id"`
+	ActorEmail string          `json:"actor_email,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   int64           `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type Response struct {
+	Events     []Event `json:"events"`
+	NextCursor *int64  `json:"next_cursor,omitempty"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	out, err := h.auditService.FindAll(c.Request().Context(), entity.AuditFilter{
+		EntityType: in.Entity,
+		EntityID:   in.ID,
+		Limit:      in.Limit,
+		Cursor:     in.Cursor,
+	})
+	if err != nil {
+		return err
+	}
+
+	response := Response{
+		Events: lo.Map(out.Events, func(e entity.AuditEvent, _ int) Event {
+			return Event{
+				ID:         e.ID,
+				ActorID:    e.ActorID,
+				ActorEmail: e.ActorEmail,
+				Action:     e.Action,
+				EntityType: e.EntityType,
+				EntityID:   e.EntityID,
+				Before:     snapshot(e.Before),
+				After:      snapshot(e.After),
+				CreatedAt:  e.CreatedAt,
+			}
+		}),
+	}
+	if out.NextCursor != 0 {
+		response.NextCursor = &out.NextCursor
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// snapshot отдает снимок из журнала как есть, отсутствующий снимок - как null
+func snapshot(v any) json.RawMessage {
+	if raw, ok := v.(json.RawMessage); ok {
+		return raw
+	}
+	return nil
+}
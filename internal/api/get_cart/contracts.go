@@ -0,0 +1,11 @@
+package get_cart
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type CartService interface {
+	FindById(ctx context.Context, id int64, currency string) (entity.Cart, error)
+}
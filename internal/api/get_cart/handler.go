@@ -0,0 +1,71 @@
+package get_cart
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	cartService CartService
+}
+
+func New(cs CartService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		cartService: cs,
+	})
+}
+
+type Request struct {
+	ID int64 `param:"id" validate:"required"`
+	// Currency - валюта цен в ответе, по умолчанию базовая
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+type Item struct {
+	AlbumID  int64       `json:"album_id"`
+	Format   string      `json:"format"`
+	Title    string      `json:"title"`
+	Artist   string      `json:"artist"`
+	Price    json.Number `json:"price"`
+	Quantity int         `json:"quantity"`
+}
+
+type Response struct {
+	ID       int64       `json:"id"`
+	Items    []Item      `json:"items"`
+	Total    json.Number `json:"total"`
+	Currency string      `json:"currency"`
+}
+
+// ToResponse преобразует entity в DTO
+func ToResponse(cart entity.Cart) Response {
+	return Response{
+		ID: cart.ID,
+		Items: lo.Map(cart.Items, func(item entity.CartItem, _ int) Item {
+			return Item{
+				AlbumID:  item.AlbumID,
+				Format:   item.Format,
+				Title:    item.Title,
+				Artist:   item.Artist,
+				Price:    json.Number(item.Price.Decimal()),
+				Quantity: item.Quantity,
+			}
+		}),
+		Total:    json.Number(cart.Total().Decimal()),
+		Currency: cart.Currency,
+	}
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	cart, err := h.cartService.FindById(c.Request().Context(), in.ID, in.Currency)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, ToResponse(cart))
+}
@@ -0,0 +1,11 @@
+package get_cart_pricing
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type PromotionService interface {
+	PriceCart(ctx context.Context, cartID int64, code, currency string) (entity.Pricing, error)
+}
@@ -0,0 +1,95 @@
+package get_cart_pricing
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	promotionService PromotionService
+}
+
+func New(ps PromotionService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		promotionService: ps,
+	})
+}
+
+type Request struct {
+	ID int64 `param:"id" validate:"required"`
+	// Code - код купона, который покупатель собирается применить
+	Code     string `query:"code" validate:"omitempty,max=64"`
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+type Line struct {
+	AlbumID      int64       `json:"album_id"`
+	Price        json.Number `json:"price"`
+	Quantity     int         `json:"quantity"`
+	Subtotal     json.Number `json:"subtotal"`
+	Discount     json.Number `json:"discount"`
+	Total        json.Number `json:"total"`
+	PromotionIDs []int64     `json:"promotion_ids"`
+}
+
+// Applied объясняет, какое правило сработало и на какие альбомы
+type Applied struct {
+	PromotionID int64       `json:"promotion_id"`
+	Name        string      `json:"name"`
+	Code        *string     `json:"code,omitempty"`
+	Description string      `json:"description"`
+	Discount    json.Number `json:"discount"`
+	AlbumIDs    []int64     `json:"album_ids"`
+}
+
+type Response struct {
+	CartID   int64       `json:"cart_id"`
+	Currency string      `json:"currency"`
+	Lines    []Line      `json:"lines"`
+	Applied  []Applied   `json:"applied"`
+	Subtotal json.Number `json:"subtotal"`
+	Discount json.Number `json:"discount"`
+	Total    json.Number `json:"total"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	pricing, err := h.promotionService.PriceCart(c.Request().Context(), in.ID, in.Code, in.Currency)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, Response{
+		CartID:   in.ID,
+		Currency: pricing.Currency,
+		Lines: lo.Map(pricing.Lines, func(l entity.PricedLine, _ int) Line {
+			return Line{
+				AlbumID:      l.AlbumID,
+				Price:        json.Number(l.Price.Decimal()),
+				Quantity:     l.Quantity,
+				Subtotal:     json.Number(l.Subtotal().Decimal()),
+				Discount:     json.Number(l.Discount.Decimal()),
+				Total:        json.Number(l.Total().Decimal()),
+				PromotionIDs: lo.Ternary(l.PromotionIDs == nil, []int64{}, l.PromotionIDs),
+			}
+		}),
+		Applied: lo.Map(pricing.Applied, func(a entity.AppliedPromotion, _ int) Applied {
+			return Applied{
+				PromotionID: a.PromotionID,
+				Name:        a.Name,
+				Code:        a.Code,
+				Description: a.Description,
+				Discount:    json.Number(a.Discount.Decimal()),
+				AlbumIDs:    a.AlbumIDs,
+			}
+		}),
+		Subtotal: json.Number(pricing.Subtotal().Decimal()),
+		Discount: json.Number(pricing.Discount().Decimal()),
+		Total:    json.Number(pricing.Total().Decimal()),
+	})
+}
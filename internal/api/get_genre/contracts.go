@@ -0,0 +1,11 @@
+package get_genre
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type GenreService interface {
+	FindById(ctx context.Context, id int64) (entity.Genre, error)
+}
@@ -0,0 +1,15 @@
+package get_genre_albums
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type GenreService interface {
+	FindById(ctx context.Context, id int64) (entity.Genre, error)
+}
+
+type AlbumsService interface {
+	FindAll(ctx context.Context, filter entity.AlbumFilter) (entity.AlbumPage, error)
+}
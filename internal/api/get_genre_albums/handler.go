@@ -0,0 +1,65 @@
+package get_genre_albums
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/api/get_albums"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	genreService  GenreService
+	albumsService AlbumsService
+}
+
+func New(gs GenreService, albumsService AlbumsService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		genreService:  gs,
+		albumsService: albumsService,
+	})
+}
+
+type Request struct {
+	ID     int64 `param:"id" validate:"required"`
+	Limit  int   `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor int64 `query:"cursor" validate:"omitempty,min=1"`
+	// IncludeSubgenres - включить альбомы всех поджанров
+	IncludeSubgenres bool `query:"include_subgenres"`
+	// Currency - валюта цен в ответе, по умолчанию базовая
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	ctx := c.Request().Context()
+
+	if _, err := h.genreService.FindById(ctx, in.ID); err != nil {
+		return err
+	}
+
+	out, err := h.albumsService.FindAll(ctx, entity.AlbumFilter{
+		GenreIDs:         []int64{in.ID},
+		IncludeSubgenres: in.IncludeSubgenres,
+		Limit:            in.Limit,
+		Cursor:           in.Cursor,
+		Currency:         in.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	response := get_albums.Response{
+		Albums: lo.Map(out.Albums, func(album entity.Album, _ int) get_albums.Album {
+			return get_albums.ToAlbum(album)
+		}),
+		Total: out.Total,
+	}
+	if out.NextCursor != 0 {
+		response.NextCursor = &out.NextCursor
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -7,5 +7,6 @@ import (
 )
 
 type GenreService interface {
-	FindAll(ctx context.Context) (genres []entity.Genre, err error)
+	FindAll(ctx context.Context, includeDeleted bool) (genres []entity.Genre, err error)
+	FindTree(ctx context.Context, includeDeleted bool) ([]entity.GenreNode, error)
 }
@@ -2,6 +2,7 @@ package get_genres
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/decorator"
@@ -19,18 +20,35 @@ func New(gs GenreService) api.Handler {
 	})
 }
 
-type Request struct{}
+type Request struct {
+	// Tree - вернуть жанры вложенным деревом вместо плоского списка
+	Tree bool `query:"tree"`
+	// IncludeDeleted доступен только администраторам, проверяется в роутере
+	IncludeDeleted bool `query:"include_deleted"`
+}
 
 type Response struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	ParentID   *int64     `json:"parent_id"`
+	AlbumCount int64      `json:"album_count"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+}
+
+// TreeResponse - жанр с вложенными поджанрами
+type TreeResponse struct {
+	Response
+	Children []TreeResponse `json:"children"`
 }
 
 // ToResponse преобразует entity в DTO
 func ToResponse(g entity.Genre) Response {
 	return Response{
-		ID:   g.ID,
-		Name: g.Name,
+		ID:         g.ID,
+		Name:       g.Name,
+		ParentID:   g.ParentID,
+		AlbumCount: g.AlbumCount,
+		DeletedAt:  g.DeletedAt,
 	}
 }
 
@@ -43,10 +61,30 @@ func ToResponseList(genres []entity.Genre) []Response {
 	return result
 }
 
+// ToTreeResponse преобразует дерево entities в дерево DTO
+func ToTreeResponse(nodes []entity.GenreNode) []TreeResponse {
+	result := make([]TreeResponse, len(nodes))
+	for i, n := range nodes {
+		result[i] = TreeResponse{
+			Response: ToResponse(n.Genre),
+			Children: ToTreeResponse(n.Children),
+		}
+	}
+	return result
+}
+
 func (h *handler) Handle(c echo.Context, in Request) error {
-	genres, err := h.genreService.FindAll(c.Request().Context())
+	if in.Tree {
+		tree, err := h.genreService.FindTree(c.Request().Context(), in.IncludeDeleted)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, ToTreeResponse(tree))
+	}
+
+	genres, err := h.genreService.FindAll(c.Request().Context(), in.IncludeDeleted)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	return c.JSON(http.StatusOK, ToResponseList(genres))
 }
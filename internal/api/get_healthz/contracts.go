@@ -0,0 +1,7 @@
+package get_healthz
+
+import "github.com/4udiwe/musicshop/internal/entity"
+
+type HealthService interface {
+	Live() entity.HealthReport
+}
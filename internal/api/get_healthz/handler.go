@@ -0,0 +1,26 @@
+package get_healthz
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/api/get_readyz"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	healthService HealthService
+}
+
+func New(healthService HealthService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		healthService: healthService,
+	})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	return c.JSON(http.StatusOK, get_readyz.ToResponse(h.healthService.Live()))
+}
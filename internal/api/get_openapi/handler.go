@@ -0,0 +1,27 @@
+package get_openapi
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/openapi"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	document *openapi.Document
+}
+
+// New отдает спецификацию, построенную один раз при старте
+func New(document *openapi.Document) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		document: document,
+	})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	return c.JSON(http.StatusOK, h.document)
+}
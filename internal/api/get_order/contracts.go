@@ -0,0 +1,11 @@
+package get_order
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type OrderService interface {
+	FindById(ctx context.Context, id int64) (entity.Order, error)
+}
@@ -0,0 +1,82 @@
+package get_order
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	orderService OrderService
+}
+
+func New(os OrderService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		orderService: os,
+	})
+}
+
+type Request struct {
+	ID int64 `param:"id" validate:"required"`
+}
+
+type Item struct {
+	AlbumID  int64       `json:"album_id,omitempty"`
+	Format   string      `json:"format,omitempty"`
+	Title    string      `json:"title"`
+	Artist   string      `json:"artist"`
+	Price    json.Number `json:"price"`
+	Quantity int         `json:"quantity"`
+	Discount json.Number `json:"discount"`
+}
+
+type Response struct {
+	ID        int64       `json:"id"`
+	Status    string      `json:"status"`
+	Items     []Item      `json:"items"`
+	PromoCode *string     `json:"promo_code,omitempty"`
+	Discount  json.Number `json:"discount"`
+	Total     json.Number `json:"total"`
+	Currency  string      `json:"currency"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// ToResponse преобразует entity в DTO
+func ToResponse(order entity.Order) Response {
+	return Response{
+		ID:     order.ID,
+		Status: string(order.Status),
+		Items: lo.Map(order.Items, func(item entity.OrderItem, _ int) Item {
+			return Item{
+				AlbumID:  item.AlbumID,
+				Format:   item.Format,
+				Title:    item.Title,
+				Artist:   item.Artist,
+				Price:    json.Number(item.Price.Decimal()),
+				Quantity: item.Quantity,
+				Discount: json.Number(item.Discount.Decimal()),
+			}
+		}),
+		PromoCode: order.PromoCode,
+		Discount:  json.Number(order.Discount().Decimal()),
+		Total:     json.Number(order.Total().Decimal()),
+		Currency:  order.Currency,
+		CreatedAt: order.CreatedAt,
+		UpdatedAt: order.UpdatedAt,
+	}
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	order, err := h.orderService.FindById(c.Request().Context(), in.ID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, ToResponse(order))
+}
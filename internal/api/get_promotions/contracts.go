@@ -0,0 +1,11 @@
+package get_promotions
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type PromotionService interface {
+	FindAll(ctx context.Context) ([]entity.Promotion, error)
+}
@@ -0,0 +1,81 @@
+package get_promotions
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	promotionService PromotionService
+}
+
+func New(ps PromotionService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		promotionService: ps,
+	})
+}
+
+type Request struct{}
+
+type Response struct {
+	ID           int64       `json:"id"`
+	Name         string      `json:"name"`
+	Code         *string     `json:"code"`
+	Kind         string      `json:"kind"`
+	Description  string      `json:"description"`
+	Percent      int         `json:"percent,omitempty"`
+	Amount       json.Number `json:"amount,omitempty"`
+	Currency     string      `json:"currency,omitempty"`
+	BuyQuantity  int         `json:"buy_quantity,omitempty"`
+	FreeQuantity int         `json:"free_quantity,omitempty"`
+	Target       string      `json:"target"`
+	TargetIDs    []int64     `json:"target_ids"`
+	StartsAt     time.Time   `json:"starts_at"`
+	EndsAt       *time.Time  `json:"ends_at"`
+	UsageLimit   *int        `json:"usage_limit"`
+	UsageCount   int         `json:"usage_count"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// ToResponse преобразует entity в DTO
+func ToResponse(p entity.Promotion) Response {
+	response := Response{
+		ID:           p.ID,
+		Name:         p.Name,
+		Code:         p.Code,
+		Kind:         string(p.Kind),
+		Description:  p.Describe(),
+		Percent:      p.Percent,
+		BuyQuantity:  p.BuyQuantity,
+		FreeQuantity: p.FreeQuantity,
+		Target:       string(p.Target),
+		TargetIDs:    p.TargetIDs,
+		StartsAt:     p.StartsAt,
+		EndsAt:       p.EndsAt,
+		UsageLimit:   p.UsageLimit,
+		UsageCount:   p.UsageCount,
+		CreatedAt:    p.CreatedAt,
+	}
+	if p.Kind == entity.PromotionFixed {
+		response.Amount = json.Number(p.Amount.Decimal())
+		response.Currency = p.Amount.Currency
+	}
+	return response
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	promotions, err := h.promotionService.FindAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, lo.Map(promotions, func(p entity.Promotion, _ int) Response {
+		return ToResponse(p)
+	}))
+}
@@ -0,0 +1,11 @@
+package get_readyz
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type HealthService interface {
+	Ready(ctx context.Context) entity.HealthReport
+}
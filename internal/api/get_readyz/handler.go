@@ -0,0 +1,59 @@
+package get_readyz
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	healthService HealthService
+}
+
+func New(healthService HealthService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		healthService: healthService,
+	})
+}
+
+type Request struct{}
+
+type Check struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Response struct {
+	Status string  `json:"status"`
+	Checks []Check `json:"checks"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	report := h.healthService.Ready(c.Request().Context())
+
+	status := http.StatusOK
+	if report.Status != entity.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	return c.JSON(status, ToResponse(report))
+}
+
+func ToResponse(report entity.HealthReport) Response {
+	return Response{
+		Status: string(report.Status),
+		Checks: lo.Map(report.Checks, func(check entity.HealthCheck, _ int) Check {
+			return Check{
+				Name:      check.Name,
+				Status:    string(check.Status),
+				LatencyMs: float64(check.Latency.Microseconds()) / 1000,
+				Error:     check.Error,
+			}
+		}),
+	}
+}
@@ -0,0 +1,11 @@
+package get_search
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type AlbumsService interface {
+	Search(ctx context.Context, search entity.AlbumSearch) (entity.AlbumPage, error)
+}
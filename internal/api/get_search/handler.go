@@ -0,0 +1,53 @@
+package get_search
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/api/get_albums"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	albumsService AlbumsService
+}
+
+func New(albumsService AlbumsService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		albumsService: albumsService,
+	})
+}
+
+type Request struct {
+	Q      string `query:"q" validate:"required,max=200"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor int64  `query:"cursor" validate:"omitempty,min=1"`
+	// Currency - валюта цен в ответе, по умолчанию базовая
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	out, err := h.albumsService.Search(c.Request().Context(), entity.AlbumSearch{
+		Query:    in.Q,
+		Limit:    in.Limit,
+		Cursor:   in.Cursor,
+		Currency: in.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	albums := lo.Map(out.Albums, func(album entity.Album, _ int) get_albums.Album {
+		return get_albums.ToAlbum(album)
+	})
+
+	response := get_albums.Response{Albums: albums, Total: out.Total}
+	if out.NextCursor != 0 {
+		response.NextCursor = &out.NextCursor
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
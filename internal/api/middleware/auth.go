@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/labstack/echo/v4"
+)
+
+type TokenParser interface {
+	Parse(token string) (auth.Principal, error)
+}
+
+// Authenticate кладет пользователя из заголовка Authorization в контекст запроса.
+// Запросы без заголовка пропускаются анонимно, с невалидным токеном - отклоняются.
+func Authenticate(p TokenParser) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				return next(c)
+			}
+
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || token == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "malformed authorization header")
+			}
+
+			principal, err := p.Parse(token)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid access token")
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), principal)))
+			withUser(c, principal)
+			return next(c)
+		}
+	}
+}
+
+// RequireUser отклоняет анонимные запросы
+func RequireUser() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := auth.PrincipalFromContext(c.Request().Context()); !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+			}
+			return next(c)
+		}
+	}
+}
+
+// Require пропускает только пользователей, чья роль имеет разрешение:
+// анонимным возвращается 401, остальным - 403
+func Require(permission auth.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := auth.PrincipalFromContext(c.Request().Context())
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+			}
+			if !principal.Can(permission) {
+				return echo.NewHTTPError(http.StatusForbidden, "insufficient permissions")
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireWhen требует разрешение только у запросов, для которых when возвращает true,
+// например у публичных маршрутов со служебными query-параметрами
+func RequireWhen(permission auth.Permission, when func(c echo.Context) bool) echo.MiddlewareFunc {
+	require := Require(permission)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		guarded := require(next)
+		return func(c echo.Context) error {
+			if when(c) {
+				return guarded(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// QueryFlag сообщает, включен ли булев query-параметр
+func QueryFlag(name string) func(c echo.Context) bool {
+	return func(c echo.Context) bool {
+		on, _ := strconv.ParseBool(c.QueryParam(name))
+		return on
+	}
+}
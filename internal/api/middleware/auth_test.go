@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api/middleware"
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticate(t *testing.T) {
+	manager := auth.NewManager([]byte("test-signing-key"), time.Minute)
+	foreign := auth.NewManager([]byte("another-key"), time.Minute)
+	expired := auth.NewManager([]byte("test-signing-key"), -time.Minute)
+
+	mint := func(m *auth.Manager) string {
+		token, _, err := m.Issue(entity.User{ID: 42, Email: "user@example.com"})
+		assert.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	e := echo.New()
+	e.Use(middleware.Authenticate(manager))
+	e.GET("/me", func(c echo.Context) error {
+		p, ok := auth.PrincipalFromContext(c.Request().Context())
+		if !ok {
+			return c.String(http.StatusOK, "anonymous")
+		}
+		return c.String(http.StatusOK, p.Email)
+	})
+	e.DELETE("/me", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, middleware.RequireUser())
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		header     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "anonymous", method: http.MethodGet, wantStatus: http.StatusOK, wantBody: "anonymous"},
+		{name: "valid token", method: http.MethodGet, header: mint(manager), wantStatus: http.StatusOK, wantBody: "user@example.com"},
+		{name: "foreign key", method: http.MethodGet, header: mint(foreign), wantStatus: http.StatusUnauthorized},
+		{name: "expired token", method: http.MethodGet, header: mint(expired), wantStatus: http.StatusUnauthorized},
+		{name: "malformed header", method: http.MethodGet, header: "Token abc", wantStatus: http.StatusUnauthorized},
+		{name: "require user anonymous", method: http.MethodDelete, wantStatus: http.StatusUnauthorized},
+		{name: "require user authenticated", method: http.MethodDelete, header: mint(manager), wantStatus: http.StatusNoContent},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/me", nil)
+			if tc.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tc.header)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/logger"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const maxRequestIDLength = 128
+
+// RequestID принимает X-Request-ID клиента или создает новый, возвращает его в ответе
+// и кладет в логгер контекста. Пустой, слишком длинный или содержащий
+// непечатные символы заголовок заменяется, чтобы не попасть в логи как есть.
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestID(id) {
+				id = newRequestID()
+				req.Header.Set(echo.HeaderXRequestID, id)
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+
+			c.SetRequest(req.WithContext(logger.WithFields(req.Context(), log.Fields{"request_id": id})))
+			return next(c)
+		}
+	}
+}
+
+// AccessLog пишет журнал каждого запроса и добавляет в логгер контекста маршрут и trace_id.
+// Успешные запросы пишутся через один на sampleEvery, ответы 4xx и 5xx - всегда.
+func AccessLog(sampleEvery int) echo.MiddlewareFunc {
+	var counter atomic.Uint64
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			req := c.Request()
+			fields := log.Fields{"method": req.Method, "route": c.Path()}
+			if span := trace.SpanContextFromContext(req.Context()); span.IsValid() {
+				fields["trace_id"] = span.TraceID().String()
+			}
+			c.SetRequest(req.WithContext(logger.WithFields(req.Context(), fields)))
+
+			// причину ошибки пишет обработчик ошибок, здесь только итог запроса
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			if status < http.StatusBadRequest && sampleEvery > 1 && (counter.Add(1)-1)%uint64(sampleEvery) != 0 {
+				return nil
+			}
+
+			// логгер берется после обработки: Authenticate добавляет в него пользователя
+			entry := logger.FromContext(c.Request().Context()).WithFields(log.Fields{
+				"path":        req.URL.Path,
+				"status":      status,
+				"duration_ms": time.Since(start).Milliseconds(),
+				"bytes_out":   c.Response().Size,
+			})
+
+			switch {
+			case status >= http.StatusInternalServerError:
+				entry.Error("request completed")
+			case status >= http.StatusBadRequest:
+				entry.Warn("request completed")
+			default:
+				entry.Info("request completed")
+			}
+			return nil
+		}
+	}
+}
+
+// withUser добавляет пользователя в логгер контекста
+func withUser(c echo.Context, principal auth.Principal) {
+	req := c.Request()
+	c.SetRequest(req.WithContext(logger.WithFields(req.Context(), log.Fields{"user_id": principal.UserID})))
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
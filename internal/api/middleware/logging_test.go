@@ -0,0 +1,105 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api/middleware"
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/logger"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.RequestID())
+	e.GET("/ping", func(c echo.Context) error {
+		return c.String(http.StatusOK, logger.FromContext(c.Request().Context()).Data["request_id"].(string))
+	})
+
+	for _, tc := range []struct {
+		name     string
+		header   string
+		wantKept bool
+	}{
+		{name: "propagated", header: "req-42", wantKept: true},
+		{name: "generated when missing", header: ""},
+		{name: "replaced when too long", header: strings.Repeat("a", 129)},
+		{name: "replaced when not printable", header: "req 42"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			if tc.header != "" {
+				req.Header.Set(echo.HeaderXRequestID, tc.header)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(echo.HeaderXRequestID)
+			assert.NotEmpty(t, id)
+			assert.Equal(t, id, rec.Body.String())
+			assert.Equal(t, tc.wantKept, id == tc.header)
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	hook := test.NewGlobal()
+	level := log.GetLevel()
+	log.SetLevel(log.InfoLevel)
+	defer log.SetLevel(level)
+
+	manager := auth.NewManager([]byte("test-signing-key"), time.Minute)
+	token, _, err := manager.Issue(entity.User{ID: 7, Email: "user@example.com"})
+	assert.NoError(t, err)
+
+	e := echo.New()
+	e.Use(middleware.RequestID())
+	e.Use(middleware.AccessLog(2))
+	e.Use(middleware.Authenticate(manager))
+	e.GET("/albums/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.POST("/albums", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	})
+
+	serve := func(method, path string) {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(echo.HeaderXRequestID, "req-1")
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// из четырех успешных запросов пишется каждый второй, ошибки - все
+	for range 4 {
+		serve(http.MethodGet, "/albums/1")
+	}
+	serve(http.MethodPost, "/albums")
+	serve(http.MethodPost, "/albums")
+
+	entries := hook.AllEntries()
+	if assert.Len(t, entries, 4) {
+		assert.Equal(t, log.Fields{
+			"request_id":  "req-1",
+			"method":      http.MethodGet,
+			"route":       "/albums/:id",
+			"path":        "/albums/1",
+			"user_id":     int64(7),
+			"status":      http.StatusOK,
+			"duration_ms": entries[0].Data["duration_ms"],
+			"bytes_out":   int64(0),
+		}, entries[0].Data)
+		assert.Equal(t, log.InfoLevel, entries[0].Level)
+		assert.Equal(t, http.StatusBadRequest, entries[3].Data["status"])
+		assert.Equal(t, log.WarnLevel, entries[3].Level)
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute - метка запросов, не попавших ни в один маршрут
+const unmatchedRoute = "unmatched"
+
+// RequestObserver принимает замер обработанного запроса
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// Metrics замеряет запросы с меткой шаблона маршрута (/albums/:id), а не пути.
+// Ошибка обработчика передается в обработчик ошибок echo сразу, чтобы учесть итоговый статус.
+func Metrics(o RequestObserver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			o.ObserveRequest(c.Request().Method, route, c.Response().Status, time.Since(start))
+			return nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type observation struct {
+	method, route string
+	status        int
+}
+
+type recordingObserver struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (o *recordingObserver) ObserveRequest(method, route string, status int, _ time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.observations = append(o.observations, observation{method, route, status})
+}
+
+func TestMetrics(t *testing.T) {
+	observer := &recordingObserver{}
+
+	e := echo.New()
+	e.Use(middleware.Metrics(observer))
+	e.GET("/albums/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.POST("/albums", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	})
+
+	for _, tc := range []struct {
+		method, path string
+		want         observation
+	}{
+		{http.MethodGet, "/albums/1", observation{http.MethodGet, "/albums/:id", http.StatusOK}},
+		{http.MethodGet, "/albums/2", observation{http.MethodGet, "/albums/:id", http.StatusOK}},
+		{http.MethodPost, "/albums", observation{http.MethodPost, "/albums", http.StatusBadRequest}},
+		{http.MethodGet, "/unknown/1", observation{http.MethodGet, "unmatched", http.StatusNotFound}},
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+
+		assert.Equal(t, tc.want.status, rec.Code)
+		assert.Equal(t, tc.want, observer.observations[len(observer.observations)-1])
+	}
+}
@@ -0,0 +1,11 @@
+package patch_album
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type AlbumsService interface {
+	Patch(ctx context.Context, id int64, patch entity.AlbumPatch) error
+}
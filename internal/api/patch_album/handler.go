@@ -0,0 +1,66 @@
+package patch_album
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	albumsService AlbumsService
+}
+
+func New(albumsService AlbumsService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		albumsService: albumsService,
+	})
+}
+
+type Genre struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+// Request содержит только переданные поля, отсутствующие поля не изменяются.
+// Price альбома с SKU выводится из них, см. put_album.Request.
+type Request struct {
+	ID       int64        `param:"id" validate:"required"`
+	Title    *string      `json:"title" validate:"omitempty,min=2"`
+	Artist   *string      `json:"artist" validate:"omitempty,min=2"`
+	ArtistID *int64       `json:"artist_id" validate:"omitempty,min=1"`
+	Price    *json.Number `json:"price"` // в базовой валюте
+	Genres   []Genre      `json:"genres" validate:"omitempty,dive"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	patch := entity.AlbumPatch{
+		Title:    in.Title,
+		Artist:   in.Artist,
+		ArtistID: in.ArtistID,
+	}
+	if in.Price != nil {
+		price, err := entity.ParsePrice(in.Price.String(), entity.BaseCurrency)
+		if err != nil {
+			return err
+		}
+		patch.Price = &price
+	}
+	if in.Genres != nil {
+		patch.Genres = lo.Map(in.Genres, func(g Genre, i int) entity.Genre {
+			return entity.Genre{
+				ID: g.ID,
+			}
+		})
+	}
+
+	err := h.albumsService.Patch(c.Request().Context(), in.ID, patch)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
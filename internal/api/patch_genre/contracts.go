@@ -0,0 +1,11 @@
+package patch_genre
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type GenreService interface {
+	Patch(ctx context.Context, id int64, patch entity.GenrePatch) error
+}
@@ -0,0 +1,39 @@
+package patch_genre
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	genreService GenreService
+}
+
+func New(gs GenreService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		genreService: gs,
+	})
+}
+
+// Request содержит только переданные поля, отсутствующие поля не изменяются.
+// parent_id = 0 делает жанр корневым.
+type Request struct {
+	ID       int64   `param:"id" validate:"required"`
+	Name     *string `json:"name" validate:"omitempty,min=3"`
+	ParentID *int64  `json:"parent_id" validate:"omitempty,gte=0"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.genreService.Patch(c.Request().Context(), in.ID, entity.GenrePatch{
+		Name:     in.Name,
+		ParentID: in.ParentID,
+	})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
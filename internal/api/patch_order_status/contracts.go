@@ -0,0 +1,11 @@
+package patch_order_status
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type OrderService interface {
+	ChangeStatus(ctx context.Context, id int64, status entity.OrderStatus) error
+}
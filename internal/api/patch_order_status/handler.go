@@ -0,0 +1,33 @@
+package patch_order_status
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	orderService OrderService
+}
+
+func New(os OrderService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		orderService: os,
+	})
+}
+
+type Request struct {
+	ID     int64  `param:"id" validate:"required"`
+	Status string `json:"status" validate:"required,oneof=pending paid shipped cancelled refunded"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.orderService.ChangeStatus(c.Request().Context(), in.ID, entity.OrderStatus(in.Status))
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
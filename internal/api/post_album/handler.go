@@ -1,13 +1,12 @@
 package post_album
 
 import (
-	"errors"
+	"encoding/json"
 	"net/http"
 
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/decorator"
 	"github.com/4udiwe/musicshop/internal/entity"
-	service "github.com/4udiwe/musicshop/internal/service/albums"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
 )
@@ -27,10 +26,11 @@ type Genre struct {
 }
 
 type Request struct {
-	Title  string  `json:"title" validate:"required,min=2"`
-	Artist string  `json:"artist" validate:"required,min=2"`
-	Price  float64 `json:"price" validate:"required"`
-	Genres []Genre `json:"genres"`
+	Title    string      `json:"title" validate:"required,min=2"`
+	ArtistID int64       `json:"artist_id"`
+	Artist   string      `json:"artist" validate:"required_without=ArtistID,omitempty,min=2"` // устаревший формат, используйте artist_id
+	Price    json.Number `json:"price" validate:"required"`                                   // в базовой валюте, число или строка
+	Genres   []Genre     `json:"genres"`
 }
 
 type Response struct {
@@ -38,10 +38,16 @@ type Response struct {
 }
 
 func (h *handler) Handle(c echo.Context, in Request) error {
+	price, err := entity.ParsePrice(in.Price.String(), entity.BaseCurrency)
+	if err != nil {
+		return err
+	}
+
 	album := entity.Album{
-		Title:  in.Title,
-		Artist: in.Artist,
-		Price:  in.Price,
+		Title:    in.Title,
+		ArtistID: in.ArtistID,
+		Artist:   in.Artist,
+		Price:    price,
 		Genres: lo.Map(in.Genres, func(g Genre, i int) entity.Genre {
 			return entity.Genre{
 				ID: g.ID,
@@ -50,13 +56,7 @@ func (h *handler) Handle(c echo.Context, in Request) error {
 	}
 	id, err := h.albumsService.Create(c.Request().Context(), album)
 	if err != nil {
-		if errors.Is(err, service.ErrAlbumAlreadyExists) {
-			return echo.NewHTTPError(http.StatusConflict, err.Error())
-		}
-		if errors.Is(err, service.ErrGenreNotExists) {
-			return echo.NewHTTPError(http.StatusNotFound, err.Error())
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, Response{ID: id})
@@ -0,0 +1,12 @@
+package post_album_price
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type PriceService interface {
+	Schedule(ctx context.Context, albumID int64, price entity.Money, from time.Time) (entity.AlbumPrice, error)
+}
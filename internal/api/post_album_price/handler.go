@@ -0,0 +1,64 @@
+package post_album_price
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	priceService PriceService
+}
+
+func New(ps PriceService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		priceService: ps,
+	})
+}
+
+type Request struct {
+	AlbumID int64       `param:"id" validate:"required"`
+	Price   json.Number `json:"price" validate:"required"`
+	// Currency - валюта цены, по умолчанию базовая
+	Currency string `json:"currency" validate:"omitempty,iso4217"`
+	// EffectiveFrom - начало действия цены, без него цена действует сразу
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+type Response struct {
+	ID             int64       `json:"id"`
+	AlbumID        int64       `json:"album_id"`
+	Price          json.Number `json:"price"`
+	Currency       string      `json:"currency"`
+	EffectiveFrom  time.Time   `json:"effective_from"`
+	EffectiveUntil *time.Time  `json:"effective_until"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	currency := in.Currency
+	if currency == "" {
+		currency = entity.BaseCurrency
+	}
+	amount, err := entity.ParsePrice(in.Price.String(), currency)
+	if err != nil {
+		return err
+	}
+
+	price, err := h.priceService.Schedule(c.Request().Context(), in.AlbumID, amount, in.EffectiveFrom)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, Response{
+		ID:             price.ID,
+		AlbumID:        price.AlbumID,
+		Price:          json.Number(price.Price.Decimal()),
+		Currency:       price.Price.Currency,
+		EffectiveFrom:  price.EffectiveFrom,
+		EffectiveUntil: price.EffectiveUntil,
+	})
+}
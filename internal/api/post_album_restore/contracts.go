@@ -0,0 +1,7 @@
+package post_album_restore
+
+import "context"
+
+type AlbumsService interface {
+	Restore(ctx context.Context, id int64) error
+}
@@ -0,0 +1,29 @@
+package post_album_restore
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	albumsService AlbumsService
+}
+
+func New(albumsService AlbumsService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{albumsService: albumsService})
+}
+
+type Request struct {
+	ID int64 `param:"id" validate:"required"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.albumsService.Restore(c.Request().Context(), in.ID)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
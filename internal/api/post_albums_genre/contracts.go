@@ -1,7 +1,11 @@
 package post_albums_genre
 
-import "context"
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
 
 type GenreService interface {
-	AddGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) error
+	AddGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error)
 }
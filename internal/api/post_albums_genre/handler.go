@@ -1,12 +1,10 @@
 package post_albums_genre
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/decorator"
-	service "github.com/4udiwe/musicshop/internal/service/genres"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
 )
@@ -27,19 +25,32 @@ type Genre struct {
 
 type Request struct {
 	AlbumID int64   `param:"id" validate:"required"`
-	Genres  []Genre `json:"genres"`
+	Genres  []Genre `json:"genres" validate:"dive"`
+}
+
+// Response перечисляет, какие жанры были привязаны, какие уже были у альбома и каких не существует
+type Response struct {
+	Added    []int64 `json:"added"`
+	Existing []int64 `json:"existing"`
+	Missing  []int64 `json:"missing"`
 }
 
 func (h *handler) Handle(c echo.Context, in Request) error {
 	genreIDs := lo.Map(in.Genres, func(g Genre, i int) int64 {
 		return g.ID
 	})
-	err := h.genreService.AddGenresToAlbum(c.Request().Context(), in.AlbumID, genreIDs...)
+	links, err := h.genreService.AddGenresToAlbum(c.Request().Context(), in.AlbumID, genreIDs...)
 	if err != nil {
-		if errors.Is(err, service.ErrCannotAddConstraintAlbumGenre) {
-			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	return c.NoContent(http.StatusCreated)
+
+	status := http.StatusOK
+	if len(links.Added) > 0 {
+		status = http.StatusCreated
+	}
+	return c.JSON(status, Response{
+		Added:    links.Added,
+		Existing: links.Existing,
+		Missing:  links.Missing,
+	})
 }
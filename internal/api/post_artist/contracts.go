@@ -0,0 +1,11 @@
+package post_artist
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type ArtistService interface {
+	Create(ctx context.Context, artist entity.Artist) (int64, error)
+}
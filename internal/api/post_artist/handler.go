@@ -0,0 +1,38 @@
+package post_artist
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	artistService ArtistService
+}
+
+func New(as ArtistService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		artistService: as,
+	})
+}
+
+type Request struct {
+	Name string `json:"name" validate:"required,min=2"`
+	Bio  string `json:"bio"`
+}
+
+type Response struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	id, err := h.artistService.Create(c.Request().Context(), entity.Artist{Name: in.Name, Bio: in.Bio})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, Response{ID: id, Name: in.Name})
+}
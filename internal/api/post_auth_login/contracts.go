@@ -0,0 +1,11 @@
+package post_auth_login
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type UserService interface {
+	Login(ctx context.Context, email, password string) (entity.TokenPair, error)
+}
@@ -0,0 +1,51 @@
+package post_auth_login
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	userService UserService
+}
+
+func New(us UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		userService: us,
+	})
+}
+
+type Request struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type Response struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// ToResponse преобразует пару токенов в DTO
+func ToResponse(pair entity.TokenPair) Response {
+	return Response{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    pair.ExpiresAt,
+	}
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	pair, err := h.userService.Login(c.Request().Context(), in.Email, in.Password)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, ToResponse(pair))
+}
@@ -0,0 +1,11 @@
+package post_auth_refresh
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type UserService interface {
+	Refresh(ctx context.Context, refreshToken string) (entity.TokenPair, error)
+}
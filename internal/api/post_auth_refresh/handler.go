@@ -0,0 +1,32 @@
+package post_auth_refresh
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/api/post_auth_login"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	userService UserService
+}
+
+func New(us UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		userService: us,
+	})
+}
+
+type Request struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	pair, err := h.userService.Refresh(c.Request().Context(), in.RefreshToken)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, post_auth_login.ToResponse(pair))
+}
@@ -0,0 +1,7 @@
+package post_auth_register
+
+import "context"
+
+type UserService interface {
+	Register(ctx context.Context, email, password string) (int64, error)
+}
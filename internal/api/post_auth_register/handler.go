@@ -0,0 +1,37 @@
+package post_auth_register
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	userService UserService
+}
+
+func New(us UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		userService: us,
+	})
+}
+
+type Request struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type Response struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	id, err := h.userService.Register(c.Request().Context(), in.Email, in.Password)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, Response{ID: id, Email: in.Email})
+}
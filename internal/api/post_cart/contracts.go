@@ -0,0 +1,7 @@
+package post_cart
+
+import "context"
+
+type CartService interface {
+	Create(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,33 @@
+package post_cart
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	cartService CartService
+}
+
+func New(cs CartService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		cartService: cs,
+	})
+}
+
+type Request struct{}
+
+type Response struct {
+	ID int64 `json:"id"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	id, err := h.cartService.Create(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, Response{ID: id})
+}
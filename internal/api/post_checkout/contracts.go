@@ -0,0 +1,7 @@
+package post_checkout
+
+import "context"
+
+type OrderService interface {
+	Checkout(ctx context.Context, cartID int64, currency, promoCode string) (int64, error)
+}
@@ -0,0 +1,39 @@
+package post_checkout
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	orderService OrderService
+}
+
+func New(os OrderService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		orderService: os,
+	})
+}
+
+type Request struct {
+	CartID int64 `param:"id" validate:"required"`
+	// Currency - валюта заказа, по умолчанию базовая
+	Currency string `json:"currency" validate:"omitempty,iso4217"`
+	// PromoCode - необязательный код акции
+	PromoCode string `json:"promo_code" validate:"omitempty,max=64"`
+}
+
+type Response struct {
+	OrderID int64 `json:"order_id"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	id, err := h.orderService.Checkout(c.Request().Context(), in.CartID, in.Currency, in.PromoCode)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, Response{OrderID: id})
+}
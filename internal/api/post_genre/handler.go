@@ -1,13 +1,11 @@
 package post_genre
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/decorator"
 	"github.com/4udiwe/musicshop/internal/entity"
-	service "github.com/4udiwe/musicshop/internal/service/genres"
 	"github.com/labstack/echo/v4"
 )
 
@@ -22,21 +20,20 @@ func New(gs GenreService) api.Handler {
 }
 
 type Request struct {
-	Name string `json:"name" validate:"required,min=3"`
+	Name     string `json:"name" validate:"required,min=3"`
+	ParentID *int64 `json:"parent_id" validate:"omitempty,min=1"`
 }
 
 type Response struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parent_id"`
 }
 
 func (h *handler) Handle(c echo.Context, in Request) error {
-	id, err := h.genreService.Create(c.Request().Context(), entity.Genre{Name: in.Name})
+	id, err := h.genreService.Create(c.Request().Context(), entity.Genre{Name: in.Name, ParentID: in.ParentID})
 	if err != nil {
-		if errors.Is(err, service.ErrGenreAlreadyExists) {
-			return echo.NewHTTPError(http.StatusConflict, err.Error())
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	return c.JSON(http.StatusCreated, Response{ID: id, Name: in.Name})
+	return c.JSON(http.StatusCreated, Response{ID: id, Name: in.Name, ParentID: in.ParentID})
 }
@@ -0,0 +1,7 @@
+package post_genre_restore
+
+import "context"
+
+type GenreService interface {
+	Restore(ctx context.Context, id int64) error
+}
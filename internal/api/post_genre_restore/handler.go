@@ -0,0 +1,31 @@
+package post_genre_restore
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	genreService GenreService
+}
+
+func New(gs GenreService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		genreService: gs,
+	})
+}
+
+type Request struct {
+	ID int64 `param:"id" validate:"required"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.genreService.Restore(c.Request().Context(), in.ID)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
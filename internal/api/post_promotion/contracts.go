@@ -0,0 +1,11 @@
+package post_promotion
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type PromotionService interface {
+	Create(ctx context.Context, p entity.Promotion) (entity.Promotion, error)
+}
@@ -0,0 +1,77 @@
+package post_promotion
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/api/get_promotions"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	promotionService PromotionService
+}
+
+func New(ps PromotionService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		promotionService: ps,
+	})
+}
+
+// Request описывает акцию. Обязательность полей зависит от kind:
+// percent - percent, fixed - amount и currency, buy_x_get_y - buy_quantity и free_quantity.
+type Request struct {
+	Name string `json:"name" validate:"required,min=2"`
+	// Code - код купона, без него акция применяется автоматически
+	Code         *string     `json:"code" validate:"omitempty,alphanum,min=3,max=64"`
+	Kind         string      `json:"kind" validate:"required,oneof=percent fixed buy_x_get_y"`
+	Percent      int         `json:"percent" validate:"omitempty,min=1,max=100"`
+	Amount       json.Number `json:"amount"`
+	Currency     string      `json:"currency" validate:"omitempty,iso4217"`
+	BuyQuantity  int         `json:"buy_quantity" validate:"omitempty,min=1"`
+	FreeQuantity int         `json:"free_quantity" validate:"omitempty,min=1"`
+	Target       string      `json:"target" validate:"required,oneof=all genre artist album"`
+	TargetIDs    []int64     `json:"target_ids" validate:"omitempty,dive,min=1"`
+	// StartsAt - начало действия, по умолчанию сразу
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     *time.Time `json:"ends_at"`
+	UsageLimit *int       `json:"usage_limit" validate:"omitempty,min=1"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	promotion := entity.Promotion{
+		Name:         in.Name,
+		Code:         in.Code,
+		Kind:         entity.PromotionKind(in.Kind),
+		Percent:      in.Percent,
+		BuyQuantity:  in.BuyQuantity,
+		FreeQuantity: in.FreeQuantity,
+		Target:       entity.PromotionTarget(in.Target),
+		TargetIDs:    in.TargetIDs,
+		StartsAt:     in.StartsAt,
+		EndsAt:       in.EndsAt,
+		UsageLimit:   in.UsageLimit,
+	}
+
+	if in.Amount != "" {
+		currency := in.Currency
+		if currency == "" {
+			currency = entity.BaseCurrency
+		}
+		amount, err := entity.ParsePrice(in.Amount.String(), currency)
+		if err != nil {
+			return err
+		}
+		promotion.Amount = amount
+	}
+
+	created, err := h.promotionService.Create(c.Request().Context(), promotion)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, get_promotions.ToResponse(created))
+}
@@ -0,0 +1,11 @@
+package post_stock_adjustment
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type StockService interface {
+	Adjust(ctx context.Context, albumID int64, format string, delta int, reason string) (entity.SKU, error)
+}
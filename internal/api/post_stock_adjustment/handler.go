@@ -0,0 +1,39 @@
+package post_stock_adjustment
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	stockService StockService
+}
+
+func New(ss StockService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		stockService: ss,
+	})
+}
+
+type Request struct {
+	AlbumID int64  `param:"id" validate:"required"`
+	Format  string `param:"format" validate:"required,oneof=cd vinyl cassette digital"`
+	Delta   int    `json:"delta" validate:"required"`
+	Reason  string `json:"reason" validate:"required,min=3"`
+}
+
+type Response struct {
+	Format   string `json:"format"`
+	Quantity int    `json:"quantity"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	sku, err := h.stockService.Adjust(c.Request().Context(), in.AlbumID, in.Format, in.Delta, in.Reason)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, Response{Format: sku.Format, Quantity: sku.Quantity})
+}
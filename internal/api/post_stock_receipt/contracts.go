@@ -0,0 +1,11 @@
+package post_stock_receipt
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type StockService interface {
+	Receive(ctx context.Context, albumID int64, format string, quantity int) (entity.SKU, error)
+}
@@ -0,0 +1,38 @@
+package post_stock_receipt
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	stockService StockService
+}
+
+func New(ss StockService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		stockService: ss,
+	})
+}
+
+type Request struct {
+	AlbumID  int64  `param:"id" validate:"required"`
+	Format   string `param:"format" validate:"required,oneof=cd vinyl cassette digital"`
+	Quantity int    `json:"quantity" validate:"required,min=1"`
+}
+
+type Response struct {
+	Format   string `json:"format"`
+	Quantity int    `json:"quantity"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	sku, err := h.stockService.Receive(c.Request().Context(), in.AlbumID, in.Format, in.Quantity)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, Response{Format: sku.Format, Quantity: sku.Quantity})
+}
@@ -0,0 +1,11 @@
+package put_album
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type AlbumsService interface {
+	Update(ctx context.Context, album entity.Album) error
+}
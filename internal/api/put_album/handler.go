@@ -0,0 +1,63 @@
+package put_album
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	albumsService AlbumsService
+}
+
+func New(albumsService AlbumsService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		albumsService: albumsService,
+	})
+}
+
+type Genre struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+// Request заменяет альбом целиком. Price альбома с SKU - цена самого дешевого SKU,
+// другая цена отклоняется с кодом price_derived_from_skus.
+type Request struct {
+	ID       int64       `param:"id" validate:"required"`
+	Title    string      `json:"title" validate:"required,min=2"`
+	ArtistID int64       `json:"artist_id"`
+	Artist   string      `json:"artist" validate:"required_without=ArtistID,omitempty,min=2"` // устаревший формат, используйте artist_id
+	Price    json.Number `json:"price" validate:"required"`                                   // в базовой валюте, число или строка
+	Genres   []Genre     `json:"genres"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	price, err := entity.ParsePrice(in.Price.String(), entity.BaseCurrency)
+	if err != nil {
+		return err
+	}
+
+	album := entity.Album{
+		ID:       in.ID,
+		Title:    in.Title,
+		ArtistID: in.ArtistID,
+		Artist:   in.Artist,
+		Price:    price,
+		Genres: lo.Map(in.Genres, func(g Genre, i int) entity.Genre {
+			return entity.Genre{
+				ID: g.ID,
+			}
+		}),
+	}
+	err = h.albumsService.Update(c.Request().Context(), album)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,11 @@
+package put_album_genres
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type GenreService interface {
+	SetAlbumGenres(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error)
+}
@@ -0,0 +1,47 @@
+package put_album_genres
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/api/post_albums_genre"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	genreService GenreService
+}
+
+func New(gs GenreService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		genreService: gs,
+	})
+}
+
+type Request struct {
+	AlbumID int64                     `param:"id" validate:"required"`
+	Genres  []post_albums_genre.Genre `json:"genres" validate:"dive"`
+}
+
+type Response struct {
+	Added    []int64 `json:"added"`
+	Existing []int64 `json:"existing"`
+	Removed  []int64 `json:"removed"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	genreIDs := lo.Map(in.Genres, func(g post_albums_genre.Genre, i int) int64 {
+		return g.ID
+	})
+	links, err := h.genreService.SetAlbumGenres(c.Request().Context(), in.AlbumID, genreIDs...)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, Response{
+		Added:    links.Added,
+		Existing: links.Existing,
+		Removed:  links.Removed,
+	})
+}
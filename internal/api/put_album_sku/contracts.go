@@ -0,0 +1,11 @@
+package put_album_sku
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type StockService interface {
+	SetPrice(ctx context.Context, albumID int64, format string, price entity.Money) (entity.SKU, error)
+}
@@ -0,0 +1,54 @@
+package put_album_sku
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	stockService StockService
+}
+
+func New(ss StockService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		stockService: ss,
+	})
+}
+
+type Request struct {
+	AlbumID int64       `param:"id" validate:"required"`
+	Format  string      `param:"format" validate:"required,oneof=cd vinyl cassette digital"`
+	Price   json.Number `json:"price" validate:"required"` // в базовой валюте
+}
+
+type Response struct {
+	ID       int64       `json:"id"`
+	Format   string      `json:"format"`
+	Price    json.Number `json:"price"`
+	Currency string      `json:"currency"`
+	Quantity int         `json:"quantity"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	price, err := entity.ParsePrice(in.Price.String(), entity.BaseCurrency)
+	if err != nil {
+		return err
+	}
+
+	sku, err := h.stockService.SetPrice(c.Request().Context(), in.AlbumID, in.Format, price)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, Response{
+		ID:       sku.ID,
+		Format:   sku.Format,
+		Price:    json.Number(sku.Price.Decimal()),
+		Currency: sku.Price.Currency,
+		Quantity: sku.Quantity,
+	})
+}
@@ -0,0 +1,11 @@
+package put_album_tracks
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type AlbumsService interface {
+	ReplaceTracks(ctx context.Context, albumID int64, tracks []entity.Track) error
+}
@@ -0,0 +1,55 @@
+package put_album_tracks
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	albumsService AlbumsService
+}
+
+func New(albumsService AlbumsService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		albumsService: albumsService,
+	})
+}
+
+type Track struct {
+	DiscNumber int    `json:"disc_number" validate:"omitempty,min=1"`
+	Position   int    `json:"position" validate:"required,min=1"`
+	Title      string `json:"title" validate:"required"`
+	Duration   int    `json:"duration" validate:"min=0"`      // в секундах
+	ISRC       string `json:"isrc" validate:"omitempty,isrc"` // в любом регистре
+}
+
+type Request struct {
+	AlbumID int64   `param:"id" validate:"required"`
+	Tracks  []Track `json:"tracks" validate:"dive"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	tracks := lo.Map(in.Tracks, func(t Track, _ int) entity.Track {
+		return entity.Track{
+			DiscNumber: max(t.DiscNumber, 1),
+			Position:   t.Position,
+			Title:      t.Title,
+			Duration:   time.Duration(t.Duration) * time.Second,
+			ISRC:       strings.ToUpper(t.ISRC),
+		}
+	})
+
+	err := h.albumsService.ReplaceTracks(c.Request().Context(), in.AlbumID, tracks)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
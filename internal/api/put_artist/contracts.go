@@ -0,0 +1,11 @@
+package put_artist
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type ArtistService interface {
+	Update(ctx context.Context, artist entity.Artist) error
+}
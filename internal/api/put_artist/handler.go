@@ -0,0 +1,38 @@
+package put_artist
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	artistService ArtistService
+}
+
+func New(as ArtistService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		artistService: as,
+	})
+}
+
+type Request struct {
+	ID   int64  `param:"id" validate:"required"`
+	Name string `json:"name" validate:"required,min=2"`
+	Bio  string `json:"bio"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.artistService.Update(c.Request().Context(), entity.Artist{
+		ID:   in.ID,
+		Name: in.Name,
+		Bio:  in.Bio,
+	})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
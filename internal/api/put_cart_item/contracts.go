@@ -0,0 +1,7 @@
+package put_cart_item
+
+import "context"
+
+type CartService interface {
+	SetItem(ctx context.Context, cartID, albumID int64, format string, quantity int) error
+}
@@ -0,0 +1,34 @@
+package put_cart_item
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/api/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	cartService CartService
+}
+
+func New(cs CartService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		cartService: cs,
+	})
+}
+
+type Request struct {
+	CartID   int64  `param:"id" validate:"required"`
+	AlbumID  int64  `param:"album_id" validate:"required"`
+	Format   string `json:"format" validate:"omitempty,oneof=cd vinyl cassette digital"`
+	Quantity int    `json:"quantity" validate:"required,min=1,max=100"`
+}
+
+func (h *handler) Handle(c echo.Context, in Request) error {
+	err := h.cartService.SetItem(c.Request().Context(), in.CartID, in.AlbumID, in.Format, in.Quantity)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -3,50 +3,162 @@ package app
 import (
 	"context"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/4udiwe/musicshop/config"
 	"github.com/4udiwe/musicshop/internal/api"
+	"github.com/4udiwe/musicshop/internal/auth"
 	"github.com/4udiwe/musicshop/internal/database"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/logger"
+	"github.com/4udiwe/musicshop/internal/metrics"
+	"github.com/4udiwe/musicshop/internal/openapi"
 	albums_repo "github.com/4udiwe/musicshop/internal/repo/albums"
+	artists_repo "github.com/4udiwe/musicshop/internal/repo/artists"
+	audit_repo "github.com/4udiwe/musicshop/internal/repo/audit"
+	carts_repo "github.com/4udiwe/musicshop/internal/repo/carts"
 	genres_repo "github.com/4udiwe/musicshop/internal/repo/genres"
+	orders_repo "github.com/4udiwe/musicshop/internal/repo/orders"
+	prices_repo "github.com/4udiwe/musicshop/internal/repo/prices"
+	promotions_repo "github.com/4udiwe/musicshop/internal/repo/promotions"
+	stock_repo "github.com/4udiwe/musicshop/internal/repo/stock"
+	tracks_repo "github.com/4udiwe/musicshop/internal/repo/tracks"
+	users_repo "github.com/4udiwe/musicshop/internal/repo/users"
 	albums_service "github.com/4udiwe/musicshop/internal/service/albums"
+	artists_service "github.com/4udiwe/musicshop/internal/service/artists"
+	audit_service "github.com/4udiwe/musicshop/internal/service/audit"
+	carts_service "github.com/4udiwe/musicshop/internal/service/carts"
 	genres_service "github.com/4udiwe/musicshop/internal/service/genres"
+	health_service "github.com/4udiwe/musicshop/internal/service/health"
+	orders_service "github.com/4udiwe/musicshop/internal/service/orders"
+	prices_service "github.com/4udiwe/musicshop/internal/service/prices"
+	promotions_service "github.com/4udiwe/musicshop/internal/service/promotions"
+	stock_service "github.com/4udiwe/musicshop/internal/service/stock"
+	users_service "github.com/4udiwe/musicshop/internal/service/users"
 	"github.com/4udiwe/musicshop/pkg/httpserver"
 	"github.com/4udiwe/musicshop/pkg/postgres"
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type App struct {
 	cfg       *config.Config
 	interrupt <-chan os.Signal
 
+	exchangeRates entity.ExchangeRates
+
 	// DB
 	postgres *postgres.Postgres
 
 	// Echo
 	echoHandler *echo.Echo
 
+	// Metrics
+	metrics *metrics.Metrics
+
+	// Tracing
+	tracerProvider trace.TracerProvider
+
+	// Auth
+	tokenManager *auth.Manager
+
 	// Repositories
-	albumsRepo *albums_repo.Repository
-	genresRepo *genres_repo.Repository
+	albumsRepo     *albums_repo.Repository
+	genresRepo     *genres_repo.Repository
+	artistsRepo    *artists_repo.Repository
+	tracksRepo     *tracks_repo.Repository
+	stockRepo      *stock_repo.Repository
+	cartsRepo      *carts_repo.Repository
+	ordersRepo     *orders_repo.Repository
+	usersRepo      *users_repo.Repository
+	auditRepo      *audit_repo.Repository
+	pricesRepo     *prices_repo.Repository
+	promotionsRepo *promotions_repo.Repository
 
 	// Handlers
-	deleteAlbumHandler api.Handler
-	deleteGenreHandler api.Handler
+	deleteAlbumHandler       api.Handler
+	deleteGenreHandler       api.Handler
+	deleteGenreLegacyHandler api.Handler
+
+	postAlbumRestoreHandler api.Handler
+	postGenreRestoreHandler api.Handler
 
 	getAlbumHandler  api.Handler
 	getAlbumsHandler api.Handler
+	getSearchHandler api.Handler
 	getGenresHandler api.Handler
 
-	postAlbumHandler      api.Handler
-	postAlbumGenreHandler api.Handler
-	postGenreHandler      api.Handler
+	getGenreHandler       api.Handler
+	getGenreAlbumsHandler api.Handler
+	patchGenreHandler     api.Handler
+
+	postAlbumHandler        api.Handler
+	postAlbumGenreHandler   api.Handler
+	putAlbumGenresHandler   api.Handler
+	deleteAlbumGenreHandler api.Handler
+	postGenreHandler        api.Handler
+
+	putAlbumHandler   api.Handler
+	patchAlbumHandler api.Handler
+
+	getAlbumTracksHandler api.Handler
+	putAlbumTracksHandler api.Handler
+
+	getAlbumAvailabilityHandler api.Handler
+	putAlbumSKUHandler          api.Handler
+	postStockReceiptHandler     api.Handler
+	postStockAdjustmentHandler  api.Handler
+
+	getAlbumPricesHandler api.Handler
+	postAlbumPriceHandler api.Handler
+
+	postCartHandler         api.Handler
+	getCartHandler          api.Handler
+	putCartItemHandler      api.Handler
+	deleteCartItemHandler   api.Handler
+	postCheckoutHandler     api.Handler
+	getOrderHandler         api.Handler
+	patchOrderStatusHandler api.Handler
+
+	postAuthRegisterHandler api.Handler
+	postAuthLoginHandler    api.Handler
+	postAuthRefreshHandler  api.Handler
+
+	getArtistsHandler      api.Handler
+	getArtistHandler       api.Handler
+	getArtistAlbumsHandler api.Handler
+	postArtistHandler      api.Handler
+	putArtistHandler       api.Handler
+	deleteArtistHandler    api.Handler
+
+	getAuditHandler api.Handler
+
+	getPromotionsHandler  api.Handler
+	postPromotionHandler  api.Handler
+	getCartPricingHandler api.Handler
+
+	getHealthzHandler api.Handler
+	getReadyzHandler  api.Handler
+	getOpenAPIHandler api.Handler
 
 	// Services
-	albumsService *albums_service.Service
-	genresService *genres_service.Service
+	albumsService     *albums_service.Service
+	genresService     *genres_service.Service
+	artistsService    *artists_service.Service
+	stockService      *stock_service.Service
+	cartsService      *carts_service.Service
+	ordersService     *orders_service.Service
+	usersService      *users_service.Service
+	auditService      *audit_service.Service
+	pricesService     *prices_service.Service
+	promotionsService *promotions_service.Service
+	healthService     *health_service.Service
+
+	openAPIDocument *openapi.Document
 }
 
 func New(configPath string) *App {
@@ -55,23 +167,48 @@ func New(configPath string) *App {
 		log.Fatalf("app - New - config.New: %v", err)
 	}
 
-	initLogger(cfg.Log.Level)
+	if err := logger.Setup(cfg.Log.Level, cfg.Log.Format); err != nil {
+		log.Fatalf("app - New - logger.Setup: %v", err)
+	}
+
+	rates, err := entity.ParseExchangeRates(cfg.Currency.Rates)
+	if err != nil {
+		log.Fatalf("app - New - entity.ParseExchangeRates: %v", err)
+	}
 
 	return &App{
-		cfg: cfg,
+		cfg:           cfg,
+		exchangeRates: rates,
 	}
 }
 
 func (app *App) Start() {
+	app.interrupt = notifyInterrupt()
+
+	// Tracing
+	shutdownTracing := app.setupTracing()
+
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Errorf("Tracing shutdown error: %v", err)
+		}
+	}()
+
 	// Postgres
 	log.Info("Connecting to PostgreSQL...")
 
-	postgres, err := postgres.New(app.cfg.Postgres.URL, postgres.ConnAttempts(5))
+	postgres, err := postgres.New(
+		app.cfg.Postgres.URL,
+		postgres.ConnAttempts(5),
+		postgres.OnTransaction(app.Metrics().TransactionFinished),
+		postgres.TracerProvider(app.TracerProvider()),
+	)
 
 	if err != nil {
 		log.Fatalf("app - Start - Postgres failed:%v", err)
 	}
 	app.postgres = postgres
+	app.Metrics().RegisterPool(postgres.Pool)
 
 	defer postgres.Close()
 
@@ -91,12 +228,46 @@ func (app *App) Start() {
 		}
 	}()
 
+	// Prices - после сборки обработчиков, чтобы сервисы уже были созданы
+	syncCtx, stopSync := context.WithCancel(context.Background())
+	defer stopSync()
+	go app.syncPrices(syncCtx)
+
+	log.Infof("Start metrics server on %s...", app.cfg.Metrics.Addr)
+	metricsServer := httpserver.New(app.MetricsHandler(), httpserver.Addr(app.cfg.Metrics.Addr))
+	metricsServer.Start()
+
+	defer func() {
+		if err := metricsServer.Shutdown(); err != nil {
+			log.Errorf("Metrics server shutdown error: %v", err)
+		}
+	}()
+
+	app.awaitShutdown(httpServer.Notify(), metricsServer.Notify())
+}
+
+// notifyInterrupt подписывается на сигналы остановки процесса
+func notifyInterrupt() <-chan os.Signal {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	return interrupt
+}
+
+// awaitShutdown ждет сигнала остановки или ошибки сервера, затем снимает готовность
+// и выжидает ShutdownDelay. Серверы останавливаются уже после возврата,
+// чтобы балансировщик успел увести трафик.
+func (app *App) awaitShutdown(httpErrors, metricsErrors <-chan error) {
 	select {
 	case s := <-app.interrupt:
 		log.Infof("app - Start - signal: %v", s)
-	case err := <-httpServer.Notify():
+	case err := <-httpErrors:
 		log.Errorf("app - Start - server error: %v", err)
+	case err := <-metricsErrors:
+		log.Errorf("app - Start - metrics server error: %v", err)
 	}
 
 	log.Info("Shutting down...")
+
+	app.HealthService().StartShutdown()
+	time.Sleep(app.cfg.HTTP.ShutdownDelay)
 }
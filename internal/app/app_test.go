@@ -0,0 +1,47 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// После SIGTERM /readyz отвечает 503 еще до того, как Start перейдет к остановке серверов
+func TestAwaitShutdownDrainsReadiness(t *testing.T) {
+	cfg := &config.Config{Auth: config.Auth{SigningKey: "test-signing-key", AccessTTL: time.Minute}}
+	cfg.HTTP.ShutdownDelay = 500 * time.Millisecond
+
+	app := &App{cfg: cfg, interrupt: notifyInterrupt()}
+	e := app.EchoHandler()
+
+	done := make(chan struct{})
+	go func() {
+		app.awaitShutdown(nil, nil)
+		close(done)
+	}()
+
+	assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+
+	assert.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return rec.Code == http.StatusServiceUnavailable
+	}, 400*time.Millisecond, 10*time.Millisecond)
+
+	select {
+	case <-done:
+		t.Fatal("awaitShutdown returned before the shutdown delay")
+	default:
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("awaitShutdown did not return after the shutdown delay")
+	}
+}
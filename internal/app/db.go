@@ -2,7 +2,16 @@ package app
 
 import (
 	"github.com/4udiwe/musicshop/internal/repo/albums"
+	"github.com/4udiwe/musicshop/internal/repo/artists"
+	"github.com/4udiwe/musicshop/internal/repo/audit"
+	"github.com/4udiwe/musicshop/internal/repo/carts"
 	"github.com/4udiwe/musicshop/internal/repo/genres"
+	"github.com/4udiwe/musicshop/internal/repo/orders"
+	"github.com/4udiwe/musicshop/internal/repo/prices"
+	"github.com/4udiwe/musicshop/internal/repo/promotions"
+	"github.com/4udiwe/musicshop/internal/repo/stock"
+	"github.com/4udiwe/musicshop/internal/repo/tracks"
+	"github.com/4udiwe/musicshop/internal/repo/users"
 	"github.com/4udiwe/musicshop/pkg/postgres"
 )
 
@@ -25,3 +34,75 @@ func (app *App) GenresRepo() *genres.Repository {
 	app.genresRepo = genres.New(app.Postgres())
 	return app.genresRepo
 }
+
+func (app *App) ArtistsRepo() *artists.Repository {
+	if app.artistsRepo != nil {
+		return app.artistsRepo
+	}
+	app.artistsRepo = artists.New(app.Postgres())
+	return app.artistsRepo
+}
+
+func (app *App) TracksRepo() *tracks.Repository {
+	if app.tracksRepo != nil {
+		return app.tracksRepo
+	}
+	app.tracksRepo = tracks.New(app.Postgres())
+	return app.tracksRepo
+}
+
+func (app *App) StockRepo() *stock.Repository {
+	if app.stockRepo != nil {
+		return app.stockRepo
+	}
+	app.stockRepo = stock.New(app.Postgres())
+	return app.stockRepo
+}
+
+func (app *App) CartsRepo() *carts.Repository {
+	if app.cartsRepo != nil {
+		return app.cartsRepo
+	}
+	app.cartsRepo = carts.New(app.Postgres())
+	return app.cartsRepo
+}
+
+func (app *App) OrdersRepo() *orders.Repository {
+	if app.ordersRepo != nil {
+		return app.ordersRepo
+	}
+	app.ordersRepo = orders.New(app.Postgres())
+	return app.ordersRepo
+}
+
+func (app *App) UsersRepo() *users.Repository {
+	if app.usersRepo != nil {
+		return app.usersRepo
+	}
+	app.usersRepo = users.New(app.Postgres())
+	return app.usersRepo
+}
+
+func (app *App) AuditRepo() *audit.Repository {
+	if app.auditRepo != nil {
+		return app.auditRepo
+	}
+	app.auditRepo = audit.New(app.Postgres())
+	return app.auditRepo
+}
+
+func (app *App) PricesRepo() *prices.Repository {
+	if app.pricesRepo != nil {
+		return app.pricesRepo
+	}
+	app.pricesRepo = prices.New(app.Postgres())
+	return app.pricesRepo
+}
+
+func (app *App) PromotionsRepo() *promotions.Repository {
+	if app.promotionsRepo != nil {
+		return app.promotionsRepo
+	}
+	app.promotionsRepo = promotions.New(app.Postgres())
+	return app.promotionsRepo
+}
@@ -3,13 +3,55 @@ package app
 import (
 	"github.com/4udiwe/musicshop/internal/api"
 	"github.com/4udiwe/musicshop/internal/api/delete_album"
+	"github.com/4udiwe/musicshop/internal/api/delete_album_genre"
+	"github.com/4udiwe/musicshop/internal/api/delete_artist"
+	"github.com/4udiwe/musicshop/internal/api/delete_cart_item"
 	"github.com/4udiwe/musicshop/internal/api/delete_genre"
+	"github.com/4udiwe/musicshop/internal/api/delete_genre_legacy"
 	"github.com/4udiwe/musicshop/internal/api/get_album"
+	"github.com/4udiwe/musicshop/internal/api/get_album_availability"
+	"github.com/4udiwe/musicshop/internal/api/get_album_prices"
+	"github.com/4udiwe/musicshop/internal/api/get_album_tracks"
 	"github.com/4udiwe/musicshop/internal/api/get_albums"
+	"github.com/4udiwe/musicshop/internal/api/get_artist"
+	"github.com/4udiwe/musicshop/internal/api/get_artist_albums"
+	"github.com/4udiwe/musicshop/internal/api/get_artists"
+	"github.com/4udiwe/musicshop/internal/api/get_audit"
+	"github.com/4udiwe/musicshop/internal/api/get_cart"
+	"github.com/4udiwe/musicshop/internal/api/get_cart_pricing"
+	"github.com/4udiwe/musicshop/internal/api/get_genre"
+	"github.com/4udiwe/musicshop/internal/api/get_genre_albums"
 	"github.com/4udiwe/musicshop/internal/api/get_genres"
+	"github.com/4udiwe/musicshop/internal/api/get_healthz"
+	"github.com/4udiwe/musicshop/internal/api/get_openapi"
+	"github.com/4udiwe/musicshop/internal/api/get_order"
+	"github.com/4udiwe/musicshop/internal/api/get_promotions"
+	"github.com/4udiwe/musicshop/internal/api/get_readyz"
+	"github.com/4udiwe/musicshop/internal/api/get_search"
+	"github.com/4udiwe/musicshop/internal/api/patch_album"
+	"github.com/4udiwe/musicshop/internal/api/patch_genre"
+	"github.com/4udiwe/musicshop/internal/api/patch_order_status"
 	"github.com/4udiwe/musicshop/internal/api/post_album"
+	"github.com/4udiwe/musicshop/internal/api/post_album_price"
+	"github.com/4udiwe/musicshop/internal/api/post_album_restore"
 	"github.com/4udiwe/musicshop/internal/api/post_albums_genre"
+	"github.com/4udiwe/musicshop/internal/api/post_artist"
+	"github.com/4udiwe/musicshop/internal/api/post_auth_login"
+	"github.com/4udiwe/musicshop/internal/api/post_auth_refresh"
+	"github.com/4udiwe/musicshop/internal/api/post_auth_register"
+	"github.com/4udiwe/musicshop/internal/api/post_cart"
+	"github.com/4udiwe/musicshop/internal/api/post_checkout"
 	"github.com/4udiwe/musicshop/internal/api/post_genre"
+	"github.com/4udiwe/musicshop/internal/api/post_genre_restore"
+	"github.com/4udiwe/musicshop/internal/api/post_promotion"
+	"github.com/4udiwe/musicshop/internal/api/post_stock_adjustment"
+	"github.com/4udiwe/musicshop/internal/api/post_stock_receipt"
+	"github.com/4udiwe/musicshop/internal/api/put_album"
+	"github.com/4udiwe/musicshop/internal/api/put_album_genres"
+	"github.com/4udiwe/musicshop/internal/api/put_album_sku"
+	"github.com/4udiwe/musicshop/internal/api/put_album_tracks"
+	"github.com/4udiwe/musicshop/internal/api/put_artist"
+	"github.com/4udiwe/musicshop/internal/api/put_cart_item"
 )
 
 func (app *App) DeleteAlbumHandler() api.Handler {
@@ -28,6 +70,30 @@ func (app *App) DeleteGenreHandler() api.Handler {
 	return app.deleteGenreHandler
 }
 
+func (app *App) DeleteGenreLegacyHandler() api.Handler {
+	if app.deleteGenreLegacyHandler != nil {
+		return app.deleteGenreLegacyHandler
+	}
+	app.deleteGenreLegacyHandler = delete_genre_legacy.New(app.GenresService())
+	return app.deleteGenreLegacyHandler
+}
+
+func (app *App) PostAlbumRestoreHandler() api.Handler {
+	if app.postAlbumRestoreHandler != nil {
+		return app.postAlbumRestoreHandler
+	}
+	app.postAlbumRestoreHandler = post_album_restore.New(app.AlbumsService())
+	return app.postAlbumRestoreHandler
+}
+
+func (app *App) PostGenreRestoreHandler() api.Handler {
+	if app.postGenreRestoreHandler != nil {
+		return app.postGenreRestoreHandler
+	}
+	app.postGenreRestoreHandler = post_genre_restore.New(app.GenresService())
+	return app.postGenreRestoreHandler
+}
+
 func (app *App) GetAlbumHandler() api.Handler {
 	if app.getAlbumHandler != nil {
 		return app.getAlbumHandler
@@ -60,6 +126,30 @@ func (app *App) PostAlbumsHandler() api.Handler {
 	return app.postAlbumHandler
 }
 
+func (app *App) GetGenreHandler() api.Handler {
+	if app.getGenreHandler != nil {
+		return app.getGenreHandler
+	}
+	app.getGenreHandler = get_genre.New(app.GenresService())
+	return app.getGenreHandler
+}
+
+func (app *App) GetGenreAlbumsHandler() api.Handler {
+	if app.getGenreAlbumsHandler != nil {
+		return app.getGenreAlbumsHandler
+	}
+	app.getGenreAlbumsHandler = get_genre_albums.New(app.GenresService(), app.AlbumsService())
+	return app.getGenreAlbumsHandler
+}
+
+func (app *App) PatchGenreHandler() api.Handler {
+	if app.patchGenreHandler != nil {
+		return app.patchGenreHandler
+	}
+	app.patchGenreHandler = patch_genre.New(app.GenresService())
+	return app.patchGenreHandler
+}
+
 func (app *App) PostAlbumGenreHandler() api.Handler {
 	if app.postAlbumGenreHandler != nil {
 		return app.postAlbumGenreHandler
@@ -68,6 +158,22 @@ func (app *App) PostAlbumGenreHandler() api.Handler {
 	return app.postAlbumGenreHandler
 }
 
+func (app *App) PutAlbumGenresHandler() api.Handler {
+	if app.putAlbumGenresHandler != nil {
+		return app.putAlbumGenresHandler
+	}
+	app.putAlbumGenresHandler = put_album_genres.New(app.GenresService())
+	return app.putAlbumGenresHandler
+}
+
+func (app *App) DeleteAlbumGenreHandler() api.Handler {
+	if app.deleteAlbumGenreHandler != nil {
+		return app.deleteAlbumGenreHandler
+	}
+	app.deleteAlbumGenreHandler = delete_album_genre.New(app.GenresService())
+	return app.deleteAlbumGenreHandler
+}
+
 func (app *App) PostGenreHandler() api.Handler {
 	if app.postGenreHandler != nil {
 		return app.postGenreHandler
@@ -75,3 +181,275 @@ func (app *App) PostGenreHandler() api.Handler {
 	app.postGenreHandler = post_genre.New(app.GenresService())
 	return app.postGenreHandler
 }
+
+func (app *App) PutAlbumHandler() api.Handler {
+	if app.putAlbumHandler != nil {
+		return app.putAlbumHandler
+	}
+	app.putAlbumHandler = put_album.New(app.AlbumsService())
+	return app.putAlbumHandler
+}
+
+func (app *App) PatchAlbumHandler() api.Handler {
+	if app.patchAlbumHandler != nil {
+		return app.patchAlbumHandler
+	}
+	app.patchAlbumHandler = patch_album.New(app.AlbumsService())
+	return app.patchAlbumHandler
+}
+
+func (app *App) GetArtistsHandler() api.Handler {
+	if app.getArtistsHandler != nil {
+		return app.getArtistsHandler
+	}
+	app.getArtistsHandler = get_artists.New(app.ArtistsService())
+	return app.getArtistsHandler
+}
+
+func (app *App) GetArtistHandler() api.Handler {
+	if app.getArtistHandler != nil {
+		return app.getArtistHandler
+	}
+	app.getArtistHandler = get_artist.New(app.ArtistsService())
+	return app.getArtistHandler
+}
+
+func (app *App) GetArtistAlbumsHandler() api.Handler {
+	if app.getArtistAlbumsHandler != nil {
+		return app.getArtistAlbumsHandler
+	}
+	app.getArtistAlbumsHandler = get_artist_albums.New(app.ArtistsService(), app.AlbumsService())
+	return app.getArtistAlbumsHandler
+}
+
+func (app *App) PostArtistHandler() api.Handler {
+	if app.postArtistHandler != nil {
+		return app.postArtistHandler
+	}
+	app.postArtistHandler = post_artist.New(app.ArtistsService())
+	return app.postArtistHandler
+}
+
+func (app *App) PutArtistHandler() api.Handler {
+	if app.putArtistHandler != nil {
+		return app.putArtistHandler
+	}
+	app.putArtistHandler = put_artist.New(app.ArtistsService())
+	return app.putArtistHandler
+}
+
+func (app *App) DeleteArtistHandler() api.Handler {
+	if app.deleteArtistHandler != nil {
+		return app.deleteArtistHandler
+	}
+	app.deleteArtistHandler = delete_artist.New(app.ArtistsService())
+	return app.deleteArtistHandler
+}
+
+func (app *App) GetAlbumTracksHandler() api.Handler {
+	if app.getAlbumTracksHandler != nil {
+		return app.getAlbumTracksHandler
+	}
+	app.getAlbumTracksHandler = get_album_tracks.New(app.AlbumsService())
+	return app.getAlbumTracksHandler
+}
+
+func (app *App) PutAlbumTracksHandler() api.Handler {
+	if app.putAlbumTracksHandler != nil {
+		return app.putAlbumTracksHandler
+	}
+	app.putAlbumTracksHandler = put_album_tracks.New(app.AlbumsService())
+	return app.putAlbumTracksHandler
+}
+
+func (app *App) GetAlbumAvailabilityHandler() api.Handler {
+	if app.getAlbumAvailabilityHandler != nil {
+		return app.getAlbumAvailabilityHandler
+	}
+	app.getAlbumAvailabilityHandler = get_album_availability.New(app.StockService())
+	return app.getAlbumAvailabilityHandler
+}
+
+func (app *App) PutAlbumSKUHandler() api.Handler {
+	if app.putAlbumSKUHandler != nil {
+		return app.putAlbumSKUHandler
+	}
+	app.putAlbumSKUHandler = put_album_sku.New(app.StockService())
+	return app.putAlbumSKUHandler
+}
+
+func (app *App) GetAlbumPricesHandler() api.Handler {
+	if app.getAlbumPricesHandler != nil {
+		return app.getAlbumPricesHandler
+	}
+	app.getAlbumPricesHandler = get_album_prices.New(app.PricesService())
+	return app.getAlbumPricesHandler
+}
+
+func (app *App) PostAlbumPriceHandler() api.Handler {
+	if app.postAlbumPriceHandler != nil {
+		return app.postAlbumPriceHandler
+	}
+	app.postAlbumPriceHandler = post_album_price.New(app.PricesService())
+	return app.postAlbumPriceHandler
+}
+
+func (app *App) PostStockReceiptHandler() api.Handler {
+	if app.postStockReceiptHandler != nil {
+		return app.postStockReceiptHandler
+	}
+	app.postStockReceiptHandler = post_stock_receipt.New(app.StockService())
+	return app.postStockReceiptHandler
+}
+
+func (app *App) PostStockAdjustmentHandler() api.Handler {
+	if app.postStockAdjustmentHandler != nil {
+		return app.postStockAdjustmentHandler
+	}
+	app.postStockAdjustmentHandler = post_stock_adjustment.New(app.StockService())
+	return app.postStockAdjustmentHandler
+}
+
+func (app *App) PostCartHandler() api.Handler {
+	if app.postCartHandler != nil {
+		return app.postCartHandler
+	}
+	app.postCartHandler = post_cart.New(app.CartsService())
+	return app.postCartHandler
+}
+
+func (app *App) GetCartHandler() api.Handler {
+	if app.getCartHandler != nil {
+		return app.getCartHandler
+	}
+	app.getCartHandler = get_cart.New(app.CartsService())
+	return app.getCartHandler
+}
+
+func (app *App) PutCartItemHandler() api.Handler {
+	if app.putCartItemHandler != nil {
+		return app.putCartItemHandler
+	}
+	app.putCartItemHandler = put_cart_item.New(app.CartsService())
+	return app.putCartItemHandler
+}
+
+func (app *App) DeleteCartItemHandler() api.Handler {
+	if app.deleteCartItemHandler != nil {
+		return app.deleteCartItemHandler
+	}
+	app.deleteCartItemHandler = delete_cart_item.New(app.CartsService())
+	return app.deleteCartItemHandler
+}
+
+func (app *App) PostCheckoutHandler() api.Handler {
+	if app.postCheckoutHandler != nil {
+		return app.postCheckoutHandler
+	}
+	app.postCheckoutHandler = post_checkout.New(app.OrdersService())
+	return app.postCheckoutHandler
+}
+
+func (app *App) GetOrderHandler() api.Handler {
+	if app.getOrderHandler != nil {
+		return app.getOrderHandler
+	}
+	app.getOrderHandler = get_order.New(app.OrdersService())
+	return app.getOrderHandler
+}
+
+func (app *App) PatchOrderStatusHandler() api.Handler {
+	if app.patchOrderStatusHandler != nil {
+		return app.patchOrderStatusHandler
+	}
+	app.patchOrderStatusHandler = patch_order_status.New(app.OrdersService())
+	return app.patchOrderStatusHandler
+}
+
+func (app *App) PostAuthRegisterHandler() api.Handler {
+	if app.postAuthRegisterHandler != nil {
+		return app.postAuthRegisterHandler
+	}
+	app.postAuthRegisterHandler = post_auth_register.New(app.UsersService())
+	return app.postAuthRegisterHandler
+}
+
+func (app *App) PostAuthLoginHandler() api.Handler {
+	if app.postAuthLoginHandler != nil {
+		return app.postAuthLoginHandler
+	}
+	app.postAuthLoginHandler = post_auth_login.New(app.UsersService())
+	return app.postAuthLoginHandler
+}
+
+func (app *App) PostAuthRefreshHandler() api.Handler {
+	if app.postAuthRefreshHandler != nil {
+		return app.postAuthRefreshHandler
+	}
+	app.postAuthRefreshHandler = post_auth_refresh.New(app.UsersService())
+	return app.postAuthRefreshHandler
+}
+
+func (app *App) GetSearchHandler() api.Handler {
+	if app.getSearchHandler != nil {
+		return app.getSearchHandler
+	}
+	app.getSearchHandler = get_search.New(app.AlbumsService())
+	return app.getSearchHandler
+}
+
+func (app *App) GetAuditHandler() api.Handler {
+	if app.getAuditHandler != nil {
+		return app.getAuditHandler
+	}
+	app.getAuditHandler = get_audit.New(app.AuditService())
+	return app.getAuditHandler
+}
+
+func (app *App) GetPromotionsHandler() api.Handler {
+	if app.getPromotionsHandler != nil {
+		return app.getPromotionsHandler
+	}
+	app.getPromotionsHandler = get_promotions.New(app.PromotionsService())
+	return app.getPromotionsHandler
+}
+
+func (app *App) PostPromotionHandler() api.Handler {
+	if app.postPromotionHandler != nil {
+		return app.postPromotionHandler
+	}
+	app.postPromotionHandler = post_promotion.New(app.PromotionsService())
+	return app.postPromotionHandler
+}
+
+func (app *App) GetCartPricingHandler() api.Handler {
+	if app.getCartPricingHandler != nil {
+		return app.getCartPricingHandler
+	}
+	app.getCartPricingHandler = get_cart_pricing.New(app.PromotionsService())
+	return app.getCartPricingHandler
+}
+
+func (app *App) GetHealthzHandler() api.Handler {
+	if app.getHealthzHandler != nil {
+		return app.getHealthzHandler
+	}
+	app.getHealthzHandler = get_healthz.New(app.HealthService())
+	return app.getHealthzHandler
+}
+
+func (app *App) GetReadyzHandler() api.Handler {
+	if app.getReadyzHandler != nil {
+		return app.getReadyzHandler
+	}
+	app.getReadyzHandler = get_readyz.New(app.HealthService())
+	return app.getReadyzHandler
+}
+
+func (app *App) GetOpenAPIHandler() api.Handler {
+	if app.getOpenAPIHandler != nil {
+		return app.getOpenAPIHandler
+	}
+	app.getOpenAPIHandler = get_openapi.New(app.OpenAPIDocument())
+	return app.getOpenAPIHandler
+}
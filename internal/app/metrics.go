@@ -0,0 +1,22 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/metrics"
+)
+
+func (app *App) Metrics() *metrics.Metrics {
+	if app.metrics != nil {
+		return app.metrics
+	}
+	app.metrics = metrics.New()
+	return app.metrics
+}
+
+// MetricsHandler отдает /metrics, обслуживается отдельным сервером на cfg.Metrics.Addr
+func (app *App) MetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", app.Metrics().Handler())
+	return mux
+}
@@ -0,0 +1,265 @@
+package app
+
+import (
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/musicshop/internal/api/apierror"
+	"github.com/4udiwe/musicshop/internal/api/delete_album"
+	"github.com/4udiwe/musicshop/internal/api/delete_album_genre"
+	"github.com/4udiwe/musicshop/internal/api/delete_artist"
+	"github.com/4udiwe/musicshop/internal/api/delete_cart_item"
+	"github.com/4udiwe/musicshop/internal/api/delete_genre"
+	"github.com/4udiwe/musicshop/internal/api/delete_genre_legacy"
+	"github.com/4udiwe/musicshop/internal/api/get_album"
+	"github.com/4udiwe/musicshop/internal/api/get_album_availability"
+	"github.com/4udiwe/musicshop/internal/api/get_album_prices"
+	"github.com/4udiwe/musicshop/internal/api/get_album_tracks"
+	"github.com/4udiwe/musicshop/internal/api/get_albums"
+	"github.com/4udiwe/musicshop/internal/api/get_artist"
+	"github.com/4udiwe/musicshop/internal/api/get_artist_albums"
+	"github.com/4udiwe/musicshop/internal/api/get_artists"
+	"github.com/4udiwe/musicshop/internal/api/get_audit"
+	"github.com/4udiwe/musicshop/internal/api/get_cart"
+	"github.com/4udiwe/musicshop/internal/api/get_cart_pricing"
+	"github.com/4udiwe/musicshop/internal/api/get_genre"
+	"github.com/4udiwe/musicshop/internal/api/get_genre_albums"
+	"github.com/4udiwe/musicshop/internal/api/get_genres"
+	"github.com/4udiwe/musicshop/internal/api/get_healthz"
+	"github.com/4udiwe/musicshop/internal/api/get_order"
+	"github.com/4udiwe/musicshop/internal/api/get_promotions"
+	"github.com/4udiwe/musicshop/internal/api/get_readyz"
+	"github.com/4udiwe/musicshop/internal/api/get_search"
+	"github.com/4udiwe/musicshop/internal/api/patch_album"
+	"github.com/4udiwe/musicshop/internal/api/patch_genre"
+	"github.com/4udiwe/musicshop/internal/api/patch_order_status"
+	"github.com/4udiwe/musicshop/internal/api/post_album"
+	"github.com/4udiwe/musicshop/internal/api/post_album_price"
+	"github.com/4udiwe/musicshop/internal/api/post_album_restore"
+	"github.com/4udiwe/musicshop/internal/api/post_albums_genre"
+	"github.com/4udiwe/musicshop/internal/api/post_artist"
+	"github.com/4udiwe/musicshop/internal/api/post_auth_login"
+	"github.com/4udiwe/musicshop/internal/api/post_auth_refresh"
+	"github.com/4udiwe/musicshop/internal/api/post_auth_register"
+	"github.com/4udiwe/musicshop/internal/api/post_cart"
+	"github.com/4udiwe/musicshop/internal/api/post_checkout"
+	"github.com/4udiwe/musicshop/internal/api/post_genre"
+	"github.com/4udiwe/musicshop/internal/api/post_genre_restore"
+	"github.com/4udiwe/musicshop/internal/api/post_promotion"
+	"github.com/4udiwe/musicshop/internal/api/post_stock_adjustment"
+	"github.com/4udiwe/musicshop/internal/api/post_stock_receipt"
+	"github.com/4udiwe/musicshop/internal/api/put_album"
+	"github.com/4udiwe/musicshop/internal/api/put_album_genres"
+	"github.com/4udiwe/musicshop/internal/api/put_album_sku"
+	"github.com/4udiwe/musicshop/internal/api/put_album_tracks"
+	"github.com/4udiwe/musicshop/internal/api/put_artist"
+	"github.com/4udiwe/musicshop/internal/api/put_cart_item"
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/openapi"
+)
+
+const (
+	openAPIPath = "/openapi.json"
+	docsPath    = "/docs"
+)
+
+// undocumentedRoutes - маршруты, которые обслуживают саму документацию и в спецификацию не входят
+var undocumentedRoutes = map[string]bool{
+	http.MethodGet + " " + openAPIPath:     true,
+	http.MethodGet + " " + docsPath:        true,
+	http.MethodGet + " " + docsPath + "/*": true,
+}
+
+// viewDeletedNote - пояснение к маршрутам, где include_deleted проверяется в роутере
+const viewDeletedNote = "`include_deleted` requires permission `" + string(auth.PermissionCatalogViewDeleted) + "`."
+
+// derivedPriceNote - пояснение к записи цены альбома, которая у альбома с SKU выводится из них
+const derivedPriceNote = "The price of an album with SKUs is the price of its cheapest SKU and is changed through " +
+	"`PUT /albums/{id}/skus/{format}`; any other `price` responds 409 price_derived_from_skus."
+
+// ownCartNote - пояснение к маршрутам корзины: владелец проверяется в сервисе
+const ownCartNote = "Carts of other users respond 404."
+
+// apiRoutes описывает маршруты configureRouter для спецификации OpenAPI.
+// Новый маршрут без описания здесь не пройдет TestOpenAPICoversRoutes.
+var apiRoutes = []openapi.Route{
+	// health
+	{Method: http.MethodGet, Path: "/healthz", Tag: "health", Summary: "Liveness probe",
+		Request: get_healthz.Request{}, Status: http.StatusOK, Response: get_readyz.Response{}},
+	{Method: http.MethodGet, Path: "/readyz", Tag: "health", Summary: "Readiness probe",
+		Description: "Responds 503 with the failed checks when a dependency is unavailable or the server is shutting down.",
+		Request:     get_readyz.Request{}, Status: http.StatusOK, Response: get_readyz.Response{}},
+
+	// auth
+	{Method: http.MethodPost, Path: "/auth/register", Tag: "auth", Summary: "Register a customer",
+		Request: post_auth_register.Request{}, Status: http.StatusCreated, Response: post_auth_register.Response{}},
+	{Method: http.MethodPost, Path: "/auth/login", Tag: "auth", Summary: "Log in with email and password",
+		Request: post_auth_login.Request{}, Status: http.StatusOK, Response: post_auth_login.Response{}},
+	{Method: http.MethodPost, Path: "/auth/refresh", Tag: "auth", Summary: "Exchange a refresh token for a new token pair",
+		Request: post_auth_refresh.Request{}, Status: http.StatusOK, Response: post_auth_login.Response{}},
+
+	// albums
+	{Method: http.MethodGet, Path: "/search", Tag: "albums", Summary: "Full-text album search",
+		Request: get_search.Request{}, Status: http.StatusOK, Response: get_albums.Response{}},
+	{Method: http.MethodGet, Path: "/albums", Tag: "albums", Summary: "List albums", Description: viewDeletedNote,
+		Request: get_albums.Request{}, Status: http.StatusOK, Response: get_albums.Response{}},
+	{Method: http.MethodGet, Path: "/albums/:id", Tag: "albums", Summary: "Get an album",
+		Request: get_album.Request{}, Status: http.StatusOK, Response: get_album.Response{}},
+	{Method: http.MethodPost, Path: "/albums", Tag: "albums", Summary: "Create an album",
+		Request: post_album.Request{}, Status: http.StatusCreated, Response: post_album.Response{},
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodPut, Path: "/albums/:id", Tag: "albums", Summary: "Replace an album",
+		Description: derivedPriceNote,
+		Request:     put_album.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodPatch, Path: "/albums/:id", Tag: "albums", Summary: "Update album fields",
+		Description: derivedPriceNote,
+		Request:     patch_album.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodDelete, Path: "/albums/:id", Tag: "albums", Summary: "Soft-delete an album",
+		Request: delete_album.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodPost, Path: "/albums/:id/restore", Tag: "albums", Summary: "Restore a deleted album",
+		Request: post_album_restore.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodPost, Path: "/albums/:id/genres", Tag: "albums", Summary: "Attach genres to an album",
+		Description: "Responds 201 when at least one genre was attached and 200 otherwise.",
+		Request:     post_albums_genre.Request{}, Status: http.StatusCreated, Response: post_albums_genre.Response{},
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodPut, Path: "/albums/:id/genres", Tag: "albums", Summary: "Replace album genres",
+		Request: put_album_genres.Request{}, Status: http.StatusOK, Response: put_album_genres.Response{},
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodDelete, Path: "/albums/:id/genres/:genre_id", Tag: "albums", Summary: "Detach a genre from an album",
+		Request: delete_album_genre.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodGet, Path: "/albums/:id/tracks", Tag: "albums", Summary: "List album tracks",
+		Request: get_album_tracks.Request{}, Status: http.StatusOK, Response: get_album_tracks.Response{}},
+	{Method: http.MethodPut, Path: "/albums/:id/tracks", Tag: "albums", Summary: "Replace album tracks",
+		Request: put_album_tracks.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+
+	// inventory
+	{Method: http.MethodGet, Path: "/albums/:id/availability", Tag: "inventory", Summary: "Album availability by format",
+		Request: get_album_availability.Request{}, Status: http.StatusOK, Response: get_album_availability.Response{}},
+	{Method: http.MethodPut, Path: "/albums/:id/skus/:format", Tag: "inventory", Summary: "Create or update an SKU",
+		Request: put_album_sku.Request{}, Status: http.StatusOK, Response: put_album_sku.Response{},
+		Permission: string(auth.PermissionInventoryWrite)},
+	{Method: http.MethodPost, Path: "/albums/:id/skus/:format/receipts", Tag: "inventory", Summary: "Receive stock",
+		Request: post_stock_receipt.Request{}, Status: http.StatusOK, Response: post_stock_receipt.Response{},
+		Permission: string(auth.PermissionInventoryWrite)},
+	{Method: http.MethodPost, Path: "/albums/:id/skus/:format/adjustments", Tag: "inventory", Summary: "Adjust stock",
+		Request: post_stock_adjustment.Request{}, Status: http.StatusOK, Response: post_stock_adjustment.Response{},
+		Permission: string(auth.PermissionInventoryWrite)},
+	{Method: http.MethodGet, Path: "/albums/:id/prices", Tag: "inventory", Summary: "Album price history",
+		Description: "Scheduled prices are listed only with permission `" + string(auth.PermissionInventoryWrite) + "`.",
+		Request:     get_album_prices.Request{}, Status: http.StatusOK, Response: get_album_prices.Response{}},
+	{Method: http.MethodPost, Path: "/albums/:id/prices", Tag: "inventory", Summary: "Schedule an album price",
+		Description: "Albums with SKUs are sold at their SKU prices, so scheduling a price for them responds 409 price_derived_from_skus.",
+		Request:     post_album_price.Request{}, Status: http.StatusCreated, Response: post_album_price.Response{},
+		Permission: string(auth.PermissionInventoryWrite)},
+
+	// artists
+	{Method: http.MethodGet, Path: "/artists", Tag: "artists", Summary: "List artists",
+		Request: get_artists.Request{}, Status: http.StatusOK, Response: []get_artists.Response{}},
+	{Method: http.MethodGet, Path: "/artists/:id", Tag: "artists", Summary: "Get an artist",
+		Request: get_artist.Request{}, Status: http.StatusOK, Response: get_artist.Response{}},
+	{Method: http.MethodGet, Path: "/artists/:id/albums", Tag: "artists", Summary: "List artist albums",
+		Request: get_artist_albums.Request{}, Status: http.StatusOK, Response: get_albums.Response{}},
+	{Method: http.MethodPost, Path: "/artists", Tag: "artists", Summary: "Create an artist",
+		Request: post_artist.Request{}, Status: http.StatusCreated, Response: post_artist.Response{},
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodPut, Path: "/artists/:id", Tag: "artists", Summary: "Update an artist",
+		Request: put_artist.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodDelete, Path: "/artists/:id", Tag: "artists", Summary: "Delete an artist",
+		Request: delete_artist.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+
+	// carts
+	{Method: http.MethodPost, Path: "/carts", Tag: "carts", Summary: "Create a cart",
+		Description: "The cart belongs to the caller.",
+		Request:     post_cart.Request{}, Status: http.StatusCreated, Response: post_cart.Response{},
+		Authenticated: true},
+	{Method: http.MethodGet, Path: "/carts/:id", Tag: "carts", Summary: "Get a cart",
+		Description: ownCartNote,
+		Request:     get_cart.Request{}, Status: http.StatusOK, Response: get_cart.Response{},
+		Authenticated: true},
+	{Method: http.MethodPut, Path: "/carts/:id/items/:album_id", Tag: "carts", Summary: "Set item format and quantity",
+		Description: "Empty format picks the cheapest format of the album. " + ownCartNote,
+		Request:     put_cart_item.Request{}, Status: http.StatusNoContent,
+		Authenticated: true},
+	{Method: http.MethodDelete, Path: "/carts/:id/items/:album_id", Tag: "carts", Summary: "Remove an item",
+		Description: ownCartNote,
+		Request:     delete_cart_item.Request{}, Status: http.StatusNoContent,
+		Authenticated: true},
+	{Method: http.MethodGet, Path: "/carts/:id/pricing", Tag: "carts", Summary: "Price a cart with promotions",
+		Description: ownCartNote,
+		Request:     get_cart_pricing.Request{}, Status: http.StatusOK, Response: get_cart_pricing.Response{},
+		Authenticated: true},
+	{Method: http.MethodPost, Path: "/carts/:id/checkout", Tag: "carts", Summary: "Place an order from a cart",
+		Description: "Writes off stock of every item; responds 409 insufficient_stock when a format is short. " + ownCartNote,
+		Request:     post_checkout.Request{}, Status: http.StatusCreated, Response: post_checkout.Response{},
+		Authenticated: true},
+
+	// orders
+	{Method: http.MethodGet, Path: "/orders/:id", Tag: "orders", Summary: "Get an order",
+		Description: "Orders of other users respond 404 unless the caller has permission `" + string(auth.PermissionOrdersManage) + "`.",
+		Request:     get_order.Request{}, Status: http.StatusOK, Response: get_order.Response{},
+		Authenticated: true},
+	{Method: http.MethodPatch, Path: "/orders/:id/status", Tag: "orders", Summary: "Change order status",
+		Description: "Cancelling or refunding an order returns its physical items to stock.",
+		Request:     patch_order_status.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionOrdersManage)},
+
+	// genres
+	{Method: http.MethodGet, Path: "/genres", Tag: "genres", Summary: "List genres",
+		Description: "With `tree=true` genres are nested under `children`. " + viewDeletedNote,
+		Request:     get_genres.Request{}, Status: http.StatusOK, Response: []get_genres.TreeResponse{}},
+	{Method: http.MethodPost, Path: "/genres", Tag: "genres", Summary: "Create a genre",
+		Request: post_genre.Request{}, Status: http.StatusCreated, Response: post_genre.Response{},
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodGet, Path: "/genres/:id", Tag: "genres", Summary: "Get a genre",
+		Request: get_genre.Request{}, Status: http.StatusOK, Response: get_genres.Response{}},
+	{Method: http.MethodPatch, Path: "/genres/:id", Tag: "genres", Summary: "Rename or move a genre",
+		Request: patch_genre.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodDelete, Path: "/genres/:id", Tag: "genres", Summary: "Delete a genre",
+		Request: delete_genre.Request{}, Status: http.StatusOK,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodDelete, Path: "/genres", Tag: "genres", Summary: "Delete a genre by id in the body",
+		Description: "Use `DELETE /genres/{id}` instead.",
+		Request:     delete_genre_legacy.Request{}, Status: http.StatusOK,
+		Permission: string(auth.PermissionCatalogWrite), Deprecated: true},
+	{Method: http.MethodPost, Path: "/genres/:id/restore", Tag: "genres", Summary: "Restore a deleted genre",
+		Request: post_genre_restore.Request{}, Status: http.StatusNoContent,
+		Permission: string(auth.PermissionCatalogWrite)},
+	{Method: http.MethodGet, Path: "/genres/:id/albums", Tag: "genres", Summary: "List genre albums",
+		Request: get_genre_albums.Request{}, Status: http.StatusOK, Response: get_albums.Response{}},
+
+	// promotions
+	{Method: http.MethodGet, Path: "/promotions", Tag: "promotions", Summary: "List promotions",
+		Request: get_promotions.Request{}, Status: http.StatusOK, Response: []get_promotions.Response{},
+		Permission: string(auth.PermissionPromotionsManage)},
+	{Method: http.MethodPost, Path: "/promotions", Tag: "promotions", Summary: "Create a promotion",
+		Request: post_promotion.Request{}, Status: http.StatusCreated, Response: get_promotions.Response{},
+		Permission: string(auth.PermissionPromotionsManage)},
+
+	// audit
+	{Method: http.MethodGet, Path: "/audit", Tag: "audit", Summary: "Catalog change history",
+		Request: get_audit.Request{}, Status: http.StatusOK, Response: get_audit.Response{},
+		Permission: string(auth.PermissionAuditRead)},
+}
+
+func (app *App) OpenAPIDocument() *openapi.Document {
+	if app.openAPIDocument != nil {
+		return app.openAPIDocument
+	}
+	info := openapi.Info{Title: app.cfg.App.Name, Version: app.cfg.App.Version}
+	document, err := openapi.Build(info, apierror.Response{}, apiRoutes)
+	if err != nil {
+		log.Fatalf("app - OpenAPIDocument - openapi.Build: %v", err)
+	}
+	app.openAPIDocument = document
+	return app.openAPIDocument
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/config"
+	"github.com/4udiwe/musicshop/internal/api/apierror"
+	"github.com/4udiwe/musicshop/internal/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Каждый маршрут роутера должен быть описан в apiRoutes, и наоборот
+func TestOpenAPICoversRoutes(t *testing.T) {
+	document, err := openapi.Build(openapi.Info{Title: "test"}, apierror.Response{}, apiRoutes)
+	require.NoError(t, err)
+
+	app := &App{cfg: &config.Config{Auth: config.Auth{SigningKey: "test-signing-key", AccessTTL: time.Minute}}}
+	e := app.EchoHandler()
+
+	registered := map[string]bool{}
+	for _, route := range e.Routes() {
+		// echo добавляет служебные маршруты 404 для групп с middleware
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if undocumentedRoutes[key] {
+			continue
+		}
+		assert.Truef(t, document.Has(route.Method, route.Path), "route %s has no entry in apiRoutes", key)
+	}
+
+	for _, route := range apiRoutes {
+		key := route.Method + " " + route.Path
+		assert.Truef(t, registered[key], "apiRoutes entry %s is not registered in the router", key)
+	}
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	app := &App{cfg: &config.Config{Auth: config.Auth{SigningKey: "test-signing-key", AccessTTL: time.Minute}}}
+	e := app.EchoHandler()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var document openapi.Document
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &document))
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+	assert.Contains(t, document.Paths, "/albums/{id}")
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/docs/", rec.Header().Get(echo.HeaderLocation))
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "swagger-ui")
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"/openapi.json"`)
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// syncPrices переносит наступившие запланированные цены в albums.price раз в
+// Prices.SyncInterval, пока не отменен ctx. Нулевой интервал отключает синхронизацию.
+func (app *App) syncPrices(ctx context.Context) {
+	interval := app.cfg.Prices.SyncInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		applied, err := app.PricesService().ApplyDue(ctx)
+		if err != nil {
+			log.Errorf("app - syncPrices - ApplyDue: %v", err)
+		} else if applied > 0 {
+			log.Infof("Applied scheduled prices: %d", applied)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/musicshop/pkg/postgres"
+)
+
+// Purge окончательно удаляет альбомы и жанры, удаленные раньше срока хранения
+func (app *App) Purge() {
+	log.Info("Connecting to PostgreSQL...")
+
+	postgres, err := postgres.New(app.cfg.Postgres.URL, postgres.ConnAttempts(5))
+	if err != nil {
+		log.Fatalf("app - Purge - Postgres failed:%v", err)
+	}
+	app.postgres = postgres
+
+	defer postgres.Close()
+
+	ctx := context.Background()
+	before := time.Now().Add(-app.cfg.Purge.Retention)
+
+	log.Infof("Purging rows deleted before %s...", before.Format(time.RFC3339))
+
+	// Альбомы удаляются первыми, чтобы связи с жанрами ушли вместе с ними
+	albums, err := app.AlbumsService().PurgeDeleted(ctx, before)
+	if err != nil {
+		log.Fatalf("app - Purge - albums: %v", err)
+	}
+
+	genres, err := app.GenresService().PurgeDeleted(ctx, before)
+	if err != nil {
+		log.Fatalf("app - Purge - genres: %v", err)
+	}
+
+	log.Infof("Purged albums: %d, genres: %d", albums, genres)
+}
@@ -1,8 +1,16 @@
 package app
 
 import (
+	"net/http"
+
+	"github.com/4udiwe/musicshop/internal/api/apierror"
+	"github.com/4udiwe/musicshop/internal/api/middleware"
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/openapi"
+	"github.com/4udiwe/musicshop/internal/tracing"
 	"github.com/4udiwe/musicshop/pkg/validator"
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
 func (app *App) EchoHandler() *echo.Echo {
@@ -12,6 +20,7 @@ func (app *App) EchoHandler() *echo.Echo {
 
 	handler := echo.New()
 	handler.Validator = validator.NewCustomValidator()
+	handler.HTTPErrorHandler = apierror.Handler
 
 	app.configureRouter(handler)
 
@@ -20,19 +29,116 @@ func (app *App) EchoHandler() *echo.Echo {
 }
 
 func (app *App) configureRouter(handler *echo.Echo) {
+	handler.Use(middleware.RequestID())
+	// спан запроса продолжает трейс из заголовка traceparent
+	handler.Use(otelecho.Middleware(
+		app.cfg.App.Name,
+		otelecho.WithTracerProvider(app.TracerProvider()),
+		otelecho.WithPropagators(tracing.Propagator()),
+	))
+	handler.Use(middleware.Metrics(app.Metrics()))
+	handler.Use(middleware.AccessLog(app.cfg.Log.AccessSampleEvery))
+	handler.Use(middleware.Authenticate(app.TokenManager()))
+
+	// Разрешения маршрутов: чтение каталога публично, изменения - только для персонала
+	var (
+		catalogWrite     = middleware.Require(auth.PermissionCatalogWrite)
+		inventoryWrite   = middleware.Require(auth.PermissionInventoryWrite)
+		ordersManage     = middleware.Require(auth.PermissionOrdersManage)
+		auditRead        = middleware.Require(auth.PermissionAuditRead)
+		promotionsManage = middleware.Require(auth.PermissionPromotionsManage)
+		// корзины и заказы принадлежат пользователям, чужие не отличаются от несуществующих
+		user = middleware.RequireUser()
+		// удаленные записи в выдаче каталога видят только администраторы
+		viewDeleted = middleware.RequireWhen(auth.PermissionCatalogViewDeleted, middleware.QueryFlag("include_deleted"))
+	)
+
+	// спецификация и Swagger UI; маршруты API описываются в apiRoutes
+	handler.GET(openAPIPath, app.GetOpenAPIHandler().Handle)
+	handler.GET(docsPath, func(c echo.Context) error {
+		return c.Redirect(http.StatusMovedPermanently, docsPath+"/")
+	})
+	handler.GET(docsPath+"/*", echo.WrapHandler(http.StripPrefix(docsPath, openapi.UI(openAPIPath))))
+
+	handler.GET("/healthz", app.GetHealthzHandler().Handle)
+	handler.GET("/readyz", app.GetReadyzHandler().Handle)
+
+	authGroup := handler.Group("/auth")
+	{
+		authGroup.POST("/register", app.PostAuthRegisterHandler().Handle)
+		authGroup.POST("/login", app.PostAuthLoginHandler().Handle)
+		authGroup.POST("/refresh", app.PostAuthRefreshHandler().Handle)
+	}
+
+	handler.GET("/search", app.GetSearchHandler().Handle)
+
 	albumsGroup := handler.Group("/albums")
 	{
-		albumsGroup.GET("", app.GetAlbumsHandler().Handle)
+		albumsGroup.GET("", app.GetAlbumsHandler().Handle, viewDeleted)
 		albumsGroup.GET("/:id", app.GetAlbumHandler().Handle)
-		albumsGroup.POST("", app.PostAlbumsHandler().Handle)
-		albumsGroup.POST("/:id/genres", app.PostAlbumGenreHandler().Handle)
-		albumsGroup.DELETE("/:id", app.DeleteAlbumHandler().Handle)
+		albumsGroup.POST("", app.PostAlbumsHandler().Handle, catalogWrite)
+		albumsGroup.POST("/:id/restore", app.PostAlbumRestoreHandler().Handle, catalogWrite)
+		albumsGroup.POST("/:id/genres", app.PostAlbumGenreHandler().Handle, catalogWrite)
+		albumsGroup.PUT("/:id/genres", app.PutAlbumGenresHandler().Handle, catalogWrite)
+		albumsGroup.DELETE("/:id/genres/:genre_id", app.DeleteAlbumGenreHandler().Handle, catalogWrite)
+		albumsGroup.PUT("/:id", app.PutAlbumHandler().Handle, catalogWrite)
+		albumsGroup.PATCH("/:id", app.PatchAlbumHandler().Handle, catalogWrite)
+		albumsGroup.GET("/:id/tracks", app.GetAlbumTracksHandler().Handle)
+		albumsGroup.PUT("/:id/tracks", app.PutAlbumTracksHandler().Handle, catalogWrite)
+		albumsGroup.GET("/:id/availability", app.GetAlbumAvailabilityHandler().Handle)
+		albumsGroup.PUT("/:id/skus/:format", app.PutAlbumSKUHandler().Handle, inventoryWrite)
+		albumsGroup.POST("/:id/skus/:format/receipts", app.PostStockReceiptHandler().Handle, inventoryWrite)
+		albumsGroup.POST("/:id/skus/:format/adjustments", app.PostStockAdjustmentHandler().Handle, inventoryWrite)
+		// история цен публична, еще не объявленные цены сервис показывает только персоналу
+		albumsGroup.GET("/:id/prices", app.GetAlbumPricesHandler().Handle)
+		albumsGroup.POST("/:id/prices", app.PostAlbumPriceHandler().Handle, inventoryWrite)
+		albumsGroup.DELETE("/:id", app.DeleteAlbumHandler().Handle, catalogWrite)
+	}
+
+	artistsGroup := handler.Group("/artists")
+	{
+		artistsGroup.GET("", app.GetArtistsHandler().Handle)
+		artistsGroup.GET("/:id", app.GetArtistHandler().Handle)
+		artistsGroup.GET("/:id/albums", app.GetArtistAlbumsHandler().Handle)
+		artistsGroup.POST("", app.PostArtistHandler().Handle, catalogWrite)
+		artistsGroup.PUT("/:id", app.PutArtistHandler().Handle, catalogWrite)
+		artistsGroup.DELETE("/:id", app.DeleteArtistHandler().Handle, catalogWrite)
+	}
+
+	cartsGroup := handler.Group("/carts", user)
+	{
+		cartsGroup.POST("", app.PostCartHandler().Handle)
+		cartsGroup.GET("/:id", app.GetCartHandler().Handle)
+		cartsGroup.PUT("/:id/items/:album_id", app.PutCartItemHandler().Handle)
+		cartsGroup.DELETE("/:id/items/:album_id", app.DeleteCartItemHandler().Handle)
+		cartsGroup.GET("/:id/pricing", app.GetCartPricingHandler().Handle)
+		cartsGroup.POST("/:id/checkout", app.PostCheckoutHandler().Handle)
+	}
+
+	ordersGroup := handler.Group("/orders", user)
+	{
+		ordersGroup.GET("/:id", app.GetOrderHandler().Handle)
+		ordersGroup.PATCH("/:id/status", app.PatchOrderStatusHandler().Handle, ordersManage)
 	}
 
 	genresGroup := handler.Group("/genres")
 	{
-		genresGroup.GET("", app.GetGenresHandler().Handle)
-		genresGroup.POST("", app.PostGenreHandler().Handle)
-		genresGroup.DELETE("", app.DeleteGenreHandler().Handle)
+		genresGroup.GET("", app.GetGenresHandler().Handle, viewDeleted)
+		genresGroup.POST("", app.PostGenreHandler().Handle, catalogWrite)
+		genresGroup.DELETE("/:id", app.DeleteGenreHandler().Handle, catalogWrite)
+		// устаревший вариант с id в теле, оставлен для старых клиентов
+		genresGroup.DELETE("", app.DeleteGenreLegacyHandler().Handle, catalogWrite)
+		genresGroup.POST("/:id/restore", app.PostGenreRestoreHandler().Handle, catalogWrite)
+		genresGroup.GET("/:id", app.GetGenreHandler().Handle)
+		genresGroup.PATCH("/:id", app.PatchGenreHandler().Handle, catalogWrite)
+		genresGroup.GET("/:id/albums", app.GetGenreAlbumsHandler().Handle)
 	}
+
+	promotionsGroup := handler.Group("/promotions", promotionsManage)
+	{
+		promotionsGroup.GET("", app.GetPromotionsHandler().Handle)
+		promotionsGroup.POST("", app.PostPromotionHandler().Handle)
+	}
+
+	handler.GET("/audit", app.GetAuditHandler().Handle, auditRead)
 }
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/config"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Запросы, отклоненные на уровне прав, не доходят до обработчиков,
+// поэтому роутер проверяется без базы данных
+func TestRoutePermissions(t *testing.T) {
+	app := &App{cfg: &config.Config{Auth: config.Auth{SigningKey: "test-signing-key", AccessTTL: time.Minute}}}
+	e := app.EchoHandler()
+
+	mint := func(role entity.Role) string {
+		token, _, err := app.TokenManager().Issue(entity.User{ID: 1, Email: "staff@example.com", Role: role})
+		assert.NoError(t, err)
+		return token
+	}
+
+	catalogWrites := []struct{ method, path string }{
+		{http.MethodPost, "/albums"},
+		{http.MethodPut, "/albums/1"},
+		{http.MethodPatch, "/albums/1"},
+		{http.MethodDelete, "/albums/1"},
+		{http.MethodPost, "/albums/1/genres"},
+		{http.MethodPut, "/albums/1/genres"},
+		{http.MethodDelete, "/albums/1/genres/1"},
+		{http.MethodPut, "/albums/1/tracks"},
+		{http.MethodPost, "/artists"},
+		{http.MethodPut, "/artists/1"},
+		{http.MethodDelete, "/artists/1"},
+		{http.MethodPost, "/genres"},
+		{http.MethodDelete, "/genres/1"},
+		{http.MethodDelete, "/genres"},
+		{http.MethodPatch, "/genres/1"},
+		{http.MethodPost, "/albums/1/restore"},
+		{http.MethodPost, "/genres/1/restore"},
+		{http.MethodPost, "/albums/1/prices"},
+		{http.MethodGet, "/promotions"},
+		{http.MethodPost, "/promotions"},
+	}
+
+	for _, route := range catalogWrites {
+		for _, tc := range []struct {
+			name       string
+			role       entity.Role
+			wantStatus int
+		}{
+			{name: "anonymous", wantStatus: http.StatusUnauthorized},
+			{name: "customer", role: entity.RoleCustomer, wantStatus: http.StatusForbidden},
+		} {
+			t.Run(route.method+" "+route.path+" "+tc.name, func(t *testing.T) {
+				req := httptest.NewRequest(route.method, route.path, strings.NewReader("{}"))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				if tc.role != "" {
+					req.Header.Set(echo.HeaderAuthorization, "Bearer "+mint(tc.role))
+				}
+				rec := httptest.NewRecorder()
+
+				e.ServeHTTP(rec, req)
+
+				assert.Equal(t, tc.wantStatus, rec.Code)
+			})
+		}
+	}
+
+	// корзины и заказы доступны только пользователям с токеном
+	userRoutes := []struct{ method, path string }{
+		{http.MethodPost, "/carts"},
+		{http.MethodGet, "/carts/1"},
+		{http.MethodPut, "/carts/1/items/1"},
+		{http.MethodDelete, "/carts/1/items/1"},
+		{http.MethodGet, "/carts/1/pricing"},
+		{http.MethodPost, "/carts/1/checkout"},
+		{http.MethodGet, "/orders/1"},
+	}
+
+	for _, route := range userRoutes {
+		t.Run(route.method+" "+route.path+" anonymous", func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, httptest.NewRequest(route.method, route.path, nil))
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		path       string
+		role       entity.Role
+		wantStatus int
+	}{
+		// пустое тело не проходит валидацию, значит проверка прав пройдена
+		{name: "editor creates album", method: http.MethodPost, path: "/albums", role: entity.RoleCatalogEditor, wantStatus: http.StatusBadRequest},
+		{name: "admin creates genre", method: http.MethodPost, path: "/genres", role: entity.RoleAdmin, wantStatus: http.StatusBadRequest},
+		{name: "admin deletes genre by body", method: http.MethodDelete, path: "/genres?policy=cascade", role: entity.RoleAdmin, wantStatus: http.StatusBadRequest},
+		{name: "editor sets sku price", method: http.MethodPut, path: "/albums/1/skus/vinyl", role: entity.RoleCatalogEditor, wantStatus: http.StatusBadRequest},
+		{name: "editor schedules price", method: http.MethodPost, path: "/albums/1/prices", role: entity.RoleCatalogEditor, wantStatus: http.StatusBadRequest},
+		{name: "editor changes order status", method: http.MethodPatch, path: "/orders/1/status", role: entity.RoleCatalogEditor, wantStatus: http.StatusForbidden},
+		{name: "admin changes order status", method: http.MethodPatch, path: "/orders/1/status", role: entity.RoleAdmin, wantStatus: http.StatusBadRequest},
+		{name: "customer sets cart item", method: http.MethodPut, path: "/carts/1/items/1", role: entity.RoleCustomer, wantStatus: http.StatusBadRequest},
+		{name: "customer gets order", method: http.MethodGet, path: "/orders/abc", role: entity.RoleCustomer, wantStatus: http.StatusBadRequest},
+		// include_deleted закрыт для всех, кроме администраторов, limit=1000 не проходит валидацию
+		{name: "customer lists albums", method: http.MethodGet, path: "/albums?limit=1000", role: entity.RoleCustomer, wantStatus: http.StatusBadRequest},
+		{name: "customer lists deleted albums", method: http.MethodGet, path: "/albums?include_deleted=true", role: entity.RoleCustomer, wantStatus: http.StatusForbidden},
+		{name: "editor lists deleted genres", method: http.MethodGet, path: "/genres?include_deleted=1", role: entity.RoleCatalogEditor, wantStatus: http.StatusForbidden},
+		{name: "admin lists deleted albums", method: http.MethodGet, path: "/albums?include_deleted=true&limit=1000", role: entity.RoleAdmin, wantStatus: http.StatusBadRequest},
+		// журнал изменений доступен только администраторам
+		{name: "editor reads audit", method: http.MethodGet, path: "/audit?entity=album&id=1", role: entity.RoleCatalogEditor, wantStatus: http.StatusForbidden},
+		{name: "admin reads audit", method: http.MethodGet, path: "/audit?entity=artist", role: entity.RoleAdmin, wantStatus: http.StatusBadRequest},
+		// акциями управляют только администраторы
+		{name: "editor creates promotion", method: http.MethodPost, path: "/promotions", role: entity.RoleCatalogEditor, wantStatus: http.StatusForbidden},
+		{name: "admin creates promotion", method: http.MethodPost, path: "/promotions", role: entity.RoleAdmin, wantStatus: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader("{}"))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+mint(tc.role))
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+		})
+	}
+}
+
+// Проверки доступны без токена; после начала остановки /readyz отвечает 503, не обращаясь к базе
+func TestHealthRoutes(t *testing.T) {
+	app := &App{cfg: &config.Config{Auth: config.Auth{SigningKey: "test-signing-key", AccessTTL: time.Minute}}}
+	e := app.EchoHandler()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok","checks":[]}`, rec.Body.String())
+
+	app.HealthService().StartShutdown()
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"shutdown"`)
+}
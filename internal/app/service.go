@@ -1,15 +1,28 @@
 package app
 
 import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/database"
 	"github.com/4udiwe/musicshop/internal/service/albums"
+	"github.com/4udiwe/musicshop/internal/service/artists"
+	"github.com/4udiwe/musicshop/internal/service/audit"
+	"github.com/4udiwe/musicshop/internal/service/carts"
 	"github.com/4udiwe/musicshop/internal/service/genres"
+	"github.com/4udiwe/musicshop/internal/service/health"
+	"github.com/4udiwe/musicshop/internal/service/orders"
+	"github.com/4udiwe/musicshop/internal/service/prices"
+	"github.com/4udiwe/musicshop/internal/service/promotions"
+	"github.com/4udiwe/musicshop/internal/service/stock"
+	"github.com/4udiwe/musicshop/internal/service/users"
 )
 
 func (app *App) AlbumsService() *albums.Service {
 	if app.albumsService != nil {
 		return app.albumsService
 	}
-	app.albumsService = albums.New(app.AlbumsRepo(), app.GenresRepo(), app.Postgres())
+	app.albumsService = albums.New(app.AlbumsRepo(), app.GenresRepo(), app.ArtistsRepo(), app.TracksRepo(), app.AuditRepo(), app.PricesService(), app.Metrics(), app.Postgres())
 	return app.albumsService
 }
 
@@ -17,6 +30,90 @@ func (app *App) GenresService() *genres.Service {
 	if app.genresService != nil {
 		return app.genresService
 	}
-	app.genresService = genres.New(app.GenresRepo(), app.Postgres())
+	app.genresService = genres.New(app.GenresRepo(), app.AuditRepo(), app.Metrics(), app.Postgres())
 	return app.genresService
 }
+
+func (app *App) ArtistsService() *artists.Service {
+	if app.artistsService != nil {
+		return app.artistsService
+	}
+	app.artistsService = artists.New(app.ArtistsRepo(), app.Postgres())
+	return app.artistsService
+}
+
+func (app *App) StockService() *stock.Service {
+	if app.stockService != nil {
+		return app.stockService
+	}
+	app.stockService = stock.New(app.StockRepo(), app.AlbumsRepo(), app.Postgres())
+	return app.stockService
+}
+
+func (app *App) CartsService() *carts.Service {
+	if app.cartsService != nil {
+		return app.cartsService
+	}
+	app.cartsService = carts.New(app.CartsRepo(), app.PricesService())
+	return app.cartsService
+}
+
+func (app *App) OrdersService() *orders.Service {
+	if app.ordersService != nil {
+		return app.ordersService
+	}
+	app.ordersService = orders.New(app.OrdersRepo(), app.CartsRepo(), app.StockRepo(), app.PricesService(), app.PromotionsService(), app.Postgres())
+	return app.ordersService
+}
+
+func (app *App) UsersService() *users.Service {
+	if app.usersService != nil {
+		return app.usersService
+	}
+	app.usersService = users.New(app.UsersRepo(), app.TokenManager(), app.Postgres(), app.cfg.Auth.RefreshTTL)
+	return app.usersService
+}
+
+func (app *App) AuditService() *audit.Service {
+	if app.auditService != nil {
+		return app.auditService
+	}
+	app.auditService = audit.New(app.AuditRepo())
+	return app.auditService
+}
+
+func (app *App) TokenManager() *auth.Manager {
+	if app.tokenManager != nil {
+		return app.tokenManager
+	}
+	app.tokenManager = auth.NewManager([]byte(app.cfg.Auth.SigningKey), app.cfg.Auth.AccessTTL)
+	return app.tokenManager
+}
+
+func (app *App) PricesService() *prices.Service {
+	if app.pricesService != nil {
+		return app.pricesService
+	}
+	app.pricesService = prices.New(app.PricesRepo(), app.AlbumsRepo(), app.AuditRepo(), app.exchangeRates, app.Postgres())
+	return app.pricesService
+}
+
+func (app *App) PromotionsService() *promotions.Service {
+	if app.promotionsService != nil {
+		return app.promotionsService
+	}
+	app.promotionsService = promotions.New(app.PromotionsRepo(), app.CartsRepo(), app.PricesService())
+	return app.promotionsService
+}
+
+func (app *App) HealthService() *health.Service {
+	if app.healthService != nil {
+		return app.healthService
+	}
+	latest, err := database.LatestVersion()
+	if err != nil {
+		log.Fatalf("app - HealthService - database.LatestVersion: %v", err)
+	}
+	app.healthService = health.New(app.Postgres(), database.NewSchema(app.Postgres()), latest)
+	return app.healthService
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/4udiwe/musicshop/internal/tracing"
+)
+
+func (app *App) TracerProvider() trace.TracerProvider {
+	if app.tracerProvider != nil {
+		return app.tracerProvider
+	}
+	app.tracerProvider = otel.GetTracerProvider()
+	return app.tracerProvider
+}
+
+// setupTracing настраивает глобальный провайдер трейсов, возвращает функцию его остановки
+func (app *App) setupTracing() func(context.Context) error {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName:    app.cfg.App.Name,
+		ServiceVersion: app.cfg.App.Version,
+		Exporter:       app.cfg.Tracing.Exporter,
+		Endpoint:       app.cfg.Tracing.Endpoint,
+		Insecure:       app.cfg.Tracing.Insecure,
+	})
+	if err != nil {
+		log.Fatalf("app - Start - tracing.Setup: %v", err)
+	}
+	return shutdown
+}
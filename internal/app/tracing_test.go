@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/config"
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Спан запроса называется по шаблону маршрута и продолжает трейс из traceparent
+func TestRouteTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(exporter, "musicshop", "test", false)
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	app := &App{
+		cfg:            &config.Config{Auth: config.Auth{SigningKey: "test-signing-key", AccessTTL: time.Minute}},
+		tracerProvider: provider,
+	}
+	e := app.EchoHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/albums/42/restore", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		span := spans[0]
+		assert.Equal(t, "POST /albums/:id/restore", span.Name)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+		assert.Contains(t, span.Attributes, attribute.Int("http.status_code", http.StatusUnauthorized))
+	}
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+// Principal - аутентифицированный пользователь текущего запроса
+type Principal struct {
+	UserID int64
+	Email  string
+	Role   entity.Role
+}
+
+func (p Principal) Can(permission Permission) bool {
+	return Allowed(p.Role, permission)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// IsUser сообщает, что запрос выполняет пользователь userID.
+// Анонимный запрос не принадлежит никому.
+func IsUser(ctx context.Context, userID int64) bool {
+	p, ok := PrincipalFromContext(ctx)
+	return ok && p.UserID == userID
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"slices"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type Permission string
+
+const (
+	// Изменение каталога: альбомы, жанры, артисты, треки
+	PermissionCatalogWrite Permission = "catalog:write"
+	// Цены и складские остатки
+	PermissionInventoryWrite Permission = "inventory:write"
+	// Смена статусов заказов
+	PermissionOrdersManage Permission = "orders:manage"
+	// Просмотр мягко удаленных записей каталога
+	PermissionCatalogViewDeleted Permission = "catalog:view_deleted"
+	// Просмотр журнала изменений каталога
+	PermissionAuditRead Permission = "audit:read"
+	// Управление акциями и кодами скидок
+	PermissionPromotionsManage Permission = "promotions:manage"
+)
+
+var rolePermissions = map[entity.Role][]Permission{
+	entity.RoleCustomer: {},
+	entity.RoleCatalogEditor: {
+		PermissionCatalogWrite,
+		PermissionInventoryWrite,
+	},
+	entity.RoleAdmin: {
+		PermissionCatalogWrite,
+		PermissionInventoryWrite,
+		PermissionOrdersManage,
+		PermissionCatalogViewDeleted,
+		PermissionAuditRead,
+		PermissionPromotionsManage,
+	},
+}
+
+// Allowed сообщает, есть ли у роли разрешение. Неизвестные роли не имеют разрешений.
+func Allowed(role entity.Role, permission Permission) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
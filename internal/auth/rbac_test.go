@@ -0,0 +1,38 @@
+package auth_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowed(t *testing.T) {
+	permissions := []auth.Permission{
+		auth.PermissionCatalogWrite,
+		auth.PermissionInventoryWrite,
+		auth.PermissionOrdersManage,
+		auth.PermissionCatalogViewDeleted,
+		auth.PermissionAuditRead,
+		auth.PermissionPromotionsManage,
+	}
+
+	for _, tc := range []struct {
+		role    entity.Role
+		allowed []auth.Permission
+	}{
+		{role: entity.RoleCustomer, allowed: nil},
+		{role: entity.RoleCatalogEditor, allowed: []auth.Permission{auth.PermissionCatalogWrite, auth.PermissionInventoryWrite}},
+		{role: entity.RoleAdmin, allowed: permissions},
+		{role: entity.Role("unknown"), allowed: nil},
+		{role: entity.Role(""), allowed: nil},
+	} {
+		for _, p := range permissions {
+			t.Run(string(tc.role)+"/"+string(p), func(t *testing.T) {
+				assert.Equal(t, slices.Contains(tc.allowed, p), auth.Allowed(tc.role, p))
+			})
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type claims struct {
+	Email string      `json:"email"`
+	Role  entity.Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Manager выпускает и проверяет access-токены, подписанные HS256
+type Manager struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewManager(key []byte, ttl time.Duration) *Manager {
+	return &Manager{
+		key: key,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+func (m *Manager) Issue(user entity.User) (string, time.Time, error) {
+	now := m.now()
+	expiresAt := now.Add(m.ttl)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Email: user.Email,
+		Role:  user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(user.ID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+func (m *Manager) Parse(token string) (Principal, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (any, error) {
+		return m.key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: bad subject %q", ErrInvalidToken, c.Subject)
+	}
+	return Principal{UserID: id, Email: c.Email, Role: c.Role}, nil
+}
@@ -3,14 +3,26 @@ package database
 import (
 	"context"
 	"database/sql"
+	"embed"
 	"fmt"
+	"io/fs"
 	"log"
+	"path"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
+
+	"github.com/4udiwe/musicshop/pkg/postgres"
 )
 
+// migrations встроены в бинарник, чтобы версия схемы, которую ждет код, была известна без файлов на диске
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+const migrationsDir = "migrations"
+
 // RunMigrations применяет все pending миграции
 func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 	// Конвертируем pgxpool.Pool в *sql.DB (goose требует database/sql)
@@ -25,7 +37,8 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 	}
 
 	// Применяем миграции
-	if err := goose.Up(db, "database/migrations"); err != nil {
+	goose.SetBaseFS(migrations)
+	if err := goose.Up(db, migrationsDir); err != nil {
 		return fmt.Errorf("failed to apply migrations: %w", err)
 	}
 
@@ -39,6 +52,42 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 	return nil
 }
 
+// LatestVersion возвращает версию последней встроенной миграции
+func LatestVersion() (int64, error) {
+	names, err := fs.Glob(migrations, path.Join(migrationsDir, "*.sql"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	var latest int64
+	for _, name := range names {
+		version, err := goose.NumericComponent(name)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse migration version: %w", err)
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
+
+// Schema читает версию схемы из таблицы goose
+type Schema struct {
+	pg *postgres.Postgres
+}
+
+func NewSchema(pg *postgres.Postgres) *Schema {
+	return &Schema{pg: pg}
+}
+
+// Version возвращает версию последней примененной миграции
+func (s *Schema) Version(ctx context.Context) (int64, error) {
+	// закрытие *sql.DB не закрывает пул
+	db := stdlib.OpenDBFromPool(s.pg.Pool)
+	defer db.Close()
+
+	return goose.GetDBVersionContext(ctx, db)
+}
+
 // pgxPoolToStdlib конвертирует pgxpool.Pool в *sql.DB
 func pgxPoolToStdlib(ctx context.Context, pool *pgxpool.Pool) (*sql.DB, error) {
 	// Используем адаптер из pgx
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS artists (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    bio TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO artists (name)
+SELECT DISTINCT artist FROM albums
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS artist_id BIGINT REFERENCES artists(id) ON DELETE RESTRICT;
+
+UPDATE albums a
+SET artist_id = ar.id
+FROM artists ar
+WHERE ar.name = a.artist;
+
+ALTER TABLE albums ALTER COLUMN artist_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_albums_artist_id ON albums(artist_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_albums_artist_id;
+ALTER TABLE albums DROP COLUMN IF EXISTS artist_id;
+DROP TABLE IF EXISTS artists;
+-- +goose StatementEnd
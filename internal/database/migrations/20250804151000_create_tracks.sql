@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tracks (
+    id BIGSERIAL PRIMARY KEY,
+    album_id BIGINT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    disc_number INT NOT NULL DEFAULT 1 CHECK (disc_number > 0),
+    position INT NOT NULL CHECK (position > 0),
+    title TEXT NOT NULL,
+    duration_seconds INT NOT NULL CHECK (duration_seconds >= 0),
+    isrc TEXT CHECK (isrc ~ '^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$'),
+    UNIQUE (album_id, disc_number, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracks_album_id ON tracks(album_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tracks;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS album_skus (
+    id BIGSERIAL PRIMARY KEY,
+    album_id BIGINT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    format TEXT NOT NULL CHECK (format IN ('cd', 'vinyl', 'cassette', 'digital')),
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    quantity INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (album_id, format)
+);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    sku_id BIGINT NOT NULL REFERENCES album_skus(id) ON DELETE CASCADE,
+    delta INT NOT NULL CHECK (delta <> 0),
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_album_skus_album_id ON album_skus(album_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_sku_id ON stock_movements(sku_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS album_skus;
+-- +goose StatementEnd
@@ -0,0 +1,79 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS search_vector tsvector NOT NULL DEFAULT ''::tsvector;
+
+-- Поисковый вектор: название (A), артист (B) и имена жанров (C).
+-- Конфигурация simple не применяет стемминг, что удобнее для имен и названий.
+CREATE OR REPLACE FUNCTION album_search_vector(p_title TEXT, p_artist TEXT, p_album_id BIGINT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(p_title, '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE(p_artist, '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT string_agg(g.name, ' ')
+            FROM album_genres ag
+            JOIN genres g ON g.id = ag.genre_id
+            WHERE ag.album_id = p_album_id
+        ), '')), 'C')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION albums_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := album_search_vector(NEW.title, NEW.artist, NEW.id);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER albums_search_vector_update
+    BEFORE INSERT OR UPDATE OF title, artist ON albums
+    FOR EACH ROW EXECUTE FUNCTION albums_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION album_genres_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    UPDATE albums
+    SET search_vector = album_search_vector(title, artist, id)
+    WHERE id = COALESCE(NEW.album_id, OLD.album_id);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER album_genres_search_vector_update
+    AFTER INSERT OR DELETE ON album_genres
+    FOR EACH ROW EXECUTE FUNCTION album_genres_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION genres_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    UPDATE albums
+    SET search_vector = album_search_vector(title, artist, id)
+    WHERE id IN (SELECT album_id FROM album_genres WHERE genre_id = NEW.id);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER genres_search_vector_update
+    AFTER UPDATE OF name ON genres
+    FOR EACH ROW EXECUTE FUNCTION genres_search_vector_trigger();
+
+UPDATE albums SET search_vector = album_search_vector(title, artist, id);
+
+CREATE INDEX IF NOT EXISTS idx_albums_search_vector ON albums USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_albums_title_trgm ON albums USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_albums_artist_trgm ON albums USING GIN (artist gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_albums_artist_trgm;
+DROP INDEX IF EXISTS idx_albums_title_trgm;
+DROP INDEX IF EXISTS idx_albums_search_vector;
+DROP TRIGGER IF EXISTS genres_search_vector_update ON genres;
+DROP TRIGGER IF EXISTS album_genres_search_vector_update ON album_genres;
+DROP TRIGGER IF EXISTS albums_search_vector_update ON albums;
+DROP FUNCTION IF EXISTS genres_search_vector_trigger();
+DROP FUNCTION IF EXISTS album_genres_search_vector_trigger();
+DROP FUNCTION IF EXISTS albums_search_vector_trigger();
+DROP FUNCTION IF EXISTS album_search_vector(TEXT, TEXT, BIGINT);
+ALTER TABLE albums DROP COLUMN IF EXISTS search_vector;
+DROP EXTENSION IF EXISTS pg_trgm;
+-- +goose StatementEnd
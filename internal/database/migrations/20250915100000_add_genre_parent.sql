@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE genres
+    ADD COLUMN IF NOT EXISTS parent_id BIGINT REFERENCES genres(id),
+    ADD CONSTRAINT genres_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_genres_parent ON genres(parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_genres_parent;
+ALTER TABLE genres
+    DROP CONSTRAINT IF EXISTS genres_parent_not_self,
+    DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE genres ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_albums_deleted_at ON albums(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_genres_deleted_at ON genres(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Имя удаленного жанра можно занять заново
+ALTER TABLE genres DROP CONSTRAINT IF EXISTS genres_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS genres_name_active_key ON genres(name) WHERE deleted_at IS NULL;
+
+-- Удаленные жанры не участвуют в поиске
+CREATE OR REPLACE FUNCTION album_search_vector(p_title TEXT, p_artist TEXT, p_album_id BIGINT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(p_title, '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE(p_artist, '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT string_agg(g.name, ' ')
+            FROM album_genres ag
+            JOIN genres g ON g.id = ag.genre_id
+            WHERE ag.album_id = p_album_id AND g.deleted_at IS NULL
+        ), '')), 'C')
+$$ LANGUAGE sql STABLE;
+
+DROP TRIGGER IF EXISTS genres_search_vector_update ON genres;
+CREATE TRIGGER genres_search_vector_update
+    AFTER UPDATE OF name, deleted_at ON genres
+    FOR EACH ROW EXECUTE FUNCTION genres_search_vector_trigger();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS genres_search_vector_update ON genres;
+CREATE TRIGGER genres_search_vector_update
+    AFTER UPDATE OF name ON genres
+    FOR EACH ROW EXECUTE FUNCTION genres_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION album_search_vector(p_title TEXT, p_artist TEXT, p_album_id BIGINT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(p_title, '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE(p_artist, '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT string_agg(g.name, ' ')
+            FROM album_genres ag
+            JOIN genres g ON g.id = ag.genre_id
+            WHERE ag.album_id = p_album_id
+        ), '')), 'C')
+$$ LANGUAGE sql STABLE;
+
+DROP INDEX IF EXISTS genres_name_active_key;
+ALTER TABLE genres ADD CONSTRAINT genres_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_genres_deleted_at;
+DROP INDEX IF EXISTS idx_albums_deleted_at;
+ALTER TABLE genres DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE albums DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
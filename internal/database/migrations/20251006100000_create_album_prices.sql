@@ -0,0 +1,87 @@
+-- +goose Up
+-- +goose StatementBegin
+-- История цен альбома: цена действует в полуинтервале [effective_from, effective_until),
+-- effective_until IS NULL - до следующего изменения. Будущие цены планируются заранее
+-- и становятся текущими без фоновых задач: цена вычисляется на момент запроса.
+CREATE TABLE IF NOT EXISTS album_prices (
+    id BIGSERIAL PRIMARY KEY,
+    album_id BIGINT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    effective_from TIMESTAMPTZ NOT NULL,
+    effective_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (album_id, effective_from),
+    CHECK (effective_until IS NULL OR effective_until > effective_from)
+);
+
+INSERT INTO album_prices (album_id, price, effective_from)
+SELECT id, price, created_at FROM albums;
+
+-- Цена альбома на момент p_at, NULL - если альбома тогда еще не было
+CREATE OR REPLACE FUNCTION album_price_at(p_album_id BIGINT, p_at TIMESTAMPTZ)
+RETURNS DECIMAL(10,2) AS $$
+    SELECT price
+    FROM album_prices
+    WHERE album_id = p_album_id AND effective_from <= p_at
+    ORDER BY effective_from DESC
+    LIMIT 1
+$$ LANGUAGE sql STABLE;
+
+-- Планирует цену с момента p_from: действующая тогда цена закрывается на p_from,
+-- новая действует до следующей запланированной. Цена на тот же момент заменяется.
+CREATE OR REPLACE FUNCTION schedule_album_price(p_album_id BIGINT, p_price DECIMAL(10,2), p_from TIMESTAMPTZ)
+RETURNS album_prices AS $$
+DECLARE
+    v_until TIMESTAMPTZ;
+    v_row album_prices;
+BEGIN
+    -- Сериализуем изменения цен одного альбома
+    PERFORM 1 FROM albums WHERE id = p_album_id FOR UPDATE;
+
+    SELECT MIN(effective_from) INTO v_until
+    FROM album_prices
+    WHERE album_id = p_album_id AND effective_from > p_from;
+
+    UPDATE album_prices
+    SET effective_until = p_from
+    WHERE album_id = p_album_id
+        AND effective_from < p_from
+        AND (effective_until IS NULL OR effective_until > p_from);
+
+    INSERT INTO album_prices (album_id, price, effective_from, effective_until)
+    VALUES (p_album_id, p_price, p_from, v_until)
+    ON CONFLICT (album_id, effective_from) DO UPDATE SET price = EXCLUDED.price
+    RETURNING * INTO v_row;
+
+    RETURN v_row;
+END
+$$ LANGUAGE plpgsql;
+
+-- Прямые изменения albums.price (создание, PUT/PATCH, синхронизация с SKU) попадают в историю
+CREATE OR REPLACE FUNCTION albums_price_history_trigger() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.price IS DISTINCT FROM album_price_at(NEW.id, NOW()) THEN
+        PERFORM schedule_album_price(NEW.id, NEW.price, NOW());
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER albums_price_history
+    AFTER INSERT OR UPDATE OF price ON albums
+    FOR EACH ROW EXECUTE FUNCTION albums_price_history_trigger();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS albums_price_history ON albums;
+DROP FUNCTION IF EXISTS albums_price_history_trigger();
+
+-- Без истории цена снова хранится только в albums.price
+UPDATE albums SET price = album_price_at(id, NOW())
+WHERE price IS DISTINCT FROM album_price_at(id, NOW()) AND album_price_at(id, NOW()) IS NOT NULL;
+
+DROP FUNCTION IF EXISTS schedule_album_price(BIGINT, DECIMAL, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS album_price_at(BIGINT, TIMESTAMPTZ);
+DROP TABLE IF EXISTS album_prices;
+-- +goose StatementEnd
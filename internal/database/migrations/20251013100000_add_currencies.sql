@@ -0,0 +1,144 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Суммы хранятся целыми числами в минимальных единицах валюты (центах).
+-- Цены каталога, SKU и уже оформленных заказов - в базовой валюте USD.
+DROP TRIGGER IF EXISTS albums_price_history ON albums;
+DROP FUNCTION IF EXISTS albums_price_history_trigger();
+DROP FUNCTION IF EXISTS schedule_album_price(BIGINT, DECIMAL, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS album_price_at(BIGINT, TIMESTAMPTZ);
+
+ALTER TABLE albums ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE album_skus ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE order_items ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+
+ALTER TABLE orders ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$');
+
+-- История цен ведется отдельно по каждой валюте, цена в USD есть у каждого альбома
+ALTER TABLE album_prices ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE album_prices ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$');
+ALTER TABLE album_prices ALTER COLUMN currency DROP DEFAULT;
+ALTER TABLE album_prices DROP CONSTRAINT album_prices_album_id_effective_from_key;
+ALTER TABLE album_prices ADD CONSTRAINT album_prices_album_id_currency_effective_from_key
+    UNIQUE (album_id, currency, effective_from);
+
+-- Цена альбома в валюте на момент p_at, NULL - если цены в этой валюте тогда не было
+CREATE OR REPLACE FUNCTION album_price_at(p_album_id BIGINT, p_currency TEXT, p_at TIMESTAMPTZ)
+RETURNS BIGINT AS $$
+    SELECT price
+    FROM album_prices
+    WHERE album_id = p_album_id AND currency = p_currency AND effective_from <= p_at
+    ORDER BY effective_from DESC
+    LIMIT 1
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION schedule_album_price(p_album_id BIGINT, p_currency TEXT, p_price BIGINT, p_from TIMESTAMPTZ)
+RETURNS album_prices AS $$
+DECLARE
+    v_until TIMESTAMPTZ;
+    v_row album_prices;
+BEGIN
+    PERFORM 1 FROM albums WHERE id = p_album_id FOR UPDATE;
+
+    SELECT MIN(effective_from) INTO v_until
+    FROM album_prices
+    WHERE album_id = p_album_id AND currency = p_currency AND effective_from > p_from;
+
+    UPDATE album_prices
+    SET effective_until = p_from
+    WHERE album_id = p_album_id
+        AND currency = p_currency
+        AND effective_from < p_from
+        AND (effective_until IS NULL OR effective_until > p_from);
+
+    INSERT INTO album_prices (album_id, currency, price, effective_from, effective_until)
+    VALUES (p_album_id, p_currency, p_price, p_from, v_until)
+    ON CONFLICT (album_id, currency, effective_from) DO UPDATE SET price = EXCLUDED.price
+    RETURNING * INTO v_row;
+
+    RETURN v_row;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION albums_price_history_trigger() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.price IS DISTINCT FROM album_price_at(NEW.id, 'USD', NOW()) THEN
+        PERFORM schedule_album_price(NEW.id, 'USD', NEW.price, NOW());
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER albums_price_history
+    AFTER INSERT OR UPDATE OF price ON albums
+    FOR EACH ROW EXECUTE FUNCTION albums_price_history_trigger();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS albums_price_history ON albums;
+DROP FUNCTION IF EXISTS albums_price_history_trigger();
+DROP FUNCTION IF EXISTS schedule_album_price(BIGINT, TEXT, BIGINT, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS album_price_at(BIGINT, TEXT, TIMESTAMPTZ);
+
+-- Цены в других валютах без поддержки валют не имеют смысла
+DELETE FROM album_prices WHERE currency <> 'USD';
+ALTER TABLE album_prices DROP CONSTRAINT album_prices_album_id_currency_effective_from_key;
+ALTER TABLE album_prices ADD CONSTRAINT album_prices_album_id_effective_from_key UNIQUE (album_id, effective_from);
+ALTER TABLE album_prices DROP COLUMN currency;
+ALTER TABLE album_prices ALTER COLUMN price TYPE DECIMAL(10,2) USING price / 100.0;
+
+ALTER TABLE orders DROP COLUMN currency;
+
+ALTER TABLE order_items ALTER COLUMN price TYPE DECIMAL(10,2) USING price / 100.0;
+ALTER TABLE album_skus ALTER COLUMN price TYPE DECIMAL(10,2) USING price / 100.0;
+ALTER TABLE albums ALTER COLUMN price TYPE DECIMAL(10,2) USING price / 100.0;
+
+CREATE OR REPLACE FUNCTION album_price_at(p_album_id BIGINT, p_at TIMESTAMPTZ)
+RETURNS DECIMAL(10,2) AS $$
+    SELECT price
+    FROM album_prices
+    WHERE album_id = p_album_id AND effective_from <= p_at
+    ORDER BY effective_from DESC
+    LIMIT 1
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION schedule_album_price(p_album_id BIGINT, p_price DECIMAL(10,2), p_from TIMESTAMPTZ)
+RETURNS album_prices AS $$
+DECLARE
+    v_until TIMESTAMPTZ;
+    v_row album_prices;
+BEGIN
+    PERFORM 1 FROM albums WHERE id = p_album_id FOR UPDATE;
+
+    SELECT MIN(effective_from) INTO v_until
+    FROM album_prices
+    WHERE album_id = p_album_id AND effective_from > p_from;
+
+    UPDATE album_prices
+    SET effective_until = p_from
+    WHERE album_id = p_album_id
+        AND effective_from < p_from
+        AND (effective_until IS NULL OR effective_until > p_from);
+
+    INSERT INTO album_prices (album_id, price, effective_from, effective_until)
+    VALUES (p_album_id, p_price, p_from, v_until)
+    ON CONFLICT (album_id, effective_from) DO UPDATE SET price = EXCLUDED.price
+    RETURNING * INTO v_row;
+
+    RETURN v_row;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION albums_price_history_trigger() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.price IS DISTINCT FROM album_price_at(NEW.id, NOW()) THEN
+        PERFORM schedule_album_price(NEW.id, NEW.price, NOW());
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER albums_price_history
+    AFTER INSERT OR UPDATE OF price ON albums
+    FOR EACH ROW EXECUTE FUNCTION albums_price_history_trigger();
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS promotions (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    -- NULL - автоматическая акция без кода
+    code TEXT UNIQUE CHECK (code = UPPER(code)),
+    kind TEXT NOT NULL CHECK (kind IN ('percent', 'fixed', 'buy_x_get_y')),
+    percent INT NOT NULL DEFAULT 0 CHECK (percent BETWEEN 0 AND 100),
+    amount BIGINT NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    currency TEXT NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$'),
+    buy_quantity INT NOT NULL DEFAULT 0 CHECK (buy_quantity >= 0),
+    free_quantity INT NOT NULL DEFAULT 0 CHECK (free_quantity >= 0),
+    target TEXT NOT NULL CHECK (target IN ('all', 'genre', 'artist', 'album')),
+    target_ids BIGINT[] NOT NULL DEFAULT '{}',
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMPTZ CHECK (ends_at > starts_at),
+    usage_limit INT CHECK (usage_limit > 0),
+    usage_count INT NOT NULL DEFAULT 0 CHECK (usage_limit IS NULL OR usage_count <= usage_limit),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_automatic ON promotions(starts_at) WHERE code IS NULL;
+
+-- Скидка позиции и код акции фиксируются в заказе вместе с ценой
+ALTER TABLE order_items ADD COLUMN discount BIGINT NOT NULL DEFAULT 0 CHECK (discount >= 0);
+ALTER TABLE orders ADD COLUMN promo_code TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code;
+ALTER TABLE order_items DROP COLUMN IF EXISTS discount;
+DROP TABLE IF EXISTS promotions;
+-- +goose StatementEnd
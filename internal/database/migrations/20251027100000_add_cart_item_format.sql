@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Позиция корзины ссылается на SKU: формат определяет цену и остаток, с которого
+-- списывается товар при оформлении. Одна позиция на альбом в корзине.
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS format TEXT;
+
+-- Существующим позициям достается самый дешевый формат - по его цене они и показывались
+UPDATE cart_items ci
+SET format = (
+    SELECT s.format FROM album_skus s
+    WHERE s.album_id = ci.album_id
+    ORDER BY s.price, s.id
+    LIMIT 1
+);
+
+-- альбомы без SKU продать нельзя
+DELETE FROM cart_items WHERE format IS NULL;
+
+ALTER TABLE cart_items ALTER COLUMN format SET NOT NULL;
+ALTER TABLE cart_items ADD CONSTRAINT cart_items_sku_fkey
+    FOREIGN KEY (album_id, format) REFERENCES album_skus(album_id, format) ON DELETE CASCADE;
+
+-- Формат проданной позиции, NULL у заказов, оформленных до появления форматов в корзине
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS format TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_items DROP COLUMN IF EXISTS format;
+ALTER TABLE cart_items DROP CONSTRAINT IF EXISTS cart_items_sku_fkey;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS format;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Корзины и заказы принадлежат пользователям. Анонимные корзины присвоить некому,
+-- поэтому они удаляются; анонимные заказы остаются без владельца и видны только персоналу.
+DELETE FROM carts;
+ALTER TABLE carts ADD COLUMN IF NOT EXISTS user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_carts_user_id ON carts(user_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS user_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON orders(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_orders_user_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS user_id;
+DROP INDEX IF EXISTS idx_carts_user_id;
+ALTER TABLE carts DROP COLUMN IF EXISTS user_id;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Альбомы с SKU продаются по ценам SKU, запланированные для них цены альбома не вступят в силу.
+-- Снимает будущие цены альбома во всех валютах, оставшиеся периоды снова действуют
+-- до следующей оставшейся цены.
+CREATE OR REPLACE FUNCTION drop_scheduled_album_prices(p_album_id BIGINT) RETURNS void AS $$
+BEGIN
+    DELETE FROM album_prices
+    WHERE album_id = p_album_id AND effective_from > NOW();
+
+    UPDATE album_prices p
+    SET effective_until = (
+        SELECT MIN(n.effective_from)
+        FROM album_prices n
+        WHERE n.album_id = p.album_id AND n.currency = p.currency AND n.effective_from > p.effective_from
+    )
+    WHERE p.album_id = p_album_id;
+END
+$$ LANGUAGE plpgsql;
+
+SELECT drop_scheduled_album_prices(album_id) FROM (SELECT DISTINCT album_id FROM album_skus) s;
+
+-- Первый SKU переводит альбом на цены SKU
+CREATE OR REPLACE FUNCTION album_skus_drop_scheduled_prices_trigger() RETURNS trigger AS $$
+BEGIN
+    PERFORM drop_scheduled_album_prices(NEW.album_id);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER album_skus_drop_scheduled_prices
+    AFTER INSERT ON album_skus
+    FOR EACH ROW EXECUTE FUNCTION album_skus_drop_scheduled_prices_trigger();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Снятые запланированные цены не восстанавливаются
+DROP TRIGGER IF EXISTS album_skus_drop_scheduled_prices ON album_skus;
+DROP FUNCTION IF EXISTS album_skus_drop_scheduled_prices_trigger();
+DROP FUNCTION IF EXISTS drop_scheduled_album_prices(BIGINT);
+-- +goose StatementEnd
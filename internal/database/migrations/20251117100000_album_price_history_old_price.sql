@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Цена попадает в историю, только когда albums.price действительно изменили. Сравнение
+-- с действующей по истории ценой осталось для синхронизации: она переносит в albums.price
+-- уже наступившую запланированную цену, и планировать ее повторно не нужно.
+CREATE OR REPLACE FUNCTION albums_price_history_trigger() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR (
+        NEW.price IS DISTINCT FROM OLD.price
+        AND NEW.price IS DISTINCT FROM album_price_at(NEW.id, 'USD', NOW())
+    ) THEN
+        PERFORM schedule_album_price(NEW.id, 'USD', NEW.price, NOW());
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION albums_price_history_trigger() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.price IS DISTINCT FROM album_price_at(NEW.id, 'USD', NOW()) THEN
+        PERFORM schedule_album_price(NEW.id, 'USD', NEW.price, NOW());
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
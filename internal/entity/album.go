@@ -1,9 +1,62 @@
 package entity
 
+import "time"
+
 type Album struct {
-	ID     int64
-	Title  string
-	Artist string
-	Price  float64
-	Genres []Genre
+	ID        int64
+	Title     string
+	ArtistID  int64
+	Artist    string // имя артиста, денормализовано из artists
+	Price     Money
+	Genres    []Genre
+	Tracks    []Track
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time // nil, если альбом не удален
+}
+
+// RunningTime возвращает суммарную длительность треков альбома
+func (a Album) RunningTime() time.Duration {
+	var total time.Duration
+	for _, t := range a.Tracks {
+		total += t.Duration
+	}
+	return total
+}
+
+// AlbumPatch описывает частичное обновление альбома.
+// Nil-поля остаются без изменений, Genres == nil не трогает жанры альбома.
+// Artist задает артиста по имени и используется, пока клиенты не перешли на ArtistID.
+type AlbumPatch struct {
+	Title    *string
+	ArtistID *int64
+	Artist   *string
+	Price    *Money
+	Genres   []Genre
+}
+
+// Apply применяет изменения к альбому и возвращает результат.
+func (p AlbumPatch) Apply(a Album) Album {
+	if p.Title != nil {
+		a.Title = *p.Title
+	}
+	if p.Artist != nil {
+		a.Artist = *p.Artist
+		a.ArtistID = 0
+	}
+	if p.ArtistID != nil {
+		a.ArtistID = *p.ArtistID
+	}
+	if p.Price != nil {
+		a.Price = *p.Price
+	}
+	if p.Genres != nil {
+		a.Genres = p.Genres
+	}
+	return a
+}
+
+// ChangesArtist сообщает, меняет ли патч артиста альбома
+func (p AlbumPatch) ChangesArtist() bool {
+	return p.Artist != nil || p.ArtistID != nil
 }
@@ -0,0 +1,48 @@
+package entity
+
+const (
+	GenreMatchAny = "any"
+	GenreMatchAll = "all"
+)
+
+// AlbumFilter описывает выборку альбомов: фильтры, сортировку и keyset-пагинацию по id.
+type AlbumFilter struct {
+	Limit  int
+	Cursor int64 // id последнего альбома предыдущей страницы, 0 - первая страница
+
+	ArtistID   int64
+	Artist     string
+	Title      string // подстрока названия
+	GenreIDs   []int64
+	GenreMatch string // GenreMatchAny или GenreMatchAll
+	MinPrice   *Money // в базовой валюте
+	MaxPrice   *Money
+
+	// IncludeSubgenres расширяет каждый жанр из GenreIDs его поджанрами
+	IncludeSubgenres bool
+	// GenreSubtrees заполняется сервисом при IncludeSubgenres: по поддереву на каждый жанр из GenreIDs
+	GenreSubtrees [][]int64
+
+	SortBy   string // title, artist, price, created_at; пусто - по id
+	SortDesc bool
+
+	IncludeDeleted bool // включать мягко удаленные альбомы
+
+	Currency string // валюта цен в результате, пусто - базовая
+}
+
+// AlbumPage - страница альбомов с курсором на следующую страницу
+type AlbumPage struct {
+	Albums     []Album
+	NextCursor int64 // 0, если следующей страницы нет
+	Total      int64
+}
+
+// AlbumSearch - полнотекстовый поиск альбомов. Результаты упорядочены по релевантности,
+// курсор - id последнего альбома предыдущей страницы.
+type AlbumSearch struct {
+	Query    string
+	Limit    int
+	Cursor   int64
+	Currency string // валюта цен в результате, пусто - базовая
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// AlbumPrice - период действия цены альбома в одной валюте [EffectiveFrom, EffectiveUntil).
+// EffectiveUntil == nil - цена действует до следующего изменения.
+type AlbumPrice struct {
+	ID             int64
+	AlbumID        int64
+	Price          Money
+	EffectiveFrom  time.Time
+	EffectiveUntil *time.Time
+	CreatedAt      time.Time
+}
+
+// ActiveAt сообщает, действует ли цена в момент at
+func (p AlbumPrice) ActiveAt(at time.Time) bool {
+	return !at.Before(p.EffectiveFrom) && (p.EffectiveUntil == nil || at.Before(*p.EffectiveUntil))
+}
@@ -0,0 +1,7 @@
+package entity
+
+type Artist struct {
+	ID   int64
+	Name string
+	Bio  string
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	// AuditActionLinkGenres и AuditActionUnlinkGenres - изменение набора жанров альбома,
+	// в снимке хранятся id привязанных или отвязанных жанров
+	AuditActionLinkGenres   = "link_genres"
+	AuditActionUnlinkGenres = "unlink_genres"
+	// AuditActionSchedulePrice - планирование цены альбома, в снимке - период цены
+	AuditActionSchedulePrice = "schedule_price"
+)
+
+const (
+	AuditEntityAlbum = "album"
+	AuditEntityGenre = "genre"
+)
+
+// AuditEvent - запись журнала изменений каталога.
+// Before и After - снимки сущности до и после изменения, nil, если состояния нет
+// (до создания или после удаления). При чтении из журнала снимки - json.RawMessage.
+type AuditEvent struct {
+	ID         int64
+	ActorID    *int64 // nil, если изменение сделано без аутентифицированного пользователя
+	ActorEmail string
+	Action     string
+	EntityType string
+	EntityID   int64
+	Before     any
+	After      any
+	CreatedAt  time.Time
+}
+
+// AuditFilter - выборка журнала, новые события первыми.
+// Пустые EntityType и EntityID не ограничивают выборку.
+type AuditFilter struct {
+	EntityType string
+	EntityID   int64
+	Limit      int
+	Cursor     int64 // id последнего события предыдущей страницы, 0 - первая страница
+}
+
+// AuditPage - страница журнала с курсором на следующую страницу
+type AuditPage struct {
+	Events     []AuditEvent
+	NextCursor int64 // 0, если следующей страницы нет
+}
@@ -0,0 +1,30 @@
+package entity
+
+type Cart struct {
+	ID int64
+	// UserID - владелец корзины
+	UserID int64
+	// Currency - валюта цен позиций корзины
+	Currency string
+	Items    []CartItem
+}
+
+// CartItem - строка корзины с текущими данными альбома. Альбом лежит в корзине
+// в одном формате, цена позиции - цена SKU этого формата.
+type CartItem struct {
+	AlbumID  int64
+	Format   string
+	Title    string
+	Artist   string
+	Price    Money
+	Quantity int
+}
+
+// Total возвращает стоимость корзины по текущим ценам
+func (c Cart) Total() Money {
+	var total int64
+	for _, item := range c.Items {
+		total += item.Price.Mul(item.Quantity).Amount
+	}
+	return NewMoney(total, c.Currency)
+}
@@ -1,6 +1,58 @@
 package entity
 
+import "time"
+
+const (
+	// GenreDeleteReject запрещает удалять жанр, у которого есть поджанры
+	GenreDeleteReject = "reject"
+	// GenreDeleteReparent переносит поджанры к родителю удаляемого жанра
+	GenreDeleteReparent = "reparent"
+	// GenreDeleteCascade удаляет жанр вместе со всеми поджанрами
+	GenreDeleteCascade = "cascade"
+)
+
 type Genre struct {
 	ID   int64  `db:"genre_id"`
 	Name string `db:"genre_id"`
+	// ParentID - родительский жанр, nil для корневых жанров
+	ParentID *int64 `db:"parent_id"`
+	// AlbumCount заполняется только при выборке жанров из каталога
+	AlbumCount int64      `db:"album_count"`
+	DeletedAt  *time.Time `db:"deleted_at"`
+}
+
+// GenreNode - жанр с поджанрами для построения дерева
+type GenreNode struct {
+	Genre
+	Children []GenreNode
+}
+
+// GenrePatch описывает частичное обновление жанра.
+// Nil-поля остаются без изменений, ParentID == 0 делает жанр корневым.
+type GenrePatch struct {
+	Name     *string
+	ParentID *int64
+}
+
+// Apply применяет изменения к жанру и возвращает результат.
+func (p GenrePatch) Apply(g Genre) Genre {
+	if p.Name != nil {
+		g.Name = *p.Name
+	}
+	if p.ParentID != nil {
+		g.ParentID = nil
+		if *p.ParentID != 0 {
+			parentID := *p.ParentID
+			g.ParentID = &parentID
+		}
+	}
+	return g
+}
+
+// AlbumGenreLinks описывает результат изменения набора жанров альбома
+type AlbumGenreLinks struct {
+	Added    []int64
+	Existing []int64
+	Missing  []int64
+	Removed  []int64
 }
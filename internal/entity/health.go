@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type HealthStatus string
+
+const (
+	HealthStatusOK   HealthStatus = "ok"
+	HealthStatusFail HealthStatus = "fail"
+)
+
+// HealthCheck - результат проверки одной зависимости
+type HealthCheck struct {
+	Name    string
+	Status  HealthStatus
+	Latency time.Duration
+	// Error - причина неудачи, пусто при успехе
+	Error string
+}
+
+// HealthReport - итог проверок: ok, только если успешны все
+type HealthReport struct {
+	Status HealthStatus
+	Checks []HealthCheck
+}
@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// BaseCurrency - валюта каталога: цены альбомов, SKU и фильтры по цене задаются в ней,
+// остальные валюты получаются явными ценами или пересчетом по курсу
+const BaseCurrency = "USD"
+
+var (
+	ErrInvalidMoney        = errors.New("invalid money amount")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// currencyExponents - число знаков после запятой у валют, отличающихся от стандартных двух
+var currencyExponents = map[string]int{
+	"JPY": 0, "KRW": 0, "VND": 0, "CLP": 0, "ISK": 0,
+	"BHD": 3, "KWD": 3, "OMR": 3, "JOD": 3, "TND": 3,
+}
+
+// CurrencyExponent возвращает число знаков минимальной единицы валюты по ISO 4217
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Money - сумма в минимальных единицах валюты, например 999 центов для 9.99 USD.
+// Целые числа исключают ошибки округления при сложении и умножении.
+type Money struct {
+	Amount   int64
+	Currency string // код ISO 4217
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney разбирает десятичную запись суммы: "9.99", "10", "9.9".
+// Запись состоит только из цифр с необязательной дробной частью: знак, пустая целая
+// или дробная часть не принимаются. Знаков после точки не может быть больше,
+// чем у минимальной единицы валюты.
+func ParseMoney(s, currency string) (Money, error) {
+	exp := CurrencyExponent(currency)
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if !isDigits(whole) || (hasFrac && !isDigits(frac)) || len(frac) > exp {
+		return Money{}, fmt.Errorf("%w: '%s' for %s", ErrInvalidMoney, s, currency)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: '%s' for %s", ErrInvalidMoney, s, currency)
+	}
+	return NewMoney(amount, currency), nil
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// ParsePrice разбирает цену: как ParseMoney, но сумма должна пройти ValidatePrice
+func ParsePrice(s, currency string) (Money, error) {
+	m, err := ParseMoney(s, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if err := ValidatePrice(m); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+// ValidatePrice - общее правило для цен: сумма не может быть отрицательной
+func ValidatePrice(m Money) error {
+	if m.Amount < 0 {
+		return fmt.Errorf("%w: price '%s' is negative", ErrInvalidMoney, m.Decimal())
+	}
+	return nil
+}
+
+// Decimal возвращает десятичную запись суммы с точностью валюты: "9.99", "1000"
+func (m Money) Decimal() string {
+	exp := CurrencyExponent(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Mul возвращает сумму, умноженную на количество
+func (m Money) Mul(quantity int) Money {
+	return NewMoney(m.Amount*int64(quantity), m.Currency)
+}
+
+// Add складывает суммы одной валюты
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("cannot add %s to %s", other.Currency, m.Currency)
+	}
+	return NewMoney(m.Amount+other.Amount, m.Currency), nil
+}
+
+// ExchangeRates - курсы валют к базовой: сколько единиц валюты стоит единица BaseCurrency
+type ExchangeRates map[string]*big.Rat
+
+// ParseExchangeRates разбирает курсы из десятичных строк конфигурации: {"EUR": "0.92"}
+func ParseExchangeRates(rates map[string]string) (ExchangeRates, error) {
+	parsed := make(ExchangeRates, len(rates))
+	for currency, rate := range rates {
+		r, ok := new(big.Rat).SetString(rate)
+		if !ok || r.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s: '%s'", currency, rate)
+		}
+		parsed[strings.ToUpper(currency)] = r
+	}
+	return parsed, nil
+}
+
+// Convert пересчитывает сумму в валюту to через базовую валюту.
+// Результат округляется до минимальной единицы, половина - от нуля.
+func (r ExchangeRates) Convert(m Money, to string) (Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+
+	from, err := r.rate(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	target, err := r.rate(to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// amount / 10^expFrom / from * target * 10^expTo
+	v := new(big.Rat).SetInt64(m.Amount)
+	v.Mul(v, target)
+	v.Quo(v, from)
+	v.Mul(v, pow10(CurrencyExponent(to)))
+	v.Quo(v, pow10(CurrencyExponent(m.Currency)))
+
+	return NewMoney(roundHalfAwayFromZero(v), to), nil
+}
+
+func (r ExchangeRates) rate(currency string) (*big.Rat, error) {
+	if currency == BaseCurrency {
+		return big.NewRat(1, 1), nil
+	}
+	rate, ok := r[currency]
+	if !ok {
+		return nil, fmt.Errorf("%w: no exchange rate for %s", ErrUnsupportedCurrency, currency)
+	}
+	return rate, nil
+}
+
+func pow10(exp int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+}
+
+func roundHalfAwayFromZero(v *big.Rat) int64 {
+	num := new(big.Int).Abs(v.Num())
+	// (2*num + den) / (2*den) - округление модуля половиной вверх
+	num.Mul(num, big.NewInt(2))
+	num.Add(num, v.Denom())
+	q := num.Quo(num, new(big.Int).Mul(v.Denom(), big.NewInt(2)))
+	if v.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q.Int64()
+}
@@ -0,0 +1,90 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrice(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		in       string
+		currency string
+		want     entity.Money
+		wantErr  error
+	}{
+		{name: "cents", in: "9.99", currency: "USD", want: entity.NewMoney(999, "USD")},
+		{name: "whole", in: "10", currency: "USD", want: entity.NewMoney(1000, "USD")},
+		{name: "short fraction", in: "9.9", currency: "EUR", want: entity.NewMoney(990, "EUR")},
+		{name: "no minor units", in: "1500", currency: "JPY", want: entity.NewMoney(1500, "JPY")},
+		{name: "three digits", in: "1.234", currency: "KWD", want: entity.NewMoney(1234, "KWD")},
+		{name: "too precise", in: "9.999", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "fraction for JPY", in: "15.5", currency: "JPY", wantErr: entity.ErrInvalidMoney},
+		{name: "negative", in: "-1", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "exponent", in: "1e3", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "empty whole", in: ".5", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "negative without whole", in: "-.5", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "negative zero", in: "-0", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "plus sign", in: "+1", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "sign in fraction", in: "1.-5", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "empty fraction", in: "1.", currency: "USD", wantErr: entity.ErrInvalidMoney},
+		{name: "empty", in: "", currency: "USD", wantErr: entity.ErrInvalidMoney},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := entity.ParsePrice(tc.in, tc.currency)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	for _, tc := range []struct {
+		in   entity.Money
+		want string
+	}{
+		{in: entity.NewMoney(999, "USD"), want: "9.99"},
+		{in: entity.NewMoney(5, "USD"), want: "0.05"},
+		{in: entity.NewMoney(-120, "EUR"), want: "-1.20"},
+		{in: entity.NewMoney(1500, "JPY"), want: "1500"},
+		{in: entity.NewMoney(1234, "KWD"), want: "1.234"},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.in.Decimal())
+		})
+	}
+}
+
+func TestExchangeRatesConvert(t *testing.T) {
+	rates, err := entity.ParseExchangeRates(map[string]string{"eur": "0.92", "JPY": "150"})
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		in      entity.Money
+		to      string
+		want    entity.Money
+		wantErr error
+	}{
+		{name: "same currency", in: entity.NewMoney(999, "USD"), to: "USD", want: entity.NewMoney(999, "USD")},
+		{name: "from base", in: entity.NewMoney(2000, "USD"), to: "EUR", want: entity.NewMoney(1840, "EUR")},
+		{name: "rounds half away from zero", in: entity.NewMoney(999, "USD"), to: "JPY", want: entity.NewMoney(1499, "JPY")},
+		{name: "between non-base currencies", in: entity.NewMoney(1500, "JPY"), to: "EUR", want: entity.NewMoney(920, "EUR")},
+		{name: "no rate", in: entity.NewMoney(999, "USD"), to: "CHF", wantErr: entity.ErrUnsupportedCurrency},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := rates.Convert(tc.in, tc.to)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseExchangeRatesRejectsInvalidRate(t *testing.T) {
+	_, err := entity.ParseExchangeRates(map[string]string{"EUR": "0"})
+	assert.Error(t, err)
+}
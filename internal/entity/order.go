@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusPaid      OrderStatus = "paid"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusRefunded  OrderStatus = "refunded"
+)
+
+// orderTransitions описывает допустимые переходы между статусами заказа
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:    {OrderStatusShipped, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusShipped: {OrderStatusRefunded},
+}
+
+// CanTransitionTo сообщает, можно ли перевести заказ из статуса s в next
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return slices.Contains(orderTransitions[s], next)
+}
+
+// ReturnsStock сообщает, возвращается ли товар заказа на склад при переходе в статус s
+func (s OrderStatus) ReturnsStock() bool {
+	return s == OrderStatusCancelled || s == OrderStatusRefunded
+}
+
+type Order struct {
+	ID int64
+	// UserID - покупатель, nil у заказов, оформленных до появления владельцев
+	UserID *int64
+	Status OrderStatus
+	// Currency - валюта, в которой оформлен заказ
+	Currency string
+	// PromoCode - код акции, указанный при оформлении
+	PromoCode *string
+	Items     []OrderItem
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrderItem - позиция заказа со снимком альбома на момент покупки
+type OrderItem struct {
+	AlbumID int64
+	// Format - проданный формат, пусто у заказов, оформленных до появления форматов в корзине
+	Format   string
+	Title    string
+	Artist   string
+	Price    Money
+	Quantity int
+	// Discount - скидка на всю позицию по акциям
+	Discount Money
+}
+
+// Discount возвращает сумму скидок заказа
+func (o Order) Discount() Money {
+	var total int64
+	for _, item := range o.Items {
+		total += item.Discount.Amount
+	}
+	return NewMoney(total, o.Currency)
+}
+
+// Total возвращает стоимость заказа со скидками
+func (o Order) Total() Money {
+	var total int64
+	for _, item := range o.Items {
+		total += item.Price.Mul(item.Quantity).Amount - item.Discount.Amount
+	}
+	return NewMoney(total, o.Currency)
+}
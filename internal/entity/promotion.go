@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type PromotionKind string
+
+const (
+	// PromotionPercent - скидка в процентах от стоимости позиций
+	PromotionPercent PromotionKind = "percent"
+	// PromotionFixed - фиксированная скидка на сумму подходящих позиций
+	PromotionFixed PromotionKind = "fixed"
+	// PromotionBuyXGetY - из каждых BuyQuantity+FreeQuantity единиц самые дешевые FreeQuantity бесплатны
+	PromotionBuyXGetY PromotionKind = "buy_x_get_y"
+)
+
+type PromotionTarget string
+
+const (
+	PromotionTargetAll    PromotionTarget = "all"
+	PromotionTargetGenre  PromotionTarget = "genre"
+	PromotionTargetArtist PromotionTarget = "artist"
+	PromotionTargetAlbum  PromotionTarget = "album"
+)
+
+// Promotion - правило скидки. Акция без кода применяется автоматически,
+// акция с кодом - только к корзине, для которой этот код указан.
+type Promotion struct {
+	ID   int64
+	Name string
+	Code *string
+	Kind PromotionKind
+	// Percent - размер скидки PromotionPercent, от 1 до 100
+	Percent int
+	// Amount - размер скидки PromotionFixed, действует только в своей валюте
+	Amount       Money
+	BuyQuantity  int
+	FreeQuantity int
+	// Target и TargetIDs задают альбомы, на которые распространяется акция.
+	// Жанр включает все свои поджанры.
+	Target    PromotionTarget
+	TargetIDs []int64
+	StartsAt  time.Time
+	// EndsAt - конец действия, не включительно, nil - бессрочно
+	EndsAt *time.Time
+	// UsageLimit - сколько заказов может воспользоваться акцией, nil - без ограничений
+	UsageLimit *int
+	UsageCount int
+	CreatedAt  time.Time
+}
+
+// ActiveAt сообщает, действует ли акция в момент at
+func (p Promotion) ActiveAt(at time.Time) bool {
+	return !at.Before(p.StartsAt) && (p.EndsAt == nil || at.Before(*p.EndsAt))
+}
+
+// Exhausted сообщает, исчерпан ли лимит использований
+func (p Promotion) Exhausted() bool {
+	return p.UsageLimit != nil && p.UsageCount >= *p.UsageLimit
+}
+
+// Covers сообщает, распространяется ли акция на позицию
+func (p Promotion) Covers(line PricingLine) bool {
+	switch p.Target {
+	case PromotionTargetAll:
+		return true
+	case PromotionTargetAlbum:
+		return slices.Contains(p.TargetIDs, line.AlbumID)
+	case PromotionTargetArtist:
+		return slices.Contains(p.TargetIDs, line.ArtistID)
+	case PromotionTargetGenre:
+		return slices.ContainsFunc(line.GenreIDs, func(id int64) bool { return slices.Contains(p.TargetIDs, id) })
+	}
+	return false
+}
+
+// Describe возвращает короткое описание правила для покупателя: "20% off", "buy 2 get 1 free"
+func (p Promotion) Describe() string {
+	switch p.Kind {
+	case PromotionPercent:
+		return fmt.Sprintf("%d%% off", p.Percent)
+	case PromotionFixed:
+		return p.Amount.String() + " off"
+	case PromotionBuyXGetY:
+		return fmt.Sprintf("buy %d get %d free", p.BuyQuantity, p.FreeQuantity)
+	}
+	return string(p.Kind)
+}
+
+// PricingLine - позиция корзины с данными, по которым подбираются акции
+type PricingLine struct {
+	AlbumID  int64
+	ArtistID int64
+	// GenreIDs - жанры альбома вместе со всеми их предками
+	GenreIDs []int64
+	Price    Money
+	Quantity int
+}
+
+// Subtotal возвращает стоимость позиции без скидок
+func (l PricingLine) Subtotal() Money {
+	return l.Price.Mul(l.Quantity)
+}
+
+// PricedLine - позиция с рассчитанной скидкой
+type PricedLine struct {
+	PricingLine
+	Discount Money
+	// PromotionIDs - акции, давшие скидку на позицию, в порядке применения
+	PromotionIDs []int64
+}
+
+// Total возвращает стоимость позиции со скидкой
+func (l PricedLine) Total() Money {
+	return NewMoney(l.Subtotal().Amount-l.Discount.Amount, l.Price.Currency)
+}
+
+// AppliedPromotion объясняет, какое правило сработало и на что
+type AppliedPromotion struct {
+	PromotionID int64
+	Name        string
+	Code        *string
+	Description string
+	Discount    Money
+	AlbumIDs    []int64
+}
+
+// Pricing - расчет корзины со скидками
+type Pricing struct {
+	Currency string
+	Lines    []PricedLine
+	Applied  []AppliedPromotion
+}
+
+// Subtotal возвращает стоимость без скидок
+func (p Pricing) Subtotal() Money {
+	var total int64
+	for _, line := range p.Lines {
+		total += line.Subtotal().Amount
+	}
+	return NewMoney(total, p.Currency)
+}
+
+// Discount возвращает сумму всех скидок
+func (p Pricing) Discount() Money {
+	var total int64
+	for _, line := range p.Lines {
+		total += line.Discount.Amount
+	}
+	return NewMoney(total, p.Currency)
+}
+
+// Total возвращает стоимость со скидками
+func (p Pricing) Total() Money {
+	return NewMoney(p.Subtotal().Amount-p.Discount().Amount, p.Currency)
+}
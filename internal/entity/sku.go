@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+const (
+	FormatCD       = "cd"
+	FormatVinyl    = "vinyl"
+	FormatCassette = "cassette"
+	FormatDigital  = "digital"
+)
+
+// SKU - товарная позиция альбома в конкретном формате со своей ценой и остатком
+type SKU struct {
+	ID       int64
+	AlbumID  int64
+	Format   string
+	Price    Money // в базовой валюте
+	Quantity int
+}
+
+// Available сообщает, можно ли продать позицию. Цифровой формат не ограничен остатком.
+func (s SKU) Available() bool {
+	return s.Format == FormatDigital || s.Quantity > 0
+}
+
+// StockMovement - запись журнала движения остатков
+type StockMovement struct {
+	ID        int64
+	SKUID     int64
+	Delta     int
+	Reason    string
+	CreatedAt time.Time
+}
+
+// Availability - наличие альбома по всем форматам
+type Availability struct {
+	AlbumID int64
+	Price   Money // цена альбома, минимальная среди SKU
+	SKUs    []SKU
+}
+
+// Available сообщает, доступен ли альбом хотя бы в одном формате
+func (a Availability) Available() bool {
+	for _, s := range a.SKUs {
+		if s.Available() {
+			return true
+		}
+	}
+	return false
+}
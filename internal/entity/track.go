@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type Track struct {
+	ID         int64
+	AlbumID    int64
+	DiscNumber int
+	Position   int
+	Title      string
+	Duration   time.Duration
+	ISRC       string // пустая строка, если код не указан
+}
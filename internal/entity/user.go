@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+type Role string
+
+const (
+	RoleCustomer      Role = "customer"
+	RoleCatalogEditor Role = "catalog_editor"
+	RoleAdmin         Role = "admin"
+)
+
+type User struct {
+	ID           int64
+	Email        string
+	PasswordHash string
+	Role         Role
+	CreatedAt    time.Time
+}
+
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// Active сообщает, можно ли еще обменять токен на новую пару
+func (t RefreshToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// TokenPair выдается клиенту при входе и обновлении токенов
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Форматы вывода логов
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type ctxKey struct{}
+
+// Setup настраивает глобальный логгер: уровень и формат вывода.
+// Неизвестный уровень заменяется debug, неизвестный формат - ошибка.
+func Setup(level, format string) error {
+	logrusLevel, err := log.ParseLevel(level)
+	if err != nil {
+		log.SetLevel(log.DebugLevel)
+	} else {
+		log.SetLevel(logrusLevel)
+	}
+
+	switch format {
+	case FormatJSON, "":
+		log.SetFormatter(&log.JSONFormatter{
+			TimestampFormat: time.DateTime,
+		})
+	case FormatText:
+		log.SetFormatter(&log.TextFormatter{
+			FullTimestamp:   true,
+			TimestampFormat: time.DateTime,
+		})
+	default:
+		return fmt.Errorf("logger - unknown format '%s'", format)
+	}
+	return nil
+}
+
+// FromContext возвращает логгер запроса с его полями (request_id, route, user_id),
+// вне запроса - глобальный логгер
+func FromContext(ctx context.Context) *log.Entry {
+	if entry, ok := ctx.Value(ctxKey{}).(*log.Entry); ok {
+		return entry
+	}
+	return log.NewEntry(log.StandardLogger())
+}
+
+// WithFields добавляет поля к логгеру контекста
+func WithFields(ctx context.Context, fields log.Fields) context.Context {
+	return context.WithValue(ctx, ctxKey{}, FromContext(ctx).WithFields(fields))
+}
@@ -0,0 +1,34 @@
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/logger"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFields(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Empty(t, logger.FromContext(ctx).Data)
+
+	ctx = logger.WithFields(ctx, log.Fields{"request_id": "req-1"})
+	ctx = logger.WithFields(ctx, log.Fields{"user_id": int64(7)})
+
+	assert.Equal(t, log.Fields{"request_id": "req-1", "user_id": int64(7)}, logger.FromContext(ctx).Data)
+}
+
+func TestSetup(t *testing.T) {
+	defer func() { _ = logger.Setup("debug", logger.FormatJSON) }()
+
+	assert.NoError(t, logger.Setup("info", logger.FormatText))
+	assert.IsType(t, &log.TextFormatter{}, log.StandardLogger().Formatter)
+	assert.Equal(t, log.InfoLevel, log.GetLevel())
+
+	assert.NoError(t, logger.Setup("info", logger.FormatJSON))
+	assert.IsType(t, &log.JSONFormatter{}, log.StandardLogger().Formatter)
+
+	assert.Error(t, logger.Setup("info", "xml"))
+}
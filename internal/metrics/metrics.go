@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "musicshop"
+
+// Metrics - метрики приложения в собственном реестре, отдаются через Handler
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	transactions *prometheus.CounterVec
+
+	albumsCreated  prometheus.Counter
+	genresAttached prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "transactions_total",
+			Help:      "Database transactions by outcome: commit or rollback.",
+		}, []string{"outcome"}),
+		albumsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "albums_created_total",
+			Help:      "Albums created.",
+		}),
+		genresAttached: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "album_genres_attached_total",
+			Help:      "Genres newly attached to albums.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.transactions,
+		m.albumsCreated,
+		m.genresAttached,
+	)
+
+	return m
+}
+
+// Handler отдает метрики в формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRequest учитывает обработанный HTTP запрос. route - шаблон маршрута, а не путь запроса,
+// иначе каждый id порождал бы отдельный ряд.
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// TransactionFinished учитывает завершение транзакции с исходом outcome
+func (m *Metrics) TransactionFinished(outcome string) {
+	m.transactions.WithLabelValues(outcome).Inc()
+}
+
+func (m *Metrics) AlbumCreated() {
+	m.albumsCreated.Inc()
+}
+
+func (m *Metrics) GenresAttached(n int) {
+	m.genresAttached.Add(float64(n))
+}
+
+// RegisterPool добавляет статистику пула соединений. Значения читаются при каждом сборе метрик.
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPoolCollector(pool))
+}
@@ -12,6 +12,7 @@ package mock_albums
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/4udiwe/musicshop/internal/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,36 @@ func (m *MockAlbumRepository) EXPECT() *MockAlbumRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockAlbumRepository) Count(ctx context.Context, filter entity.AlbumFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockAlbumRepositoryMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockAlbumRepository)(nil).Count), ctx, filter)
+}
+
+// CountSearch mocks base method.
+func (m *MockAlbumRepository) CountSearch(ctx context.Context, search entity.AlbumSearch) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSearch", ctx, search)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSearch indicates an expected call of CountSearch.
+func (mr *MockAlbumRepositoryMockRecorder) CountSearch(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSearch", reflect.TypeOf((*MockAlbumRepository)(nil).CountSearch), ctx, search)
+}
+
 // Create mocks base method.
 func (m *MockAlbumRepository) Create(ctx context.Context, album entity.Album) (int64, error) {
 	m.ctrl.T.Helper()
@@ -71,18 +102,18 @@ func (mr *MockAlbumRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 }
 
 // FindAll mocks base method.
-func (m *MockAlbumRepository) FindAll(ctx context.Context) ([]entity.Album, error) {
+func (m *MockAlbumRepository) FindAll(ctx context.Context, filter entity.AlbumFilter) ([]entity.Album, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
 	ret0, _ := ret[0].([]entity.Album)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockAlbumRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+func (mr *MockAlbumRepositoryMockRecorder) FindAll(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAlbumRepository)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAlbumRepository)(nil).FindAll), ctx, filter)
 }
 
 // FindById mocks base method.
@@ -100,6 +131,220 @@ func (mr *MockAlbumRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockAlbumRepository)(nil).FindById), ctx, id)
 }
 
+// FindSKUPrice mocks base method.
+func (m *MockAlbumRepository) FindSKUPrice(ctx context.Context, albumID int64) (entity.Money, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSKUPrice", ctx, albumID)
+	ret0, _ := ret[0].(entity.Money)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindSKUPrice indicates an expected call of FindSKUPrice.
+func (mr *MockAlbumRepositoryMockRecorder) FindSKUPrice(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSKUPrice", reflect.TypeOf((*MockAlbumRepository)(nil).FindSKUPrice), ctx, albumID)
+}
+
+// Lock mocks base method.
+func (m *MockAlbumRepository) Lock(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockAlbumRepositoryMockRecorder) Lock(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockAlbumRepository)(nil).Lock), ctx, id)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockAlbumRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockAlbumRepositoryMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockAlbumRepository)(nil).PurgeDeleted), ctx, before)
+}
+
+// Restore mocks base method.
+func (m *MockAlbumRepository) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAlbumRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAlbumRepository)(nil).Restore), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockAlbumRepository) Search(ctx context.Context, search entity.AlbumSearch) ([]entity.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search)
+	ret0, _ := ret[0].([]entity.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAlbumRepositoryMockRecorder) Search(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAlbumRepository)(nil).Search), ctx, search)
+}
+
+// Update mocks base method.
+func (m *MockAlbumRepository) Update(ctx context.Context, album entity.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, album)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAlbumRepositoryMockRecorder) Update(ctx, album any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAlbumRepository)(nil).Update), ctx, album)
+}
+
+// UpdatePrice mocks base method.
+func (m *MockAlbumRepository) UpdatePrice(ctx context.Context, id int64, price entity.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrice", ctx, id, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePrice indicates an expected call of UpdatePrice.
+func (mr *MockAlbumRepositoryMockRecorder) UpdatePrice(ctx, id, price any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrice", reflect.TypeOf((*MockAlbumRepository)(nil).UpdatePrice), ctx, id, price)
+}
+
+// MockArtistRepository is a mock of ArtistRepository interface.
+type MockArtistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArtistRepositoryMockRecorder is the mock recorder for MockArtistRepository.
+type MockArtistRepositoryMockRecorder struct {
+	mock *MockArtistRepository
+}
+
+// NewMockArtistRepository creates a new mock instance.
+func NewMockArtistRepository(ctrl *gomock.Controller) *MockArtistRepository {
+	mock := &MockArtistRepository{ctrl: ctrl}
+	mock.recorder = &MockArtistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistRepository) EXPECT() *MockArtistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArtistRepository) Create(ctx context.Context, artist entity.Artist) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, artist)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArtistRepositoryMockRecorder) Create(ctx, artist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArtistRepository)(nil).Create), ctx, artist)
+}
+
+// Delete mocks base method.
+func (m *MockArtistRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArtistRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArtistRepository)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockArtistRepository) FindAll(ctx context.Context) ([]entity.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]entity.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockArtistRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockArtistRepository)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockArtistRepository) FindById(ctx context.Context, id int64) (entity.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockArtistRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockArtistRepository)(nil).FindById), ctx, id)
+}
+
+// FindOrCreate mocks base method.
+func (m *MockArtistRepository) FindOrCreate(ctx context.Context, name string) (entity.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreate", ctx, name)
+	ret0, _ := ret[0].(entity.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreate indicates an expected call of FindOrCreate.
+func (mr *MockArtistRepositoryMockRecorder) FindOrCreate(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreate", reflect.TypeOf((*MockArtistRepository)(nil).FindOrCreate), ctx, name)
+}
+
+// Update mocks base method.
+func (m *MockArtistRepository) Update(ctx context.Context, artist entity.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, artist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArtistRepositoryMockRecorder) Update(ctx, artist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArtistRepository)(nil).Update), ctx, artist)
+}
+
 // MockGenreRepository is a mock of GenreRepository interface.
 type MockGenreRepository struct {
 	ctrl     *gomock.Controller
@@ -172,17 +417,383 @@ func (mr *MockGenreRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGenreRepository)(nil).Delete), ctx, id)
 }
 
+// DeleteSubtree mocks base method.
+func (m *MockGenreRepository) DeleteSubtree(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubtree", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubtree indicates an expected call of DeleteSubtree.
+func (mr *MockGenreRepositoryMockRecorder) DeleteSubtree(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubtree", reflect.TypeOf((*MockGenreRepository)(nil).DeleteSubtree), ctx, id)
+}
+
 // FindAll mocks base method.
-func (m *MockGenreRepository) FindAll(ctx context.Context) ([]entity.Genre, error) {
+func (m *MockGenreRepository) FindAll(ctx context.Context, includeDeleted bool) ([]entity.Genre, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, includeDeleted)
 	ret0, _ := ret[0].([]entity.Genre)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockGenreRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+func (mr *MockGenreRepositoryMockRecorder) FindAll(ctx, includeDeleted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockGenreRepository)(nil).FindAll), ctx, includeDeleted)
+}
+
+// FindById mocks base method.
+func (m *MockGenreRepository) FindById(ctx context.Context, id int64) (entity.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockGenreRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockGenreRepository)(nil).FindById), ctx, id)
+}
+
+// FindSubtreeIDs mocks base method.
+func (m *MockGenreRepository) FindSubtreeIDs(ctx context.Context, id int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSubtreeIDs", ctx, id)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSubtreeIDs indicates an expected call of FindSubtreeIDs.
+func (mr *MockGenreRepositoryMockRecorder) FindSubtreeIDs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSubtreeIDs", reflect.TypeOf((*MockGenreRepository)(nil).FindSubtreeIDs), ctx, id)
+}
+
+// LinkGenresToAlbum mocks base method.
+func (m *MockGenreRepository) LinkGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, albumID}
+	for _, a := range genreIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LinkGenresToAlbum", varargs...)
+	ret0, _ := ret[0].(entity.AlbumGenreLinks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkGenresToAlbum indicates an expected call of LinkGenresToAlbum.
+func (mr *MockGenreRepositoryMockRecorder) LinkGenresToAlbum(ctx, albumID any, genreIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, albumID}, genreIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkGenresToAlbum", reflect.TypeOf((*MockGenreRepository)(nil).LinkGenresToAlbum), varargs...)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockGenreRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockGenreRepositoryMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockGenreRepository)(nil).PurgeDeleted), ctx, before)
+}
+
+// RemoveGenresFromAlbum mocks base method.
+func (m *MockGenreRepository) RemoveGenresFromAlbum(ctx context.Context, albumID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGenresFromAlbum", ctx, albumID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveGenresFromAlbum indicates an expected call of RemoveGenresFromAlbum.
+func (mr *MockGenreRepositoryMockRecorder) RemoveGenresFromAlbum(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGenresFromAlbum", reflect.TypeOf((*MockGenreRepository)(nil).RemoveGenresFromAlbum), ctx, albumID)
+}
+
+// ReparentChildren mocks base method.
+func (m *MockGenreRepository) ReparentChildren(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReparentChildren", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReparentChildren indicates an expected call of ReparentChildren.
+func (mr *MockGenreRepositoryMockRecorder) ReparentChildren(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReparentChildren", reflect.TypeOf((*MockGenreRepository)(nil).ReparentChildren), ctx, id)
+}
+
+// Restore mocks base method.
+func (m *MockGenreRepository) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockGenreRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockGenreRepository)(nil).Restore), ctx, id)
+}
+
+// RetainAlbumGenres mocks base method.
+func (m *MockGenreRepository) RetainAlbumGenres(ctx context.Context, albumID int64, genreIDs ...int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, albumID}
+	for _, a := range genreIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RetainAlbumGenres", varargs...)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetainAlbumGenres indicates an expected call of RetainAlbumGenres.
+func (mr *MockGenreRepositoryMockRecorder) RetainAlbumGenres(ctx, albumID any, genreIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, albumID}, genreIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetainAlbumGenres", reflect.TypeOf((*MockGenreRepository)(nil).RetainAlbumGenres), varargs...)
+}
+
+// UnlinkGenreFromAlbum mocks base method.
+func (m *MockGenreRepository) UnlinkGenreFromAlbum(ctx context.Context, albumID, genreID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkGenreFromAlbum", ctx, albumID, genreID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkGenreFromAlbum indicates an expected call of UnlinkGenreFromAlbum.
+func (mr *MockGenreRepositoryMockRecorder) UnlinkGenreFromAlbum(ctx, albumID, genreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkGenreFromAlbum", reflect.TypeOf((*MockGenreRepository)(nil).UnlinkGenreFromAlbum), ctx, albumID, genreID)
+}
+
+// Update mocks base method.
+func (m *MockGenreRepository) Update(ctx context.Context, genre entity.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGenreRepositoryMockRecorder) Update(ctx, genre any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGenreRepository)(nil).Update), ctx, genre)
+}
+
+// MockTrackRepository is a mock of TrackRepository interface.
+type MockTrackRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrackRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTrackRepositoryMockRecorder is the mock recorder for MockTrackRepository.
+type MockTrackRepositoryMockRecorder struct {
+	mock *MockTrackRepository
+}
+
+// NewMockTrackRepository creates a new mock instance.
+func NewMockTrackRepository(ctrl *gomock.Controller) *MockTrackRepository {
+	mock := &MockTrackRepository{ctrl: ctrl}
+	mock.recorder = &MockTrackRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrackRepository) EXPECT() *MockTrackRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTrackRepository) Create(ctx context.Context, albumID int64, tracks ...entity.Track) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, albumID}
+	for _, a := range tracks {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTrackRepositoryMockRecorder) Create(ctx, albumID any, tracks ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, albumID}, tracks...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTrackRepository)(nil).Create), varargs...)
+}
+
+// DeleteByAlbumID mocks base method.
+func (m *MockTrackRepository) DeleteByAlbumID(ctx context.Context, albumID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByAlbumID", ctx, albumID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByAlbumID indicates an expected call of DeleteByAlbumID.
+func (mr *MockTrackRepositoryMockRecorder) DeleteByAlbumID(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByAlbumID", reflect.TypeOf((*MockTrackRepository)(nil).DeleteByAlbumID), ctx, albumID)
+}
+
+// FindByAlbumID mocks base method.
+func (m *MockTrackRepository) FindByAlbumID(ctx context.Context, albumID int64) ([]entity.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAlbumID", ctx, albumID)
+	ret0, _ := ret[0].([]entity.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAlbumID indicates an expected call of FindByAlbumID.
+func (mr *MockTrackRepositoryMockRecorder) FindByAlbumID(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAlbumID", reflect.TypeOf((*MockTrackRepository)(nil).FindByAlbumID), ctx, albumID)
+}
+
+// MockPriceQuoter is a mock of PriceQuoter interface.
+type MockPriceQuoter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceQuoterMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceQuoterMockRecorder is the mock recorder for MockPriceQuoter.
+type MockPriceQuoterMockRecorder struct {
+	mock *MockPriceQuoter
+}
+
+// NewMockPriceQuoter creates a new mock instance.
+func NewMockPriceQuoter(ctrl *gomock.Controller) *MockPriceQuoter {
+	mock := &MockPriceQuoter{ctrl: ctrl}
+	mock.recorder = &MockPriceQuoterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceQuoter) EXPECT() *MockPriceQuoterMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockPriceQuoter) Quote(ctx context.Context, currency string, prices map[int64]entity.Money) (map[int64]entity.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, currency, prices)
+	ret0, _ := ret[0].(map[int64]entity.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockPriceQuoterMockRecorder) Quote(ctx, currency, prices any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockPriceQuoter)(nil).Quote), ctx, currency, prices)
+}
+
+// MockAuditWriter is a mock of AuditWriter interface.
+type MockAuditWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditWriterMockRecorder is the mock recorder for MockAuditWriter.
+type MockAuditWriterMockRecorder struct {
+	mock *MockAuditWriter
+}
+
+// NewMockAuditWriter creates a new mock instance.
+func NewMockAuditWriter(ctrl *gomock.Controller) *MockAuditWriter {
+	mock := &MockAuditWriter{ctrl: ctrl}
+	mock.recorder = &MockAuditWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditWriter) EXPECT() *MockAuditWriterMockRecorder {
+	return m.recorder
+}
+
+// Write mocks base method.
+func (m *MockAuditWriter) Write(ctx context.Context, event entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockAuditWriterMockRecorder) Write(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockAuditWriter)(nil).Write), ctx, event)
+}
+
+// MockEventCounter is a mock of EventCounter interface.
+type MockEventCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventCounterMockRecorder
+	isgomock struct{}
+}
+
+// MockEventCounterMockRecorder is the mock recorder for MockEventCounter.
+type MockEventCounterMockRecorder struct {
+	mock *MockEventCounter
+}
+
+// NewMockEventCounter creates a new mock instance.
+func NewMockEventCounter(ctrl *gomock.Controller) *MockEventCounter {
+	mock := &MockEventCounter{ctrl: ctrl}
+	mock.recorder = &MockEventCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventCounter) EXPECT() *MockEventCounterMockRecorder {
+	return m.recorder
+}
+
+// AlbumCreated mocks base method.
+func (m *MockEventCounter) AlbumCreated() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AlbumCreated")
+}
+
+// AlbumCreated indicates an expected call of AlbumCreated.
+func (mr *MockEventCounterMockRecorder) AlbumCreated() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AlbumCreated", reflect.TypeOf((*MockEventCounter)(nil).AlbumCreated))
+}
+
+// GenresAttached mocks base method.
+func (m *MockEventCounter) GenresAttached(n int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GenresAttached", n)
+}
+
+// GenresAttached indicates an expected call of GenresAttached.
+func (mr *MockEventCounterMockRecorder) GenresAttached(n any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockGenreRepository)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenresAttached", reflect.TypeOf((*MockEventCounter)(nil).GenresAttached), n)
 }
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/artists/contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/artists/contracts.go -destination=internal/mocks/mock_artists/mock_repository.go
+//
+
+// Package mock_artists is a generated GoMock package.
+package mock_artists
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/4udiwe/musicshop/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArtistRepository is a mock of ArtistRepository interface.
+type MockArtistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArtistRepositoryMockRecorder is the mock recorder for MockArtistRepository.
+type MockArtistRepositoryMockRecorder struct {
+	mock *MockArtistRepository
+}
+
+// NewMockArtistRepository creates a new mock instance.
+func NewMockArtistRepository(ctrl *gomock.Controller) *MockArtistRepository {
+	mock := &MockArtistRepository{ctrl: ctrl}
+	mock.recorder = &MockArtistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistRepository) EXPECT() *MockArtistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArtistRepository) Create(ctx context.Context, artist entity.Artist) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, artist)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArtistRepositoryMockRecorder) Create(ctx, artist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArtistRepository)(nil).Create), ctx, artist)
+}
+
+// Delete mocks base method.
+func (m *MockArtistRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArtistRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArtistRepository)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockArtistRepository) FindAll(ctx context.Context) ([]entity.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]entity.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockArtistRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockArtistRepository)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockArtistRepository) FindById(ctx context.Context, id int64) (entity.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockArtistRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockArtistRepository)(nil).FindById), ctx, id)
+}
+
+// FindOrCreate mocks base method.
+func (m *MockArtistRepository) FindOrCreate(ctx context.Context, name string) (entity.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreate", ctx, name)
+	ret0, _ := ret[0].(entity.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreate indicates an expected call of FindOrCreate.
+func (mr *MockArtistRepositoryMockRecorder) FindOrCreate(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreate", reflect.TypeOf((*MockArtistRepository)(nil).FindOrCreate), ctx, name)
+}
+
+// Update mocks base method.
+func (m *MockArtistRepository) Update(ctx context.Context, artist entity.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, artist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArtistRepositoryMockRecorder) Update(ctx, artist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArtistRepository)(nil).Update), ctx, artist)
+}
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/carts/contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/carts/contracts.go -destination=internal/mocks/mock_carts/mock_repository.go
+//
+
+// Package mock_carts is a generated GoMock package.
+package mock_carts
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/4udiwe/musicshop/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCartRepository is a mock of CartRepository interface.
+type MockCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCartRepositoryMockRecorder is the mock recorder for MockCartRepository.
+type MockCartRepositoryMockRecorder struct {
+	mock *MockCartRepository
+}
+
+// NewMockCartRepository creates a new mock instance.
+func NewMockCartRepository(ctrl *gomock.Controller) *MockCartRepository {
+	mock := &MockCartRepository{ctrl: ctrl}
+	mock.recorder = &MockCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCartRepository) Create(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCartRepositoryMockRecorder) Create(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCartRepository)(nil).Create), ctx, userID)
+}
+
+// Delete mocks base method.
+func (m *MockCartRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCartRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCartRepository)(nil).Delete), ctx, id)
+}
+
+// FindById mocks base method.
+func (m *MockCartRepository) FindById(ctx context.Context, id int64) (entity.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCartRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCartRepository)(nil).FindById), ctx, id)
+}
+
+// FindOwner mocks base method.
+func (m *MockCartRepository) FindOwner(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOwner", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOwner indicates an expected call of FindOwner.
+func (mr *MockCartRepositoryMockRecorder) FindOwner(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOwner", reflect.TypeOf((*MockCartRepository)(nil).FindOwner), ctx, id)
+}
+
+// RemoveItem mocks base method.
+func (m *MockCartRepository) RemoveItem(ctx context.Context, cartID, albumID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", ctx, cartID, albumID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockCartRepositoryMockRecorder) RemoveItem(ctx, cartID, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockCartRepository)(nil).RemoveItem), ctx, cartID, albumID)
+}
+
+// SetItem mocks base method.
+func (m *MockCartRepository) SetItem(ctx context.Context, cartID, albumID int64, format string, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", ctx, cartID, albumID, format, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockCartRepositoryMockRecorder) SetItem(ctx, cartID, albumID, format, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockCartRepository)(nil).SetItem), ctx, cartID, albumID, format, quantity)
+}
+
+// MockPriceQuoter is a mock of PriceQuoter interface.
+type MockPriceQuoter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceQuoterMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceQuoterMockRecorder is the mock recorder for MockPriceQuoter.
+type MockPriceQuoterMockRecorder struct {
+	mock *MockPriceQuoter
+}
+
+// NewMockPriceQuoter creates a new mock instance.
+func NewMockPriceQuoter(ctrl *gomock.Controller) *MockPriceQuoter {
+	mock := &MockPriceQuoter{ctrl: ctrl}
+	mock.recorder = &MockPriceQuoterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceQuoter) EXPECT() *MockPriceQuoterMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockPriceQuoter) Quote(ctx context.Context, currency string, prices map[int64]entity.Money) (map[int64]entity.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, currency, prices)
+	ret0, _ := ret[0].(map[int64]entity.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockPriceQuoterMockRecorder) Quote(ctx, currency, prices any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockPriceQuoter)(nil).Quote), ctx, currency, prices)
+}
@@ -12,6 +12,7 @@ package mock_genres
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/4udiwe/musicshop/internal/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -89,17 +90,260 @@ func (mr *MockGenreRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGenreRepository)(nil).Delete), ctx, id)
 }
 
+// DeleteSubtree mocks base method.
+func (m *MockGenreRepository) DeleteSubtree(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubtree", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubtree indicates an expected call of DeleteSubtree.
+func (mr *MockGenreRepositoryMockRecorder) DeleteSubtree(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubtree", reflect.TypeOf((*MockGenreRepository)(nil).DeleteSubtree), ctx, id)
+}
+
 // FindAll mocks base method.
-func (m *MockGenreRepository) FindAll(ctx context.Context) ([]entity.Genre, error) {
+func (m *MockGenreRepository) FindAll(ctx context.Context, includeDeleted bool) ([]entity.Genre, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, includeDeleted)
 	ret0, _ := ret[0].([]entity.Genre)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockGenreRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+func (mr *MockGenreRepositoryMockRecorder) FindAll(ctx, includeDeleted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockGenreRepository)(nil).FindAll), ctx, includeDeleted)
+}
+
+// FindById mocks base method.
+func (m *MockGenreRepository) FindById(ctx context.Context, id int64) (entity.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockGenreRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockGenreRepository)(nil).FindById), ctx, id)
+}
+
+// FindSubtreeIDs mocks base method.
+func (m *MockGenreRepository) FindSubtreeIDs(ctx context.Context, id int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSubtreeIDs", ctx, id)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSubtreeIDs indicates an expected call of FindSubtreeIDs.
+func (mr *MockGenreRepositoryMockRecorder) FindSubtreeIDs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSubtreeIDs", reflect.TypeOf((*MockGenreRepository)(nil).FindSubtreeIDs), ctx, id)
+}
+
+// LinkGenresToAlbum mocks base method.
+func (m *MockGenreRepository) LinkGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, albumID}
+	for _, a := range genreIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LinkGenresToAlbum", varargs...)
+	ret0, _ := ret[0].(entity.AlbumGenreLinks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkGenresToAlbum indicates an expected call of LinkGenresToAlbum.
+func (mr *MockGenreRepositoryMockRecorder) LinkGenresToAlbum(ctx, albumID any, genreIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, albumID}, genreIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkGenresToAlbum", reflect.TypeOf((*MockGenreRepository)(nil).LinkGenresToAlbum), varargs...)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockGenreRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockGenreRepositoryMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockGenreRepository)(nil).PurgeDeleted), ctx, before)
+}
+
+// RemoveGenresFromAlbum mocks base method.
+func (m *MockGenreRepository) RemoveGenresFromAlbum(ctx context.Context, albumID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGenresFromAlbum", ctx, albumID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveGenresFromAlbum indicates an expected call of RemoveGenresFromAlbum.
+func (mr *MockGenreRepositoryMockRecorder) RemoveGenresFromAlbum(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGenresFromAlbum", reflect.TypeOf((*MockGenreRepository)(nil).RemoveGenresFromAlbum), ctx, albumID)
+}
+
+// ReparentChildren mocks base method.
+func (m *MockGenreRepository) ReparentChildren(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReparentChildren", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReparentChildren indicates an expected call of ReparentChildren.
+func (mr *MockGenreRepositoryMockRecorder) ReparentChildren(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReparentChildren", reflect.TypeOf((*MockGenreRepository)(nil).ReparentChildren), ctx, id)
+}
+
+// Restore mocks base method.
+func (m *MockGenreRepository) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockGenreRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockGenreRepository)(nil).Restore), ctx, id)
+}
+
+// RetainAlbumGenres mocks base method.
+func (m *MockGenreRepository) RetainAlbumGenres(ctx context.Context, albumID int64, genreIDs ...int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, albumID}
+	for _, a := range genreIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RetainAlbumGenres", varargs...)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetainAlbumGenres indicates an expected call of RetainAlbumGenres.
+func (mr *MockGenreRepositoryMockRecorder) RetainAlbumGenres(ctx, albumID any, genreIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, albumID}, genreIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetainAlbumGenres", reflect.TypeOf((*MockGenreRepository)(nil).RetainAlbumGenres), varargs...)
+}
+
+// UnlinkGenreFromAlbum mocks base method.
+func (m *MockGenreRepository) UnlinkGenreFromAlbum(ctx context.Context, albumID, genreID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkGenreFromAlbum", ctx, albumID, genreID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkGenreFromAlbum indicates an expected call of UnlinkGenreFromAlbum.
+func (mr *MockGenreRepositoryMockRecorder) UnlinkGenreFromAlbum(ctx, albumID, genreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkGenreFromAlbum", reflect.TypeOf((*MockGenreRepository)(nil).UnlinkGenreFromAlbum), ctx, albumID, genreID)
+}
+
+// Update mocks base method.
+func (m *MockGenreRepository) Update(ctx context.Context, genre entity.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGenreRepositoryMockRecorder) Update(ctx, genre any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGenreRepository)(nil).Update), ctx, genre)
+}
+
+// MockAuditWriter is a mock of AuditWriter interface.
+type MockAuditWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditWriterMockRecorder is the mock recorder for MockAuditWriter.
+type MockAuditWriterMockRecorder struct {
+	mock *MockAuditWriter
+}
+
+// NewMockAuditWriter creates a new mock instance.
+func NewMockAuditWriter(ctrl *gomock.Controller) *MockAuditWriter {
+	mock := &MockAuditWriter{ctrl: ctrl}
+	mock.recorder = &MockAuditWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditWriter) EXPECT() *MockAuditWriterMockRecorder {
+	return m.recorder
+}
+
+// Write mocks base method.
+func (m *MockAuditWriter) Write(ctx context.Context, event entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockAuditWriterMockRecorder) Write(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockAuditWriter)(nil).Write), ctx, event)
+}
+
+// MockEventCounter is a mock of EventCounter interface.
+type MockEventCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventCounterMockRecorder
+	isgomock struct{}
+}
+
+// MockEventCounterMockRecorder is the mock recorder for MockEventCounter.
+type MockEventCounterMockRecorder struct {
+	mock *MockEventCounter
+}
+
+// NewMockEventCounter creates a new mock instance.
+func NewMockEventCounter(ctrl *gomock.Controller) *MockEventCounter {
+	mock := &MockEventCounter{ctrl: ctrl}
+	mock.recorder = &MockEventCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventCounter) EXPECT() *MockEventCounterMockRecorder {
+	return m.recorder
+}
+
+// GenresAttached mocks base method.
+func (m *MockEventCounter) GenresAttached(n int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GenresAttached", n)
+}
+
+// GenresAttached indicates an expected call of GenresAttached.
+func (mr *MockEventCounterMockRecorder) GenresAttached(n any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockGenreRepository)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenresAttached", reflect.TypeOf((*MockEventCounter)(nil).GenresAttached), n)
 }
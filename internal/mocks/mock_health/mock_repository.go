@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/health/contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/health/contracts.go -destination=internal/mocks/mock_health/mock_repository.go
+//
+
+// Package mock_health is a generated GoMock package.
+package mock_health
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPinger is a mock of Pinger interface.
+type MockPinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockPingerMockRecorder
+	isgomock struct{}
+}
+
+// MockPingerMockRecorder is the mock recorder for MockPinger.
+type MockPingerMockRecorder struct {
+	mock *MockPinger
+}
+
+// NewMockPinger creates a new mock instance.
+func NewMockPinger(ctrl *gomock.Controller) *MockPinger {
+	mock := &MockPinger{ctrl: ctrl}
+	mock.recorder = &MockPingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPinger) EXPECT() *MockPingerMockRecorder {
+	return m.recorder
+}
+
+// Ping mocks base method.
+func (m *MockPinger) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockPingerMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockPinger)(nil).Ping), ctx)
+}
+
+// MockSchemaVersioner is a mock of SchemaVersioner interface.
+type MockSchemaVersioner struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaVersionerMockRecorder
+	isgomock struct{}
+}
+
+// MockSchemaVersionerMockRecorder is the mock recorder for MockSchemaVersioner.
+type MockSchemaVersionerMockRecorder struct {
+	mock *MockSchemaVersioner
+}
+
+// NewMockSchemaVersioner creates a new mock instance.
+func NewMockSchemaVersioner(ctrl *gomock.Controller) *MockSchemaVersioner {
+	mock := &MockSchemaVersioner{ctrl: ctrl}
+	mock.recorder = &MockSchemaVersionerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaVersioner) EXPECT() *MockSchemaVersionerMockRecorder {
+	return m.recorder
+}
+
+// Version mocks base method.
+func (m *MockSchemaVersioner) Version(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockSchemaVersionerMockRecorder) Version(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockSchemaVersioner)(nil).Version), ctx)
+}
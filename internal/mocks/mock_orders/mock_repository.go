@@ -0,0 +1,342 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/orders/contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/orders/contracts.go -destination=internal/mocks/mock_orders/mock_repository.go
+//
+
+// Package mock_orders is a generated GoMock package.
+package mock_orders
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/4udiwe/musicshop/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderRepository) Create(ctx context.Context, order entity.Order) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, order)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderRepositoryMockRecorder) Create(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepository)(nil).Create), ctx, order)
+}
+
+// FindById mocks base method.
+func (m *MockOrderRepository) FindById(ctx context.Context, id int64) (entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockOrderRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderRepository)(nil).FindById), ctx, id)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, id int64, from, to entity.OrderStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockOrderRepositoryMockRecorder) UpdateStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepository)(nil).UpdateStatus), ctx, id, from, to)
+}
+
+// MockCartRepository is a mock of CartRepository interface.
+type MockCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCartRepositoryMockRecorder is the mock recorder for MockCartRepository.
+type MockCartRepositoryMockRecorder struct {
+	mock *MockCartRepository
+}
+
+// NewMockCartRepository creates a new mock instance.
+func NewMockCartRepository(ctrl *gomock.Controller) *MockCartRepository {
+	mock := &MockCartRepository{ctrl: ctrl}
+	mock.recorder = &MockCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCartRepository) Create(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCartRepositoryMockRecorder) Create(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCartRepository)(nil).Create), ctx, userID)
+}
+
+// Delete mocks base method.
+func (m *MockCartRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCartRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCartRepository)(nil).Delete), ctx, id)
+}
+
+// FindById mocks base method.
+func (m *MockCartRepository) FindById(ctx context.Context, id int64) (entity.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCartRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCartRepository)(nil).FindById), ctx, id)
+}
+
+// RemoveItem mocks base method.
+func (m *MockCartRepository) RemoveItem(ctx context.Context, cartID, albumID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", ctx, cartID, albumID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockCartRepositoryMockRecorder) RemoveItem(ctx, cartID, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockCartRepository)(nil).RemoveItem), ctx, cartID, albumID)
+}
+
+// SetItem mocks base method.
+func (m *MockCartRepository) SetItem(ctx context.Context, cartID, albumID int64, format string, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", ctx, cartID, albumID, format, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockCartRepositoryMockRecorder) SetItem(ctx, cartID, albumID, format, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockCartRepository)(nil).SetItem), ctx, cartID, albumID, format, quantity)
+}
+
+// MockStockRepository is a mock of StockRepository interface.
+type MockStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStockRepositoryMockRecorder is the mock recorder for MockStockRepository.
+type MockStockRepositoryMockRecorder struct {
+	mock *MockStockRepository
+}
+
+// NewMockStockRepository creates a new mock instance.
+func NewMockStockRepository(ctrl *gomock.Controller) *MockStockRepository {
+	mock := &MockStockRepository{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepository) EXPECT() *MockStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMovement mocks base method.
+func (m *MockStockRepository) AddMovement(ctx context.Context, movement entity.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovement", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMovement indicates an expected call of AddMovement.
+func (mr *MockStockRepositoryMockRecorder) AddMovement(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovement", reflect.TypeOf((*MockStockRepository)(nil).AddMovement), ctx, movement)
+}
+
+// ChangeQuantity mocks base method.
+func (m *MockStockRepository) ChangeQuantity(ctx context.Context, skuID int64, delta int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeQuantity", ctx, skuID, delta)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeQuantity indicates an expected call of ChangeQuantity.
+func (mr *MockStockRepositoryMockRecorder) ChangeQuantity(ctx, skuID, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeQuantity", reflect.TypeOf((*MockStockRepository)(nil).ChangeQuantity), ctx, skuID, delta)
+}
+
+// FindSKU mocks base method.
+func (m *MockStockRepository) FindSKU(ctx context.Context, albumID int64, format string) (entity.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSKU", ctx, albumID, format)
+	ret0, _ := ret[0].(entity.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSKU indicates an expected call of FindSKU.
+func (mr *MockStockRepositoryMockRecorder) FindSKU(ctx, albumID, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSKU", reflect.TypeOf((*MockStockRepository)(nil).FindSKU), ctx, albumID, format)
+}
+
+// MockPriceQuoter is a mock of PriceQuoter interface.
+type MockPriceQuoter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceQuoterMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceQuoterMockRecorder is the mock recorder for MockPriceQuoter.
+type MockPriceQuoterMockRecorder struct {
+	mock *MockPriceQuoter
+}
+
+// NewMockPriceQuoter creates a new mock instance.
+func NewMockPriceQuoter(ctrl *gomock.Controller) *MockPriceQuoter {
+	mock := &MockPriceQuoter{ctrl: ctrl}
+	mock.recorder = &MockPriceQuoterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceQuoter) EXPECT() *MockPriceQuoterMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockPriceQuoter) Quote(ctx context.Context, currency string, prices map[int64]entity.Money) (map[int64]entity.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, currency, prices)
+	ret0, _ := ret[0].(map[int64]entity.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockPriceQuoterMockRecorder) Quote(ctx, currency, prices any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockPriceQuoter)(nil).Quote), ctx, currency, prices)
+}
+
+// MockPromotionPricer is a mock of PromotionPricer interface.
+type MockPromotionPricer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionPricerMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionPricerMockRecorder is the mock recorder for MockPromotionPricer.
+type MockPromotionPricerMockRecorder struct {
+	mock *MockPromotionPricer
+}
+
+// NewMockPromotionPricer creates a new mock instance.
+func NewMockPromotionPricer(ctrl *gomock.Controller) *MockPromotionPricer {
+	mock := &MockPromotionPricer{ctrl: ctrl}
+	mock.recorder = &MockPromotionPricerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionPricer) EXPECT() *MockPromotionPricerMockRecorder {
+	return m.recorder
+}
+
+// Price mocks base method.
+func (m *MockPromotionPricer) Price(ctx context.Context, cart entity.Cart, code string) (entity.Pricing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Price", ctx, cart, code)
+	ret0, _ := ret[0].(entity.Pricing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Price indicates an expected call of Price.
+func (mr *MockPromotionPricerMockRecorder) Price(ctx, cart, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Price", reflect.TypeOf((*MockPromotionPricer)(nil).Price), ctx, cart, code)
+}
+
+// Redeem mocks base method.
+func (m *MockPromotionPricer) Redeem(ctx context.Context, pricing entity.Pricing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, pricing)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockPromotionPricerMockRecorder) Redeem(ctx, pricing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockPromotionPricer)(nil).Redeem), ctx, pricing)
+}
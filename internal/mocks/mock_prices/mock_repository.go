@@ -0,0 +1,211 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/prices/contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/prices/contracts.go -destination=internal/mocks/mock_prices/mock_repository.go
+//
+
+// Package mock_prices is a generated GoMock package.
+package mock_prices
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/4udiwe/musicshop/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPriceRepository is a mock of PriceRepository interface.
+type MockPriceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceRepositoryMockRecorder is the mock recorder for MockPriceRepository.
+type MockPriceRepositoryMockRecorder struct {
+	mock *MockPriceRepository
+}
+
+// NewMockPriceRepository creates a new mock instance.
+func NewMockPriceRepository(ctrl *gomock.Controller) *MockPriceRepository {
+	mock := &MockPriceRepository{ctrl: ctrl}
+	mock.recorder = &MockPriceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceRepository) EXPECT() *MockPriceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplyDue mocks base method.
+func (m *MockPriceRepository) ApplyDue(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDue", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyDue indicates an expected call of ApplyDue.
+func (mr *MockPriceRepositoryMockRecorder) ApplyDue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDue", reflect.TypeOf((*MockPriceRepository)(nil).ApplyDue), ctx)
+}
+
+// FindAt mocks base method.
+func (m *MockPriceRepository) FindAt(ctx context.Context, albumID int64, currency string, at time.Time) (entity.AlbumPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAt", ctx, albumID, currency, at)
+	ret0, _ := ret[0].(entity.AlbumPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAt indicates an expected call of FindAt.
+func (mr *MockPriceRepositoryMockRecorder) FindAt(ctx, albumID, currency, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAt", reflect.TypeOf((*MockPriceRepository)(nil).FindAt), ctx, albumID, currency, at)
+}
+
+// FindByAlbumID mocks base method.
+func (m *MockPriceRepository) FindByAlbumID(ctx context.Context, albumID int64) ([]entity.AlbumPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAlbumID", ctx, albumID)
+	ret0, _ := ret[0].([]entity.AlbumPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAlbumID indicates an expected call of FindByAlbumID.
+func (mr *MockPriceRepositoryMockRecorder) FindByAlbumID(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAlbumID", reflect.TypeOf((*MockPriceRepository)(nil).FindByAlbumID), ctx, albumID)
+}
+
+// FindCurrent mocks base method.
+func (m *MockPriceRepository) FindCurrent(ctx context.Context, albumIDs []int64, currency string) (map[int64]entity.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCurrent", ctx, albumIDs, currency)
+	ret0, _ := ret[0].(map[int64]entity.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCurrent indicates an expected call of FindCurrent.
+func (mr *MockPriceRepositoryMockRecorder) FindCurrent(ctx, albumIDs, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCurrent", reflect.TypeOf((*MockPriceRepository)(nil).FindCurrent), ctx, albumIDs, currency)
+}
+
+// Schedule mocks base method.
+func (m *MockPriceRepository) Schedule(ctx context.Context, albumID int64, price entity.Money, from time.Time) (entity.AlbumPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, albumID, price, from)
+	ret0, _ := ret[0].(entity.AlbumPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockPriceRepositoryMockRecorder) Schedule(ctx, albumID, price, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockPriceRepository)(nil).Schedule), ctx, albumID, price, from)
+}
+
+// MockAlbumRepository is a mock of AlbumRepository interface.
+type MockAlbumRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlbumRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAlbumRepositoryMockRecorder is the mock recorder for MockAlbumRepository.
+type MockAlbumRepositoryMockRecorder struct {
+	mock *MockAlbumRepository
+}
+
+// NewMockAlbumRepository creates a new mock instance.
+func NewMockAlbumRepository(ctrl *gomock.Controller) *MockAlbumRepository {
+	mock := &MockAlbumRepository{ctrl: ctrl}
+	mock.recorder = &MockAlbumRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlbumRepository) EXPECT() *MockAlbumRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindById mocks base method.
+func (m *MockAlbumRepository) FindById(ctx context.Context, id int64) (entity.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockAlbumRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockAlbumRepository)(nil).FindById), ctx, id)
+}
+
+// FindSKUPrice mocks base method.
+func (m *MockAlbumRepository) FindSKUPrice(ctx context.Context, albumID int64) (entity.Money, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSKUPrice", ctx, albumID)
+	ret0, _ := ret[0].(entity.Money)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindSKUPrice indicates an expected call of FindSKUPrice.
+func (mr *MockAlbumRepositoryMockRecorder) FindSKUPrice(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSKUPrice", reflect.TypeOf((*MockAlbumRepository)(nil).FindSKUPrice), ctx, albumID)
+}
+
+// MockAuditWriter is a mock of AuditWriter interface.
+type MockAuditWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditWriterMockRecorder is the mock recorder for MockAuditWriter.
+type MockAuditWriterMockRecorder struct {
+	mock *MockAuditWriter
+}
+
+// NewMockAuditWriter creates a new mock instance.
+func NewMockAuditWriter(ctrl *gomock.Controller) *MockAuditWriter {
+	mock := &MockAuditWriter{ctrl: ctrl}
+	mock.recorder = &MockAuditWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditWriter) EXPECT() *MockAuditWriterMockRecorder {
+	return m.recorder
+}
+
+// Write mocks base method.
+func (m *MockAuditWriter) Write(ctx context.Context, event entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockAuditWriterMockRecorder) Write(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockAuditWriter)(nil).Write), ctx, event)
+}
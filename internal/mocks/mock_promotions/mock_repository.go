@@ -0,0 +1,210 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/promotions/contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/promotions/contracts.go -destination=internal/mocks/mock_promotions/mock_repository.go
+//
+
+// Package mock_promotions is a generated GoMock package.
+package mock_promotions
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/4udiwe/musicshop/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromotionRepository) Create(ctx context.Context, p entity.Promotion) (entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, p)
+	ret0, _ := ret[0].(entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionRepositoryMockRecorder) Create(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionRepository)(nil).Create), ctx, p)
+}
+
+// FindAlbumTags mocks base method.
+func (m *MockPromotionRepository) FindAlbumTags(ctx context.Context, albumIDs []int64) (map[int64]entity.PricingLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAlbumTags", ctx, albumIDs)
+	ret0, _ := ret[0].(map[int64]entity.PricingLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAlbumTags indicates an expected call of FindAlbumTags.
+func (mr *MockPromotionRepositoryMockRecorder) FindAlbumTags(ctx, albumIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAlbumTags", reflect.TypeOf((*MockPromotionRepository)(nil).FindAlbumTags), ctx, albumIDs)
+}
+
+// FindAll mocks base method.
+func (m *MockPromotionRepository) FindAll(ctx context.Context) ([]entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPromotionRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPromotionRepository)(nil).FindAll), ctx)
+}
+
+// FindAutomatic mocks base method.
+func (m *MockPromotionRepository) FindAutomatic(ctx context.Context, at time.Time) ([]entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAutomatic", ctx, at)
+	ret0, _ := ret[0].([]entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAutomatic indicates an expected call of FindAutomatic.
+func (mr *MockPromotionRepositoryMockRecorder) FindAutomatic(ctx, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAutomatic", reflect.TypeOf((*MockPromotionRepository)(nil).FindAutomatic), ctx, at)
+}
+
+// FindByCode mocks base method.
+func (m *MockPromotionRepository) FindByCode(ctx context.Context, code string) (entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", ctx, code)
+	ret0, _ := ret[0].(entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockPromotionRepositoryMockRecorder) FindByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockPromotionRepository)(nil).FindByCode), ctx, code)
+}
+
+// Redeem mocks base method.
+func (m *MockPromotionRepository) Redeem(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockPromotionRepositoryMockRecorder) Redeem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockPromotionRepository)(nil).Redeem), ctx, id)
+}
+
+// MockCartRepository is a mock of CartRepository interface.
+type MockCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCartRepositoryMockRecorder is the mock recorder for MockCartRepository.
+type MockCartRepositoryMockRecorder struct {
+	mock *MockCartRepository
+}
+
+// NewMockCartRepository creates a new mock instance.
+func NewMockCartRepository(ctrl *gomock.Controller) *MockCartRepository {
+	mock := &MockCartRepository{ctrl: ctrl}
+	mock.recorder = &MockCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindById mocks base method.
+func (m *MockCartRepository) FindById(ctx context.Context, id int64) (entity.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCartRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCartRepository)(nil).FindById), ctx, id)
+}
+
+// MockPriceQuoter is a mock of PriceQuoter interface.
+type MockPriceQuoter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceQuoterMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceQuoterMockRecorder is the mock recorder for MockPriceQuoter.
+type MockPriceQuoterMockRecorder struct {
+	mock *MockPriceQuoter
+}
+
+// NewMockPriceQuoter creates a new mock instance.
+func NewMockPriceQuoter(ctrl *gomock.Controller) *MockPriceQuoter {
+	mock := &MockPriceQuoter{ctrl: ctrl}
+	mock.recorder = &MockPriceQuoterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceQuoter) EXPECT() *MockPriceQuoterMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockPriceQuoter) Quote(ctx context.Context, currency string, prices map[int64]entity.Money) (map[int64]entity.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, currency, prices)
+	ret0, _ := ret[0].(map[int64]entity.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockPriceQuoterMockRecorder) Quote(ctx, currency, prices any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockPriceQuoter)(nil).Quote), ctx, currency, prices)
+}
@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/stock/contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/stock/contracts.go -destination=internal/mocks/mock_stock/mock_repository.go
+//
+
+// Package mock_stock is a generated GoMock package.
+package mock_stock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/4udiwe/musicshop/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockRepository is a mock of StockRepository interface.
+type MockStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStockRepositoryMockRecorder is the mock recorder for MockStockRepository.
+type MockStockRepositoryMockRecorder struct {
+	mock *MockStockRepository
+}
+
+// NewMockStockRepository creates a new mock instance.
+func NewMockStockRepository(ctrl *gomock.Controller) *MockStockRepository {
+	mock := &MockStockRepository{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepository) EXPECT() *MockStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMovement mocks base method.
+func (m *MockStockRepository) AddMovement(ctx context.Context, movement entity.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovement", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMovement indicates an expected call of AddMovement.
+func (mr *MockStockRepositoryMockRecorder) AddMovement(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovement", reflect.TypeOf((*MockStockRepository)(nil).AddMovement), ctx, movement)
+}
+
+// ChangeQuantity mocks base method.
+func (m *MockStockRepository) ChangeQuantity(ctx context.Context, skuID int64, delta int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeQuantity", ctx, skuID, delta)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeQuantity indicates an expected call of ChangeQuantity.
+func (mr *MockStockRepositoryMockRecorder) ChangeQuantity(ctx, skuID, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeQuantity", reflect.TypeOf((*MockStockRepository)(nil).ChangeQuantity), ctx, skuID, delta)
+}
+
+// FindSKU mocks base method.
+func (m *MockStockRepository) FindSKU(ctx context.Context, albumID int64, format string) (entity.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSKU", ctx, albumID, format)
+	ret0, _ := ret[0].(entity.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSKU indicates an expected call of FindSKU.
+func (mr *MockStockRepositoryMockRecorder) FindSKU(ctx, albumID, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSKU", reflect.TypeOf((*MockStockRepository)(nil).FindSKU), ctx, albumID, format)
+}
+
+// FindSKUs mocks base method.
+func (m *MockStockRepository) FindSKUs(ctx context.Context, albumID int64) ([]entity.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSKUs", ctx, albumID)
+	ret0, _ := ret[0].([]entity.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSKUs indicates an expected call of FindSKUs.
+func (mr *MockStockRepositoryMockRecorder) FindSKUs(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSKUs", reflect.TypeOf((*MockStockRepository)(nil).FindSKUs), ctx, albumID)
+}
+
+// SyncAlbumPrice mocks base method.
+func (m *MockStockRepository) SyncAlbumPrice(ctx context.Context, albumID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncAlbumPrice", ctx, albumID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncAlbumPrice indicates an expected call of SyncAlbumPrice.
+func (mr *MockStockRepositoryMockRecorder) SyncAlbumPrice(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncAlbumPrice", reflect.TypeOf((*MockStockRepository)(nil).SyncAlbumPrice), ctx, albumID)
+}
+
+// UpsertSKU mocks base method.
+func (m *MockStockRepository) UpsertSKU(ctx context.Context, sku entity.SKU) (entity.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSKU", ctx, sku)
+	ret0, _ := ret[0].(entity.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertSKU indicates an expected call of UpsertSKU.
+func (mr *MockStockRepositoryMockRecorder) UpsertSKU(ctx, sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSKU", reflect.TypeOf((*MockStockRepository)(nil).UpsertSKU), ctx, sku)
+}
+
+// MockAlbumRepository is a mock of AlbumRepository interface.
+type MockAlbumRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlbumRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAlbumRepositoryMockRecorder is the mock recorder for MockAlbumRepository.
+type MockAlbumRepositoryMockRecorder struct {
+	mock *MockAlbumRepository
+}
+
+// NewMockAlbumRepository creates a new mock instance.
+func NewMockAlbumRepository(ctrl *gomock.Controller) *MockAlbumRepository {
+	mock := &MockAlbumRepository{ctrl: ctrl}
+	mock.recorder = &MockAlbumRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlbumRepository) EXPECT() *MockAlbumRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindById mocks base method.
+func (m *MockAlbumRepository) FindById(ctx context.Context, id int64) (entity.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(entity.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockAlbumRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockAlbumRepository)(nil).FindById), ctx, id)
+}
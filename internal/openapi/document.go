@@ -0,0 +1,220 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	bearerAuth      = "bearerAuth"
+	mimeJSON        = "application/json"
+	defaultResponse = "default"
+)
+
+// Route описывает операцию API: маршрут echo и контракты его обработчика
+type Route struct {
+	Method string
+	// Path - путь в формате echo: /albums/:id
+	Path        string
+	Tag         string
+	Summary     string
+	Description string
+	// Request - значение типа Request обработчика, nil - без входных данных
+	Request any
+	// Status - код успешного ответа, Response - его тело, nil - ответ без тела
+	Status   int
+	Response any
+	// Permission - разрешение, без которого маршрут отвечает 403
+	Permission string
+	// Authenticated - маршрут доступен любому пользователю с токеном.
+	// Без Permission и Authenticated маршрут публичный.
+	Authenticated bool
+	// Deprecated - маршрут оставлен для совместимости
+	Deprecated bool
+}
+
+// Build строит спецификацию по маршрутам. Параметры и тело запроса берутся из тегов
+// param, query и json полей Request, ограничения - из тегов validate.
+// errorResponse описывает тело ответа с ошибкой, общее для всех операций.
+func Build(info Info, errorResponse any, routes []Route) (*Document, error) {
+	s := newSchemas()
+
+	errorSchema, err := s.schemaOf(reflect.TypeOf(errorResponse))
+	if err != nil {
+		return nil, fmt.Errorf("error response: %w", err)
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: s.components,
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	for _, route := range routes {
+		op, err := s.operation(route)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+		}
+		op.Responses[defaultResponse] = Response{
+			Description: "Error",
+			Content:     map[string]MediaType{mimeJSON: {Schema: errorSchema}},
+		}
+
+		path := specPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		method := strings.ToLower(route.Method)
+		if _, ok := doc.Paths[path][method]; ok {
+			return nil, fmt.Errorf("%s %s: duplicate route", route.Method, route.Path)
+		}
+		doc.Paths[path][method] = op
+	}
+
+	return doc, nil
+}
+
+// Has сообщает, описан ли маршрут echo в спецификации
+func (d *Document) Has(method, path string) bool {
+	_, ok := d.Paths[specPath(path)][strings.ToLower(method)]
+	return ok
+}
+
+func (s *schemas) operation(route Route) (*Operation, error) {
+	op := &Operation{
+		OperationID: operationID(route.Method, route.Path),
+		Summary:     route.Summary,
+		Description: route.Description,
+		Deprecated:  route.Deprecated,
+		Responses:   map[string]Response{},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+	if route.Permission != "" || route.Authenticated {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+	}
+	if route.Permission != "" {
+		op.Description = strings.TrimSpace(fmt.Sprintf("%s\n\nRequires permission `%s`.", op.Description, route.Permission))
+	}
+
+	if route.Request != nil {
+		if err := s.addRequest(op, route); err != nil {
+			return nil, err
+		}
+	}
+
+	response := Response{Description: http.StatusText(route.Status)}
+	if response.Description == "" {
+		return nil, fmt.Errorf("unknown status %d", route.Status)
+	}
+	if route.Response != nil {
+		schema, err := s.schemaOf(reflect.TypeOf(route.Response))
+		if err != nil {
+			return nil, fmt.Errorf("response: %w", err)
+		}
+		response.Content = map[string]MediaType{mimeJSON: {Schema: schema}}
+	}
+	op.Responses[strconv.Itoa(route.Status)] = response
+
+	return op, nil
+}
+
+// addRequest описывает параметры пути и запроса и тело. Каждый параметр пути
+// маршрута должен быть полем Request, иначе обработчик его не получит.
+func (s *schemas) addRequest(op *Operation, route Route) error {
+	t := deref(reflect.TypeOf(route.Request))
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("request %s is not a struct", t)
+	}
+
+	var hasBody bool
+	for field := range fields(t) {
+		in, name := "", ""
+		if v, ok := field.Tag.Lookup("param"); ok {
+			in, name = "path", v
+		} else if v, ok := field.Tag.Lookup("query"); ok {
+			in, name = "query", v
+		} else {
+			hasBody = hasBody || field.Tag.Get("json") != "-"
+			continue
+		}
+
+		schema, err := s.schemaOf(field.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, field.Name, err)
+		}
+		required, err := applyRules(schema, field.Type, field.Tag.Get("validate"))
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, field.Name, err)
+		}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     name,
+			In:       in,
+			Required: required || in == "path",
+			Schema:   schema,
+		})
+	}
+
+	for _, name := range pathParams(route.Path) {
+		if !slices.ContainsFunc(op.Parameters, func(p Parameter) bool { return p.In == "path" && p.Name == name }) {
+			return fmt.Errorf("path parameter %q is not bound by %s", name, t)
+		}
+	}
+
+	if hasBody {
+		schema, err := s.structRef(t)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{mimeJSON: {Schema: schema}}}
+	}
+	return nil
+}
+
+// specPath переводит путь echo в шаблон OpenAPI: /albums/:id -> /albums/{id}
+func specPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParams(path string) []string {
+	var params []string
+	for segment := range strings.SplitSeq(path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			params = append(params, name)
+		}
+	}
+	return params
+}
+
+// operationID строит идентификатор из метода и пути: GET /albums/:id/tracks -> getAlbumsByIdTracks
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for segment := range strings.SplitSeq(path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			b.WriteString("By")
+			segment = name
+		}
+		for word := range strings.FieldsFuncSeq(segment, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,162 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errorResponse struct {
+	Code string `json:"code"`
+}
+
+type Item struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+type Request struct {
+	ID       int64    `param:"id" validate:"required"`
+	Limit    int      `query:"limit" validate:"omitempty,min=1,max=100"`
+	Q        string   `query:"q" validate:"required,max=200"`
+	Title    string   `json:"title" validate:"required,min=2"`
+	Email    *string  `json:"email" validate:"omitempty,email"`
+	Kind     string   `json:"kind" validate:"required,oneof=percent fixed"`
+	Level    int      `json:"level" validate:"omitempty,oneof=1 2 3"`
+	Rating   float64  `json:"rating" validate:"gt=0,lt=5"`
+	Currency string   `json:"currency" validate:"omitempty,iso4217"`
+	TagIDs   []int64  `json:"tag_ids" validate:"omitempty,max=10,dive,min=1"`
+	Items    []Item   `json:"items" validate:"dive"`
+	Codes    []string `json:"codes" validate:"dive,len=12"`
+	Internal string   `json:"-"`
+}
+
+type Node struct {
+	Name     string    `json:"name"`
+	Children []Node    `json:"children"`
+	At       time.Time `json:"at"`
+}
+
+func build(t *testing.T, routes ...openapi.Route) *openapi.Document {
+	t.Helper()
+	document, err := openapi.Build(openapi.Info{Title: "test", Version: "1"}, errorResponse{}, routes)
+	require.NoError(t, err)
+	return document
+}
+
+func TestBuildRequest(t *testing.T) {
+	document := build(t, openapi.Route{
+		Method: http.MethodPost, Path: "/things/:id", Request: Request{}, Status: http.StatusCreated, Permission: "things:write",
+	})
+
+	op := document.Paths["/things/{id}"]["post"]
+	require.NotNil(t, op)
+	assert.Equal(t, "postThingsById", op.OperationID)
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, op.Security)
+	assert.Contains(t, op.Responses, "201")
+	assert.Contains(t, op.Responses, "default")
+
+	require.Len(t, op.Parameters, 3)
+	assert.Equal(t, openapi.Parameter{Name: "id", In: "path", Required: true, Schema: &openapi.Schema{Type: "integer", Format: "int64"}}, op.Parameters[0])
+	assert.Equal(t, openapi.Parameter{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer", Format: "int64", Minimum: ptr(1.0), Maximum: ptr(100.0)}}, op.Parameters[1])
+	assert.Equal(t, openapi.Parameter{Name: "q", In: "query", Required: true, Schema: &openapi.Schema{Type: "string", MaxLength: ptr(200)}}, op.Parameters[2])
+
+	require.NotNil(t, op.RequestBody)
+	assert.Equal(t, "#/components/schemas/openapi_test.Request", op.RequestBody.Content["application/json"].Schema.Ref)
+
+	body := document.Components.Schemas["openapi_test.Request"]
+	require.NotNil(t, body)
+	assert.Equal(t, []string{"title", "kind"}, body.Required)
+	assert.NotContains(t, body.Properties, "id")
+	assert.NotContains(t, body.Properties, "limit")
+	assert.NotContains(t, body.Properties, "Internal")
+
+	for _, tc := range []struct {
+		name string
+		want *openapi.Schema
+	}{
+		{"title", &openapi.Schema{Type: "string", MinLength: ptr(2)}},
+		{"email", &openapi.Schema{Type: []string{"string", "null"}, Format: "email"}},
+		{"kind", &openapi.Schema{Type: "string", Enum: []any{"percent", "fixed"}}},
+		{"level", &openapi.Schema{Type: "integer", Format: "int64", Enum: []any{int64(1), int64(2), int64(3)}}},
+		{"rating", &openapi.Schema{Type: "number", ExclusiveMinimum: ptr(0.0), ExclusiveMaximum: ptr(5.0)}},
+		{"currency", &openapi.Schema{Type: "string", Pattern: "^[A-Z]{3}$"}},
+		{"tag_ids", &openapi.Schema{Type: "array", MaxItems: ptr(10), Items: &openapi.Schema{Type: "integer", Format: "int64", Minimum: ptr(1.0)}}},
+		{"items", &openapi.Schema{Type: "array", Items: &openapi.Schema{Ref: "#/components/schemas/openapi_test.Item"}}},
+		{"codes", &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "string", MinLength: ptr(12), MaxLength: ptr(12)}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, body.Properties[tc.name])
+		})
+	}
+
+	assert.Equal(t, []string{"id"}, document.Components.Schemas["openapi_test.Item"].Required)
+}
+
+func TestBuildResponse(t *testing.T) {
+	document := build(t,
+		openapi.Route{Method: http.MethodGet, Path: "/nodes", Status: http.StatusOK, Response: []Node{}},
+		openapi.Route{Method: http.MethodDelete, Path: "/nodes", Status: http.StatusNoContent},
+		openapi.Route{Method: http.MethodGet, Path: "/nodes/mine", Status: http.StatusOK, Authenticated: true, Deprecated: true},
+	)
+
+	list := document.Paths["/nodes"]["get"].Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, &openapi.Schema{Type: "array", Items: &openapi.Schema{Ref: "#/components/schemas/openapi_test.Node"}}, list)
+
+	// рекурсивный тип описывается ссылкой на себя
+	node := document.Components.Schemas["openapi_test.Node"]
+	assert.Equal(t, &openapi.Schema{Ref: "#/components/schemas/openapi_test.Node"}, node.Properties["children"].Items)
+	assert.Equal(t, &openapi.Schema{Type: "string", Format: "date-time"}, node.Properties["at"])
+
+	deleted := document.Paths["/nodes"]["delete"]
+	assert.Nil(t, deleted.RequestBody)
+	assert.Empty(t, deleted.Responses["204"].Content)
+
+	assert.Nil(t, document.Paths["/nodes"]["get"].Security)
+	mine := document.Paths["/nodes/mine"]["get"]
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, mine.Security)
+	assert.Empty(t, mine.Description)
+	assert.True(t, mine.Deprecated)
+
+	assert.True(t, document.Has(http.MethodDelete, "/nodes"))
+	assert.False(t, document.Has(http.MethodPost, "/nodes"))
+}
+
+func TestBuildErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		routes []openapi.Route
+	}{
+		{
+			name:   "path parameter is not bound",
+			routes: []openapi.Route{{Method: http.MethodGet, Path: "/things/:thing_id", Request: Request{}, Status: http.StatusOK}},
+		},
+		{
+			name: "duplicate route",
+			routes: []openapi.Route{
+				{Method: http.MethodGet, Path: "/nodes", Status: http.StatusOK},
+				{Method: http.MethodGet, Path: "/nodes", Status: http.StatusOK},
+			},
+		},
+		{
+			name:   "unknown status",
+			routes: []openapi.Route{{Method: http.MethodGet, Path: "/nodes"}},
+		},
+		{
+			name:   "unsupported type",
+			routes: []openapi.Route{{Method: http.MethodGet, Path: "/nodes", Status: http.StatusOK, Response: make(chan int)}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := openapi.Build(openapi.Info{}, errorResponse{}, tc.routes)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// formats - правила validate, которые описываются форматом или шаблоном строки
+var formats = map[string]Schema{
+	"email":    {Format: "email"},
+	"url":      {Format: "uri"},
+	"uri":      {Format: "uri"},
+	"uuid":     {Format: "uuid"},
+	"alpha":    {Pattern: "^[a-zA-Z]+$"},
+	"alphanum": {Pattern: "^[a-zA-Z0-9]+$"},
+	"numeric":  {Pattern: "^[-+]?[0-9]+(\\.[0-9]+)?$"},
+	"iso4217":  {Pattern: "^[A-Z]{3}$"},
+	"isrc":     {Pattern: "^[A-Za-z]{2}[A-Za-z0-9]{3}[0-9]{7}$"},
+}
+
+// applyRules переносит правила тега validate в ограничения схемы и сообщает,
+// обязательно ли поле. Правила после dive относятся к элементам списка.
+// Правила без аналога в JSON Schema (required_without и т.п.) пропускаются.
+func applyRules(schema *Schema, t reflect.Type, tag string) (bool, error) {
+	if tag == "" {
+		return false, nil
+	}
+
+	var required, dived bool
+	for rule := range strings.SplitSeq(tag, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+
+		if name == "dive" {
+			t = deref(t)
+			if schema.Items == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+				return false, fmt.Errorf("dive on non-list type %s", t)
+			}
+			schema, t, dived = schema.Items, t.Elem(), true
+			continue
+		}
+		if name == "required" {
+			required = required || !dived
+			continue
+		}
+		// у ссылки на схему ограничения задаются в самой схеме
+		if schema.Ref != "" {
+			continue
+		}
+
+		if err := applyRule(schema, deref(t), name, value); err != nil {
+			return false, fmt.Errorf("rule %q: %w", rule, err)
+		}
+	}
+	return required, nil
+}
+
+func applyRule(schema *Schema, t reflect.Type, name, value string) error {
+	if f, ok := formats[name]; ok {
+		if f.Format != "" {
+			schema.Format = f.Format
+		}
+		if f.Pattern != "" {
+			schema.Pattern = f.Pattern
+		}
+		return nil
+	}
+
+	switch name {
+	case "oneof":
+		for option := range strings.FieldsSeq(value) {
+			v, err := enumValue(t, option)
+			if err != nil {
+				return err
+			}
+			schema.Enum = append(schema.Enum, v)
+		}
+	case "min", "gte":
+		return setBound(schema, t, value, 0, false)
+	case "gt":
+		return setBound(schema, t, value, 1, false)
+	case "max", "lte":
+		return setBound(schema, t, value, 0, true)
+	case "lt":
+		return setBound(schema, t, value, -1, true)
+	case "len":
+		if err := setBound(schema, t, value, 0, false); err != nil {
+			return err
+		}
+		return setBound(schema, t, value, 0, true)
+	}
+	return nil
+}
+
+// setBound задает нижнюю или верхнюю границу: длину для строк, число элементов
+// для списков и значение для чисел. shift превращает строгое сравнение
+// в нестрогое для длин; у чисел строгая граница задается через exclusive*.
+func setBound(schema *Schema, t reflect.Type, value string, shift int, upper bool) error {
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		if t == numberType {
+			break
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		n += shift
+
+		switch {
+		case t.Kind() == reflect.String && upper:
+			schema.MaxLength = &n
+		case t.Kind() == reflect.String:
+			schema.MinLength = &n
+		case upper:
+			schema.MaxItems = &n
+		default:
+			schema.MinItems = &n
+		}
+		return nil
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	switch {
+	case upper && shift != 0:
+		schema.ExclusiveMaximum = &n
+	case upper:
+		schema.Maximum = &n
+	case shift != 0:
+		schema.ExclusiveMinimum = &n
+	default:
+		schema.Minimum = &n
+	}
+	return nil
+}
+
+func enumValue(t reflect.Type, option string) (any, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(option, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(option, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(option, 64)
+	}
+	return option, nil
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,178 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	numberType     = reflect.TypeFor[json.Number]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// schemas строит схемы по типам Go. Именованные структуры выносятся в components
+// под именем "<пакет>.<тип>", поэтому рекурсивные типы описываются ссылкой.
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+func (s *schemas) schemaOf(t reflect.Type) (*Schema, error) {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case numberType:
+		// суммы принимаются числом или строкой, чтобы не терять точность
+		return &Schema{Type: []string{"number", "string"}, Format: "decimal"}, nil
+	case rawMessageType:
+		return &Schema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema, err := s.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if typ, ok := schema.Type.(string); ok {
+			schema.Type = []string{typ, "null"}
+		}
+		return schema, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: ptr(0.0)}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := s.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := s.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return s.structRef(t)
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// structRef описывает структуру в components и возвращает ссылку на нее.
+// Анонимные структуры описываются на месте.
+func (s *schemas) structRef(t reflect.Type) (*Schema, error) {
+	if t.Name() == "" {
+		return s.object(t)
+	}
+
+	name, ok := s.names[t]
+	if !ok {
+		name = path.Base(t.PkgPath()) + "." + t.Name()
+		if _, taken := s.components[name]; taken {
+			return nil, fmt.Errorf("schema name %s is used by two types", name)
+		}
+		s.names[t] = name
+		// имя занимается до обхода полей, чтобы рекурсивная ссылка на тип его нашла
+		s.components[name] = nil
+
+		object, err := s.object(t)
+		if err != nil {
+			return nil, err
+		}
+		s.components[name] = object
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}, nil
+}
+
+func (s *schemas) object(t reflect.Type) (*Schema, error) {
+	object := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	if err := s.addFields(object, t); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// addFields добавляет свойства по правилам encoding/json: встроенные структуры
+// без тега раскрываются, поля path и query параметров в тело не входят.
+func (s *schemas) addFields(object *Schema, t reflect.Type) error {
+	for field := range fields(t) {
+		if _, ok := field.Tag.Lookup("param"); ok {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("query"); ok {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := s.addFields(object, field.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := s.schemaOf(field.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, field.Name, err)
+		}
+		required, err := applyRules(schema, field.Type, field.Tag.Get("validate"))
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, field.Name, err)
+		}
+
+		object.Properties[name] = schema
+		if required {
+			object.Required = append(object.Required, name)
+		}
+	}
+	return nil
+}
+
+// fields перечисляет экспортируемые поля структуры, включая встроенные
+func fields(t reflect.Type) func(yield func(reflect.StructField) bool) {
+	return func(yield func(reflect.StructField) bool) {
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			if !yield(field) {
+				return
+			}
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
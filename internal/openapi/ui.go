@@ -0,0 +1,37 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+const initializerScript = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: %q,
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// UI отдает встроенную в бинарник страницу Swagger UI, загружающую спецификацию из specURL.
+// Обработчик ожидает пути без префикса, под которым подключен: /, /swagger-ui.css.
+func UI(specURL string) http.Handler {
+	initializer := []byte(fmt.Sprintf(initializerScript, specURL))
+	files := http.FileServerFS(swaggerFiles.FS)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// в комплекте лежит инициализатор с демонстрационной спецификацией
+		if r.URL.Path == "/swagger-initializer.js" {
+			w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+			_, _ = w.Write(initializer)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+}
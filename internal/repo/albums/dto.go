@@ -1,17 +1,23 @@
 package albums
 
 import (
+	"time"
+
 	"github.com/4udiwe/musicshop/internal/entity"
 	"github.com/samber/lo"
 )
 
 type albumsGenreRow struct {
-	ID         int64    `db:"id"`
-	Title      string   `db:"title"`
-	Artist     string   `db:"artist"`
-	Price      float64  `db:"price"`
-	GenreIDs   []int64  `db:"genre_ids"`
-	GenreNames []string `db:"genre_names"`
+	ID         int64      `db:"id"`
+	Title      string     `db:"title"`
+	ArtistID   int64      `db:"artist_id"`
+	Artist     string     `db:"artist"`
+	Price      int64      `db:"price"` // в базовой валюте
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+	DeletedAt  *time.Time `db:"deleted_at"`
+	GenreIDs   []int64    `db:"genre_ids"`
+	GenreNames []string   `db:"genre_names"`
 }
 
 func (r *albumsGenreRow) convertRowToAlbum() entity.Album {
@@ -23,11 +29,15 @@ func (r *albumsGenreRow) convertRowToAlbum() entity.Album {
 	})
 
 	return entity.Album{
-		ID:     r.ID,
-		Title:  r.Title,
-		Artist: r.Artist,
-		Price:  r.Price,
-		Genres: genres,
+		ID:        r.ID,
+		Title:     r.Title,
+		ArtistID:  r.ArtistID,
+		Artist:    r.Artist,
+		Price:     entity.NewMoney(r.Price, entity.BaseCurrency),
+		Genres:    genres,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+		DeletedAt: r.DeletedAt,
 	}
 }
 
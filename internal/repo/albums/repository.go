@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/lo"
 
 	"github.com/4udiwe/musicshop/internal/entity"
 	"github.com/4udiwe/musicshop/internal/repo"
@@ -27,8 +29,8 @@ func New(postgres *postgres.Postgres) *Repository {
 func (r *Repository) Create(ctx context.Context, album entity.Album) (id int64, err error) {
 	query, args, err := r.pg.Builder.
 		Insert("albums").
-		Columns("title", "artist", "price").
-		Values(album.Title, album.Artist, album.Price).
+		Columns("title", "artist_id", "artist", "price").
+		Values(album.Title, album.ArtistID, album.Artist, album.Price.Amount).
 		Suffix("RETURNING id").
 		ToSql()
 	if err != nil {
@@ -47,94 +49,362 @@ func (r *Repository) Create(ctx context.Context, album entity.Album) (id int64,
 		if errors.Is(err, pgx.ErrNoRows) {
 			return 0, fmt.Errorf("%w: no returned id after insert", err)
 		}
-		return 0, fmt.Errorf("failed to execute query: %w", err)
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
 	}
 	return id, nil
 }
 
-func (r *Repository) FindAll(ctx context.Context) ([]entity.Album, error) {
-	query, args, _ := r.pg.Builder.
+// currentPrice - действующая цена альбома в базовой валюте из истории цен.
+// Вычисляется только в списке выборки: фильтры и сортировка идут по индексированной
+// albums.price, которую задача синхронизации цен догоняет до наступивших цен.
+const currentPrice = "album_price_at(a.id, '" + entity.BaseCurrency + "', NOW())"
+
+var sortColumns = map[string]string{
+	"title":      "a.title",
+	"artist":     "a.artist",
+	"price":      "a.price",
+	"created_at": "a.created_at",
+}
+
+var albumColumns = []string{"a.id", "a.title", "a.artist_id", "a.artist", currentPrice + " AS price", "a.created_at", "a.updated_at", "a.deleted_at"}
+
+// withGenres оборачивает выборку альбомов запросом, агрегирующим жанры каждого альбома.
+// inner должен выбирать albumColumns и строиться без плейсхолдеров Dollar.
+func (r *Repository) withGenres(inner squirrel.SelectBuilder) squirrel.SelectBuilder {
+	return r.pg.Builder.
 		Select(`
             a.id, 
             a.title, 
+            a.artist_id,
             a.artist, 
             a.price,
+            a.created_at,
+            a.updated_at,
+            a.deleted_at,
             ARRAY_AGG(g.id ORDER BY g.id) FILTER (WHERE g.id IS NOT NULL) as genre_ids,
             ARRAY_AGG(g.name ORDER BY g.id) FILTER (WHERE g.name IS NOT NULL) as genre_names
         `).
-		From("albums a").
+		FromSelect(inner, "a").
 		LeftJoin("album_genres ag ON a.id = ag.album_id").
-		LeftJoin("genres g ON ag.genre_id = g.id").
+		LeftJoin("genres g ON ag.genre_id = g.id AND g.deleted_at IS NULL").
 		GroupBy(`
 			a.id, 
             a.title, 
+            a.artist_id,
             a.artist, 
-            a.price`).
-		ToSql()
+            a.price,
+            a.created_at,
+            a.updated_at,
+            a.deleted_at`)
+}
+
+func (r *Repository) FindAll(ctx context.Context, filter entity.AlbumFilter) ([]entity.Album, error) {
+	inner := applyFilter(squirrel.Select(albumColumns...).From("albums a"), filter)
+
+	direction := "ASC"
+	cmp := ">"
+	if filter.SortDesc {
+		direction = "DESC"
+		cmp = "<"
+	}
+
+	orderBy := []string{"a.id " + direction}
+	if column, ok := sortColumns[filter.SortBy]; ok {
+		orderBy = append([]string{column + " " + direction}, orderBy...)
+		if filter.Cursor > 0 {
+			inner = inner.Where(
+				fmt.Sprintf("(%s, a.id) %s (SELECT %s, a.id FROM albums a WHERE a.id = ?)", column, cmp, column),
+				filter.Cursor,
+			)
+		}
+	} else if filter.Cursor > 0 {
+		inner = inner.Where(fmt.Sprintf("a.id %s ?", cmp), filter.Cursor)
+	}
+
+	inner = inner.OrderBy(orderBy...)
+	if filter.Limit > 0 {
+		inner = inner.Limit(uint64(filter.Limit))
+	}
+
+	query, args, err := r.withGenres(inner).OrderBy(orderBy...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
 
 	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
 	}
 
 	rawAlbums, err := pgx.CollectRows(rows, pgx.RowToStructByName[albumsGenreRow])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse albums data: %w", err)
+		return nil, fmt.Errorf("failed to parse albums data: %w", repo.ClassifyPgError(ctx, err))
 	}
 
 	return convertRowsToAlbums(rawAlbums), nil
 }
 
+func (r *Repository) Count(ctx context.Context, filter entity.AlbumFilter) (total int64, err error) {
+	query, args, err := applyFilter(r.pg.Builder.Select("COUNT(*)").From("albums a"), filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return total, nil
+}
+
+// applyFilter добавляет условия фильтра, пагинация и сортировка не учитываются
+func applyFilter(b squirrel.SelectBuilder, filter entity.AlbumFilter) squirrel.SelectBuilder {
+	if !filter.IncludeDeleted {
+		b = b.Where("a.deleted_at IS NULL")
+	}
+	if filter.ArtistID != 0 {
+		b = b.Where(squirrel.Eq{"a.artist_id": filter.ArtistID})
+	}
+	if filter.Artist != "" {
+		b = b.Where(squirrel.Eq{"a.artist": filter.Artist})
+	}
+	if filter.Title != "" {
+		b = b.Where(squirrel.ILike{"a.title": "%" + filter.Title + "%"})
+	}
+	if filter.MinPrice != nil {
+		b = b.Where(squirrel.GtOrEq{"a.price": filter.MinPrice.Amount})
+	}
+	if filter.MaxPrice != nil {
+		b = b.Where(squirrel.LtOrEq{"a.price": filter.MaxPrice.Amount})
+	}
+	if len(filter.GenreSubtrees) > 0 {
+		if filter.GenreMatch == entity.GenreMatchAll {
+			// альбом должен попасть в каждое поддерево
+			for _, subtree := range filter.GenreSubtrees {
+				b = b.Where("a.id IN (SELECT album_id FROM album_genres WHERE genre_id = ANY(?))", subtree)
+			}
+		} else {
+			b = b.Where("a.id IN (SELECT album_id FROM album_genres WHERE genre_id = ANY(?))", lo.Flatten(filter.GenreSubtrees))
+		}
+	} else if len(filter.GenreIDs) > 0 {
+		if filter.GenreMatch == entity.GenreMatchAll {
+			b = b.Where(
+				`a.id IN (
+					SELECT album_id FROM album_genres
+					WHERE genre_id = ANY(?)
+					GROUP BY album_id
+					HAVING COUNT(DISTINCT genre_id) = ?)`,
+				filter.GenreIDs, len(lo.Uniq(filter.GenreIDs)),
+			)
+		} else {
+			b = b.Where("a.id IN (SELECT album_id FROM album_genres WHERE genre_id = ANY(?))", filter.GenreIDs)
+		}
+	}
+	return b
+}
+
 func (r *Repository) FindById(ctx context.Context, id int64) (album entity.Album, err error) {
-	query, args, err := r.pg.Builder.
-		Select("id", "title", "artist", "price").
-		From("albums").
-		Where(squirrel.Eq{"id": id}).
-		Limit(1).
-		ToSql()
+	query, args, err := r.withGenres(
+		squirrel.Select(albumColumns...).
+			From("albums a").
+			Where(squirrel.Eq{"a.id": id}).
+			Where("a.deleted_at IS NULL"),
+	).ToSql()
 
 	if err != nil {
 		return entity.Album{}, fmt.Errorf("failed to build query: %w", err)
 	}
-	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&album.ID, &album.Title, &album.Artist, &album.Price)
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return entity.Album{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[albumsGenreRow])
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Album{}, fmt.Errorf("%w: album with id '%d' not found", repo.ErrAlbumNotFound, id)
 		}
 
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			return entity.Album{}, fmt.Errorf("%w: database error code %s: %v",
-				err, pgErr.Code, pgErr.Message)
-		}
+		return entity.Album{}, fmt.Errorf("failed to parse album data: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return row.convertRowToAlbum(), nil
+}
 
-		return entity.Album{}, fmt.Errorf("failed to execute query: %w", err)
+// Lock блокирует строку альбома до конца транзакции, чтобы параллельные изменения
+// не перезаписали друг друга. Вызывать внутри транзакции до чтения альбома.
+func (r *Repository) Lock(ctx context.Context, id int64) error {
+	query, args, err := r.pg.Builder.
+		Select("id").
+		From("albums").
+		Where(squirrel.Eq{"id": id}).
+		Where("deleted_at IS NULL").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
 	}
 
-	return album, nil
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: album with id '%d' not found", repo.ErrAlbumNotFound, id)
+		}
+		return fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return nil
 }
 
+// Delete мягко удаляет альбом: связи с жанрами, треки и SKU сохраняются до очистки
 func (r *Repository) Delete(ctx context.Context, id int64) error {
 	query, args, err := r.pg.Builder.
-		Delete("albums").
+		Update("albums").
+		Set("deleted_at", squirrel.Expr("NOW()")).
 		Where(squirrel.Eq{"id": id}).
+		Where("deleted_at IS NULL").
 		ToSql()
 
 	if err != nil {
 		return fmt.Errorf("failed to build delete query: %w", err)
 	}
 
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	// Проверяем, была ли удалена хотя бы одна запись
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: album with id %d not found", repo.ErrAlbumNotFound, id)
+	}
+
+	return nil
+}
+
+// Restore возвращает мягко удаленный альбом вместе с его связями
+func (r *Repository) Restore(ctx context.Context, id int64) error {
+	query, args, err := r.pg.Builder.
+		Update("albums").
+		Set("deleted_at", nil).
+		Where(squirrel.Eq{"id": id}).
+		Where("deleted_at IS NOT NULL").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: deleted album with id %d not found", repo.ErrAlbumNotFound, id)
+	}
+
+	return nil
+}
+
+// PurgeDeleted окончательно удаляет альбомы, удаленные раньше before
+func (r *Repository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	query, args, err := r.pg.Builder.
+		Delete("albums").
+		Where(squirrel.Lt{"deleted_at": before}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// FindSKUPrice возвращает цену самого дешевого SKU альбома в базовой валюте.
+// ok == false, если у альбома нет SKU и цена задается напрямую.
+func (r *Repository) FindSKUPrice(ctx context.Context, albumID int64) (price entity.Money, ok bool, err error) {
+	query, args, err := r.pg.Builder.
+		Select("MIN(price)").
+		From("album_skus").
+		Where(squirrel.Eq{"album_id": albumID}).
+		ToSql()
+	if err != nil {
+		return entity.Money{}, false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var amount *int64
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&amount); err != nil {
+		return entity.Money{}, false, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	if amount == nil {
+		return entity.Money{}, false, nil
+	}
+	return entity.NewMoney(*amount, entity.BaseCurrency), true, nil
+}
+
+// Update сохраняет название и артиста альбома. Цена меняется только через UpdatePrice,
+// чтобы изменение других полей не записывало цену и не попадало в историю цен.
+func (r *Repository) Update(ctx context.Context, album entity.Album) error {
+	query, args, err := r.pg.Builder.
+		Update("albums").
+		Set("title", album.Title).
+		Set("artist_id", album.ArtistID).
+		Set("artist", album.Artist).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": album.ID}).
+		Where("deleted_at IS NULL").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
 	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
-			return fmt.Errorf("%w: database error code %s: %v",
-				err, pgErr.Code, pgErr.Message)
+			if pgErr.Code == "23505" { // unique_violation
+				return fmt.Errorf("%w: album with title '%s' and artist '%s' already exists",
+					repo.ErrAlbumAlreadyExists, album.Title, album.Artist)
+			}
 		}
-		return fmt.Errorf("failed to execute delete query: %w", err)
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: album with id %d not found", repo.ErrAlbumNotFound, album.ID)
+	}
+
+	return nil
+}
+
+// UpdatePrice задает цену альбома, изменение попадает в историю цен триггером
+func (r *Repository) UpdatePrice(ctx context.Context, id int64, price entity.Money) error {
+	query, args, err := r.pg.Builder.
+		Update("albums").
+		// при наличии SKU цена альбома выводится из них, сервис не пропускает другую цену
+		Set("price", squirrel.Expr(
+			"COALESCE((SELECT MIN(s.price) FROM album_skus s WHERE s.album_id = albums.id), ?)", price.Amount,
+		)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id}).
+		Where("deleted_at IS NULL").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
 	}
 
-	// Проверяем, была ли удалена хотя бы одна запись
 	if result.RowsAffected() == 0 {
 		return fmt.Errorf("%w: album with id %d not found", repo.ErrAlbumNotFound, id)
 	}
@@ -0,0 +1,104 @@
+package albums
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+)
+
+// Релевантность: ранг полнотекстового совпадения плюс триграммная близость
+// к названию или артисту, благодаря которой находятся запросы с опечатками
+const searchRank = `(ts_rank(a.search_vector, to_tsquery('simple', ?))
+	+ GREATEST(word_similarity(?, a.title), word_similarity(?, a.artist)))`
+
+func (r *Repository) Search(ctx context.Context, search entity.AlbumSearch) ([]entity.Album, error) {
+	tsQuery := prefixQuery(search.Query)
+	rankArgs := []any{tsQuery, search.Query, search.Query}
+
+	inner := searchWhere(
+		squirrel.Select(albumColumns...).Column(searchRank+" AS rank", rankArgs...).From("albums a"),
+		search.Query, tsQuery,
+	)
+
+	ranked := squirrel.Select(append(albumColumns, "a.rank")...).FromSelect(inner, "a")
+	if search.Cursor > 0 {
+		ranked = ranked.Where(
+			"(a.rank, a.id) < (SELECT "+searchRank+", a.id FROM albums a WHERE a.id = ?)",
+			append(rankArgs, search.Cursor)...,
+		)
+	}
+	ranked = ranked.OrderBy("a.rank DESC", "a.id DESC")
+	if search.Limit > 0 {
+		ranked = ranked.Limit(uint64(search.Limit))
+	}
+
+	query, args, err := r.withGenres(ranked).
+		GroupBy("a.rank").
+		OrderBy("a.rank DESC", "a.id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	rawAlbums, err := pgx.CollectRows(rows, pgx.RowToStructByName[albumsGenreRow])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse albums data: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return convertRowsToAlbums(rawAlbums), nil
+}
+
+func (r *Repository) CountSearch(ctx context.Context, search entity.AlbumSearch) (total int64, err error) {
+	query, args, err := searchWhere(
+		r.pg.Builder.Select("COUNT(*)").From("albums a"),
+		search.Query, prefixQuery(search.Query),
+	).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return total, nil
+}
+
+// searchWhere отбирает альбомы, совпавшие по поисковому вектору или похожие по триграммам
+func searchWhere(b squirrel.SelectBuilder, q, tsQuery string) squirrel.SelectBuilder {
+	b = b.Where("a.deleted_at IS NULL")
+	fuzzy := squirrel.Or{
+		squirrel.Expr("? <% a.title", q),
+		squirrel.Expr("? <% a.artist", q),
+	}
+	if tsQuery == "" {
+		return b.Where(fuzzy)
+	}
+	return b.Where(append(squirrel.Or{
+		squirrel.Expr("a.search_vector @@ to_tsquery('simple', ?)", tsQuery),
+	}, fuzzy...))
+}
+
+// prefixQuery превращает пользовательский ввод в tsquery, где каждое слово
+// ищется по префиксу: "dark sid" -> "dark:* & sid:*"
+func prefixQuery(q string) string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
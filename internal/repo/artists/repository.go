@@ -0,0 +1,204 @@
+package artists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, artist entity.Artist) (id int64, err error) {
+	query, args, err := r.pg.Builder.
+		Insert("artists").
+		Columns("name", "bio").
+		Values(artist.Name, artist.Bio).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" { // unique_violation
+				return 0, fmt.Errorf("%w: artist '%s' already exists",
+					repo.ErrArtistAlreadyExists, artist.Name)
+			}
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: no returned id after insert", err)
+		}
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return id, nil
+}
+
+// FindOrCreate возвращает артиста с указанным именем, создавая его при отсутствии
+func (r *Repository) FindOrCreate(ctx context.Context, name string) (artist entity.Artist, err error) {
+	query, args, err := r.pg.Builder.
+		Insert("artists").
+		Columns("name").
+		Values(name).
+		Suffix("ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id, name, bio").
+		ToSql()
+	if err != nil {
+		return entity.Artist{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&artist.ID, &artist.Name, &artist.Bio)
+	if err != nil {
+		return entity.Artist{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return artist, nil
+}
+
+func (r *Repository) FindAll(ctx context.Context) (artists []entity.Artist, err error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "name", "bio").
+		From("artists").
+		OrderBy("name").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	artists = make([]entity.Artist, 0)
+	for rows.Next() {
+		var artist entity.Artist
+		if err := rows.Scan(
+			&artist.ID,
+			&artist.Name,
+			&artist.Bio,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		artists = append(artists, artist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return artists, nil
+}
+
+func (r *Repository) FindById(ctx context.Context, id int64) (artist entity.Artist, err error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "name", "bio").
+		From("artists").
+		Where(squirrel.Eq{"id": id}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return entity.Artist{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&artist.ID, &artist.Name, &artist.Bio)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Artist{}, fmt.Errorf("%w: artist with id '%d' not found", repo.ErrArtistNotFound, id)
+		}
+		return entity.Artist{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return artist, nil
+}
+
+// Update обновляет артиста и денормализованное имя артиста в его альбомах
+func (r *Repository) Update(ctx context.Context, artist entity.Artist) error {
+	query, args, err := r.pg.Builder.
+		Update("artists").
+		Set("name", artist.Name).
+		Set("bio", artist.Bio).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": artist.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return fmt.Errorf("%w: artist '%s' already exists", repo.ErrArtistAlreadyExists, artist.Name)
+		}
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: artist with id %d not found", repo.ErrArtistNotFound, artist.ID)
+	}
+
+	query, args, err = r.pg.Builder.
+		Update("albums").
+		Set("artist", artist.Name).
+		Where(squirrel.Eq{"artist_id": artist.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	query, args, err := r.pg.Builder.
+		Delete("artists").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23503" { // foreign_key_violation
+				return fmt.Errorf("%w: artist with id %d is referenced by albums",
+					repo.ErrArtistHasAlbums, id)
+			}
+		}
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: artist with id %d not found", repo.ErrArtistNotFound, id)
+	}
+
+	return nil
+}
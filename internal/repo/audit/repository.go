@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+)
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+// Write добавляет событие в журнал. Вызывается внутри транзакции изменения,
+// чтобы событие и само изменение фиксировались или откатывались вместе.
+func (r *Repository) Write(ctx context.Context, event entity.AuditEvent) error {
+	before, err := marshalSnapshot(event.Before)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot before: %w", err)
+	}
+	after, err := marshalSnapshot(event.After)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot after: %w", err)
+	}
+
+	query, args, err := r.pg.Builder.
+		Insert("audit_events").
+		Columns("actor_id", "action", "entity_type", "entity_id", "before", "after").
+		Values(event.ActorID, event.Action, event.EntityType, event.EntityID, before, after).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return nil
+}
+
+// FindAll возвращает события журнала, новые первыми
+func (r *Repository) FindAll(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEvent, error) {
+	b := r.pg.Builder.
+		Select(
+			"e.id",
+			"e.actor_id",
+			"COALESCE(u.email, '')",
+			"e.action",
+			"e.entity_type",
+			"e.entity_id",
+			"e.before",
+			"e.after",
+			"e.created_at",
+		).
+		From("audit_events e").
+		LeftJoin("users u ON u.id = e.actor_id").
+		OrderBy("e.id DESC")
+
+	if filter.EntityType != "" {
+		b = b.Where(squirrel.Eq{"e.entity_type": filter.EntityType})
+	}
+	if filter.EntityID != 0 {
+		b = b.Where(squirrel.Eq{"e.entity_id": filter.EntityID})
+	}
+	if filter.Cursor > 0 {
+		b = b.Where(squirrel.Lt{"e.id": filter.Cursor})
+	}
+	if filter.Limit > 0 {
+		b = b.Limit(uint64(filter.Limit))
+	}
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	events := make([]entity.AuditEvent, 0)
+	for rows.Next() {
+		var (
+			event         entity.AuditEvent
+			before, after []byte
+		)
+		if err := rows.Scan(
+			&event.ID,
+			&event.ActorID,
+			&event.ActorEmail,
+			&event.Action,
+			&event.EntityType,
+			&event.EntityID,
+			&before,
+			&after,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		if before != nil {
+			event.Before = json.RawMessage(before)
+		}
+		if after != nil {
+			event.After = json.RawMessage(after)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit events: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return events, nil
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/samber/lo"
+)
+
+// Снимки сущностей в журнале хранятся в snake_case, как и в ответах API.
+// Суммы записываются десятичным числом с точностью валюты.
+// Жанры альбома сохраняются только id: после изменения имена жанров сервису не известны.
+
+type albumSnapshot struct {
+	ID       int64       `json:"id"`
+	Title    string      `json:"title"`
+	ArtistID int64       `json:"artist_id"`
+	Artist   string      `json:"artist"`
+	Price    json.Number `json:"price"`
+	Currency string      `json:"currency"`
+	GenreIDs []int64     `json:"genre_ids"`
+}
+
+type genreSnapshot struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parent_id"`
+}
+
+type priceSnapshot struct {
+	Price          json.Number `json:"price"`
+	Currency       string      `json:"currency"`
+	EffectiveFrom  time.Time   `json:"effective_from"`
+	EffectiveUntil *time.Time  `json:"effective_until"`
+}
+
+type trackSnapshot struct {
+	DiscNumber int    `json:"disc_number"`
+	Position   int    `json:"position"`
+	Title      string `json:"title"`
+	Duration   int64  `json:"duration"` // в секундах
+	ISRC       string `json:"isrc,omitempty"`
+}
+
+// marshalSnapshot сериализует снимок сущности, nil остается NULL
+func marshalSnapshot(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case entity.Album:
+		return json.Marshal(albumSnapshot{
+			ID:       v.ID,
+			Title:    v.Title,
+			ArtistID: v.ArtistID,
+			Artist:   v.Artist,
+			Price:    json.Number(v.Price.Decimal()),
+			Currency: v.Price.Currency,
+			GenreIDs: lo.Map(v.Genres, func(g entity.Genre, _ int) int64 { return g.ID }),
+		})
+	case entity.Genre:
+		return json.Marshal(genreSnapshot{
+			ID:       v.ID,
+			Name:     v.Name,
+			ParentID: v.ParentID,
+		})
+	case entity.AlbumPrice:
+		return json.Marshal(priceSnapshot{
+			Price:          json.Number(v.Price.Decimal()),
+			Currency:       v.Price.Currency,
+			EffectiveFrom:  v.EffectiveFrom,
+			EffectiveUntil: v.EffectiveUntil,
+		})
+	case []entity.Track:
+		return json.Marshal(lo.Map(v, func(t entity.Track, _ int) trackSnapshot {
+			return trackSnapshot{
+				DiscNumber: t.DiscNumber,
+				Position:   t.Position,
+				Title:      t.Title,
+				Duration:   int64(t.Duration / time.Second),
+				ISRC:       t.ISRC,
+			}
+		}))
+	default:
+		return json.Marshal(v)
+	}
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalSnapshot(t *testing.T) {
+	parentID := int64(1)
+
+	for _, tc := range []struct {
+		name string
+		in   any
+		want string
+	}{
+		{
+			name: "nil",
+			in:   nil,
+			want: "",
+		},
+		{
+			name: "album",
+			in: entity.Album{
+				ID:       2,
+				Title:    "Red",
+				ArtistID: 3,
+				Artist:   "King Crimson",
+				Price:    entity.NewMoney(999, entity.BaseCurrency),
+				Genres:   []entity.Genre{{ID: 1, Name: "rock"}, {ID: 4}},
+			},
+			want: `{"id":2,"title":"Red","artist_id":3,"artist":"King Crimson","price":9.99,"currency":"USD","genre_ids":[1,4]}`,
+		},
+		{
+			name: "genre",
+			in:   entity.Genre{ID: 5, Name: "prog rock", ParentID: &parentID, AlbumCount: 10},
+			want: `{"id":5,"name":"prog rock","parent_id":1}`,
+		},
+		{
+			name: "tracks",
+			in:   []entity.Track{{ID: 7, DiscNumber: 1, Position: 1, Title: "Starless", Duration: 12*time.Minute + 18*time.Second}},
+			want: `[{"disc_number":1,"position":1,"title":"Starless","duration":738}]`,
+		},
+		{
+			name: "price",
+			in: entity.AlbumPrice{
+				ID:            8,
+				AlbumID:       2,
+				Price:         entity.NewMoney(749, "EUR"),
+				EffectiveFrom: time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC),
+			},
+			want: `{"price":7.49,"currency":"EUR","effective_from":"2025-11-28T00:00:00Z","effective_until":null}`,
+		},
+		{
+			name: "genre ids",
+			in:   []int64{1, 2},
+			want: `[1,2]`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := marshalSnapshot(tc.in)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
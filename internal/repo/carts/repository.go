@@ -0,0 +1,206 @@
+package carts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, userID int64) (id int64, err error) {
+	query, args, err := r.pg.Builder.
+		Insert("carts").
+		Columns("user_id", "created_at").
+		Values(userID, squirrel.Expr("NOW()")).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return id, nil
+}
+
+// FindById возвращает корзину с текущими данными альбомов.
+// Строка корзины блокируется, если запрос выполняется в транзакции.
+func (r *Repository) FindById(ctx context.Context, id int64) (entity.Cart, error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "user_id").
+		From("carts").
+		Where(squirrel.Eq{"id": id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Cart{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	// цены корзины выбираются в базовой валюте, пересчет - забота сервиса
+	cart := entity.Cart{Currency: entity.BaseCurrency, Items: make([]entity.CartItem, 0)}
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&cart.ID, &cart.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Cart{}, fmt.Errorf("%w: cart with id %d not found", repo.ErrCartNotFound, id)
+		}
+		return entity.Cart{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	// цена позиции - цена SKU выбранного формата
+	query, args, err = r.pg.Builder.
+		Select("ci.album_id", "ci.format", "a.title", "a.artist", "s.price", "ci.quantity").
+		From("cart_items ci").
+		Join("albums a ON a.id = ci.album_id AND a.deleted_at IS NULL").
+		Join("album_skus s ON s.album_id = ci.album_id AND s.format = ci.format").
+		Where(squirrel.Eq{"ci.cart_id": id}).
+		OrderBy("ci.album_id").
+		ToSql()
+	if err != nil {
+		return entity.Cart{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return entity.Cart{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := entity.CartItem{Price: entity.NewMoney(0, cart.Currency)}
+		if err := rows.Scan(&item.AlbumID, &item.Format, &item.Title, &item.Artist, &item.Price.Amount, &item.Quantity); err != nil {
+			return entity.Cart{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+		cart.Items = append(cart.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entity.Cart{}, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return cart, nil
+}
+
+// FindOwner возвращает владельца корзины
+func (r *Repository) FindOwner(ctx context.Context, id int64) (userID int64, err error) {
+	query, args, err := r.pg.Builder.
+		Select("user_id").
+		From("carts").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: cart with id %d not found", repo.ErrCartNotFound, id)
+		}
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return userID, nil
+}
+
+// SetItem добавляет альбом в корзину в формате format или меняет формат и количество.
+// Пустой format - самый дешевый формат альбома, по цене которого альбом показывается в каталоге.
+func (r *Repository) SetItem(ctx context.Context, cartID, albumID int64, format string, quantity int) error {
+	// удаленный альбом и альбом без SKU не попадают в выборку, и вставка не происходит
+	sku := squirrel.Select().
+		Column("?::bigint", cartID).
+		Column("s.album_id").
+		Column("s.format").
+		Column("?::int", quantity).
+		From("album_skus s").
+		Join("albums a ON a.id = s.album_id AND a.deleted_at IS NULL").
+		Where(squirrel.Eq{"s.album_id": albumID}).
+		OrderBy("s.price", "s.id").
+		Limit(1)
+	if format != "" {
+		sku = sku.Where(squirrel.Eq{"s.format": format})
+	}
+
+	query, args, err := r.pg.Builder.
+		Insert("cart_items").
+		Columns("cart_id", "album_id", "format", "quantity").
+		Select(sku).
+		Suffix("ON CONFLICT (cart_id, album_id) DO UPDATE SET format = EXCLUDED.format, quantity = EXCLUDED.quantity").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("%w: cart with id %d not found", repo.ErrCartNotFound, cartID)
+		}
+		return fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		if format != "" {
+			return fmt.Errorf("%w: album id %d, format '%s'", repo.ErrSKUNotFound, albumID, format)
+		}
+		return fmt.Errorf("%w: album with id %d not found or not for sale", repo.ErrAlbumNotFound, albumID)
+	}
+
+	return nil
+}
+
+func (r *Repository) RemoveItem(ctx context.Context, cartID, albumID int64) error {
+	query, args, err := r.pg.Builder.
+		Delete("cart_items").
+		Where(squirrel.Eq{"cart_id": cartID, "album_id": albumID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: album %d is not in cart %d", repo.ErrAlbumNotFound, albumID, cartID)
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	query, args, err := r.pg.Builder.
+		Delete("carts").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: cart with id %d not found", repo.ErrCartNotFound, id)
+	}
+
+	return nil
+}
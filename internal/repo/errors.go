@@ -5,11 +5,44 @@ import "errors"
 var (
 	ErrDatabase = errors.New("database error")
 
+	// Классифицированные ошибки postgres, см. ClassifyPgError
+	ErrDeadlock             = errors.New("deadlock detected")
+	ErrSerializationFailure = errors.New("serialization failure")
+	ErrForeignKeyViolation  = errors.New("foreign key violation")
+	ErrCheckViolation       = errors.New("check constraint violation")
+	ErrStatementTimeout     = errors.New("statement timeout")
+
 	ErrAlbumAlreadyExists = errors.New("album already exists")
 	ErrAlbumNotFound      = errors.New("album not found")
+	ErrPriceNotFound      = errors.New("album price not found")
 
 	ErrGenreNotFound               = errors.New("genre not found")
 	ErrGenreAlreadyExists          = errors.New("genre already exists")
 	ErrAddAlbumGenreConstraintFail = errors.New("cannot add constraint album_genre")
 	ErrCannotAddEmptyGenres        = errors.New("cannot add empty genres to album")
+	ErrAlbumGenreNotFound          = errors.New("album genre link not found")
+	ErrParentGenreNotFound         = errors.New("parent genre not found")
+	ErrGenreHasChildren            = errors.New("genre has subgenres")
+
+	ErrArtistNotFound      = errors.New("artist not found")
+	ErrArtistAlreadyExists = errors.New("artist already exists")
+	ErrArtistHasAlbums     = errors.New("artist has albums")
+
+	ErrDuplicateTrackPosition = errors.New("duplicate track position")
+	ErrInvalidTrack           = errors.New("invalid track")
+
+	ErrSKUNotFound       = errors.New("sku not found")
+	ErrInsufficientStock = errors.New("insufficient stock")
+
+	ErrCartNotFound        = errors.New("cart not found")
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrOrderStatusConflict = errors.New("order status was changed concurrently")
+
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+	ErrPromotionNotFound   = errors.New("promotion not found")
+	ErrPromotionCodeExists = errors.New("promotion code already exists")
+	ErrPromotionExhausted  = errors.New("promotion usage limit reached")
 )
@@ -4,13 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/4udiwe/musicshop/internal/entity"
 	"github.com/4udiwe/musicshop/internal/repo"
 	"github.com/4udiwe/musicshop/pkg/postgres"
 	"github.com/Masterminds/squirrel"
-	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/lo"
 )
 
 type Repository struct {
@@ -26,8 +28,8 @@ func New(postgres *postgres.Postgres) *Repository {
 func (r *Repository) Create(ctx context.Context, genre entity.Genre) (id int64, err error) {
 	query, args, err := r.pg.Builder.
 		Insert("genres").
-		Columns("name").
-		Values(genre.Name).
+		Columns("name", "parent_id").
+		Values(genre.Name, genre.ParentID).
 		Suffix("RETURNING id").
 		ToSql()
 	if err != nil {
@@ -42,11 +44,15 @@ func (r *Repository) Create(ctx context.Context, genre entity.Genre) (id int64,
 				return 0, fmt.Errorf("%w: genre '%s' already exists",
 					repo.ErrGenreAlreadyExists, genre.Name)
 			}
+			if pgErr.Code == "23503" { // foreign_key_violation
+				return 0, fmt.Errorf("%w: parent genre with id %d not found",
+					repo.ErrParentGenreNotFound, *genre.ParentID)
+			}
 		}
 		if errors.Is(err, pgx.ErrNoRows) {
 			return 0, fmt.Errorf("%w: no returned id after insert", err)
 		}
-		return 0, fmt.Errorf("failed to execute query: %w", err)
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
 	}
 	return id, nil
 }
@@ -55,16 +61,20 @@ func (r *Repository) AddGenresToAlbum(ctx context.Context, albumID int64, genreI
 	if len(genreIDs) < 1 {
 		return repo.ErrCannotAddEmptyGenres
 	}
+	// повторы в запросе допустимы, сравниваем с числом разных жанров
+	genreIDs = lo.Uniq(genreIDs)
 
-	builder := r.pg.Builder.
+	// удаленные жанры не привязываются и дают расхождение в числе строк
+	query, args, err := r.pg.Builder.
 		Insert("album_genres").
-		Columns("album_id", "genre_id")
-
-	for _, genreID := range genreIDs {
-		builder = builder.Values(albumID, genreID)
-	}
-
-	query, args, err := builder.ToSql()
+		Columns("album_id", "genre_id").
+		Select(squirrel.Select().
+			Column("?::bigint", albumID).
+			Column("id").
+			From("genres").
+			Where(squirrel.Eq{"id": genreIDs}).
+			Where("deleted_at IS NULL")).
+		ToSql()
 
 	if err != nil {
 		return fmt.Errorf("%w: failed to build query", err)
@@ -73,11 +83,11 @@ func (r *Repository) AddGenresToAlbum(ctx context.Context, albumID int64, genreI
 	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
+		if errors.As(err, &pgErr) && (pgErr.Code == "23503" || pgErr.Code == "23505") {
 			return fmt.Errorf("%w: database error code %s: %v",
 				repo.ErrAddAlbumGenreConstraintFail, pgErr.Code, pgErr.Message)
 		}
-		return fmt.Errorf("%w: failed to execute insert query", err)
+		return fmt.Errorf("failed to execute insert query: %w", repo.ClassifyPgError(ctx, err))
 	}
 
 	if int(result.RowsAffected()) != len(genreIDs) {
@@ -90,24 +100,184 @@ func (r *Repository) AddGenresToAlbum(ctx context.Context, albumID int64, genreI
 	return nil
 }
 
-func (r *Repository) FindAll(ctx context.Context) (genres []entity.Genre, err error) {
+// LinkGenresToAlbum идемпотентно привязывает жанры к альбому.
+// Уже привязанные и несуществующие жанры не считаются ошибкой и возвращаются в результате.
+func (r *Repository) LinkGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error) {
+	links := entity.AlbumGenreLinks{
+		Added:    []int64{},
+		Existing: []int64{},
+		Missing:  []int64{},
+	}
+
+	// FOR SHARE не даёт удалить альбом до конца транзакции
 	query, args, err := r.pg.Builder.
-		Select("id", "name").
-		From("genres").
+		Select("id").
+		From("albums").
+		Where(squirrel.Eq{"id": albumID}).
+		Where("deleted_at IS NULL").
+		Suffix("FOR SHARE").
+		ToSql()
+	if err != nil {
+		return links, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var id int64
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return links, fmt.Errorf("%w: album with id %d not found", repo.ErrAlbumNotFound, albumID)
+		}
+		return links, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if len(genreIDs) == 0 {
+		return links, nil
+	}
+
+	known, err := r.existingGenreIDs(ctx, genreIDs)
+	if err != nil {
+		return links, err
+	}
+	links.Missing = lo.Filter(genreIDs, func(id int64, _ int) bool {
+		return !lo.Contains(known, id)
+	})
+	if len(known) == 0 {
+		return links, nil
+	}
+
+	builder := r.pg.Builder.
+		Insert("album_genres").
+		Columns("album_id", "genre_id")
+	for _, genreID := range known {
+		builder = builder.Values(albumID, genreID)
+	}
+
+	query, args, err = builder.
+		Suffix("ON CONFLICT DO NOTHING RETURNING genre_id").
+		ToSql()
+	if err != nil {
+		return links, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return links, fmt.Errorf("failed to execute insert query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	added, err := scanIDs(ctx, rows)
+	if err != nil {
+		return links, err
+	}
+
+	links.Added = lo.Filter(known, func(id int64, _ int) bool {
+		return lo.Contains(added, id)
+	})
+	links.Existing = lo.Filter(known, func(id int64, _ int) bool {
+		return !lo.Contains(added, id)
+	})
+
+	return links, nil
+}
+
+// UnlinkGenreFromAlbum отвязывает один жанр от альбома
+func (r *Repository) UnlinkGenreFromAlbum(ctx context.Context, albumID, genreID int64) error {
+	query, args, err := r.pg.Builder.
+		Delete("album_genres").
+		Where(squirrel.Eq{"album_id": albumID, "genre_id": genreID}).
 		ToSql()
 
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: album %d has no genre %d", repo.ErrAlbumGenreNotFound, albumID, genreID)
+	}
+
+	return nil
+}
+
+// RetainAlbumGenres отвязывает от альбома все жанры, кроме переданных, и возвращает отвязанные
+func (r *Repository) RetainAlbumGenres(ctx context.Context, albumID int64, genreIDs ...int64) ([]int64, error) {
+	builder := r.pg.Builder.
+		Delete("album_genres").
+		Where(squirrel.Eq{"album_id": albumID})
+	if len(genreIDs) > 0 {
+		builder = builder.Where(squirrel.NotEq{"genre_id": genreIDs})
+	}
+
+	query, args, err := builder.
+		Suffix("RETURNING genre_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return scanIDs(ctx, rows)
+}
+
+func (r *Repository) existingGenreIDs(ctx context.Context, genreIDs []int64) ([]int64, error) {
+	query, args, err := r.pg.Builder.
+		Select("id").
+		From("genres").
+		Where(squirrel.Eq{"id": genreIDs}).
+		Where("deleted_at IS NULL").
+		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
 	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			return nil, fmt.Errorf("%w: database error code %s: %v",
-				err, pgErr.Code, pgErr.Message)
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return scanIDs(ctx, rows)
+}
+
+func scanIDs(ctx context.Context, rows pgx.Rows) ([]int64, error) {
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return ids, nil
+}
+
+// FindAll возвращает жанры с количеством альбомов в каждом, includeDeleted добавляет удаленные жанры
+func (r *Repository) FindAll(ctx context.Context, includeDeleted bool) (genres []entity.Genre, err error) {
+	builder := r.withAlbumCount()
+	if !includeDeleted {
+		builder = builder.Where("g.deleted_at IS NULL")
+	}
+
+	query, args, err := builder.
+		OrderBy("g.id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
 	}
 	defer rows.Close()
 
@@ -117,6 +287,9 @@ func (r *Repository) FindAll(ctx context.Context) (genres []entity.Genre, err er
 		if err := rows.Scan(
 			&genre.ID,
 			&genre.Name,
+			&genre.ParentID,
+			&genre.AlbumCount,
+			&genre.DeletedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -124,7 +297,7 @@ func (r *Repository) FindAll(ctx context.Context) (genres []entity.Genre, err er
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %w", err)
+		return nil, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
 	}
 
 	if len(genres) == 0 {
@@ -134,10 +307,89 @@ func (r *Repository) FindAll(ctx context.Context) (genres []entity.Genre, err er
 	return genres, nil
 }
 
+func (r *Repository) FindById(ctx context.Context, id int64) (genre entity.Genre, err error) {
+	query, args, err := r.withAlbumCount().
+		Where(squirrel.Eq{"g.id": id}).
+		Where("g.deleted_at IS NULL").
+		ToSql()
+
+	if err != nil {
+		return entity.Genre{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&genre.ID, &genre.Name, &genre.ParentID, &genre.AlbumCount, &genre.DeletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Genre{}, fmt.Errorf("%w: genre with id '%d' not found", repo.ErrGenreNotFound, id)
+		}
+		return entity.Genre{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return genre, nil
+}
+
+func (r *Repository) Update(ctx context.Context, genre entity.Genre) error {
+	query, args, err := r.pg.Builder.
+		Update("genres").
+		Set("name", genre.Name).
+		Set("parent_id", genre.ParentID).
+		Where(squirrel.Eq{"id": genre.ID}).
+		Where("deleted_at IS NULL").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505": // unique_violation
+				return fmt.Errorf("%w: genre '%s' already exists", repo.ErrGenreAlreadyExists, genre.Name)
+			case "23503": // foreign_key_violation
+				return fmt.Errorf("%w: parent genre with id %d not found", repo.ErrParentGenreNotFound, *genre.ParentID)
+			}
+		}
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: genre with id %d not found", repo.ErrGenreNotFound, genre.ID)
+	}
+
+	return nil
+}
+
+// Delete мягко удаляет жанр без поджанров, связи с альбомами сохраняются до очистки
 func (r *Repository) Delete(ctx context.Context, id int64) error {
 	query, args, err := r.pg.Builder.
-		Delete("genres").
+		Select("1").
+		From("genres").
+		Where(squirrel.Eq{"parent_id": id}).
+		Where("deleted_at IS NULL").
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var hasChildren bool
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&hasChildren); err != nil {
+		return fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	if hasChildren {
+		return fmt.Errorf("%w: genre with id %d has subgenres", repo.ErrGenreHasChildren, id)
+	}
+
+	query, args, err = r.pg.Builder.
+		Update("genres").
+		Set("deleted_at", squirrel.Expr("NOW()")).
 		Where(squirrel.Eq{"id": id}).
+		Where("deleted_at IS NULL").
 		ToSql()
 
 	if err != nil {
@@ -145,18 +397,166 @@ func (r *Repository) Delete(ctx context.Context, id int64) error {
 	}
 
 	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: genre with id %d not found", repo.ErrGenreNotFound, id)
+	}
+
+	return nil
+}
+
+// subtreeQuery выбирает id неудаленного жанра и всех его неудаленных потомков.
+// UNION вместо UNION ALL гарантирует завершение даже при цикле в данных.
+const subtreeQuery = `
+	WITH RECURSIVE subtree AS (
+		SELECT id FROM genres WHERE id = $1 AND deleted_at IS NULL
+		UNION
+		SELECT g.id FROM genres g JOIN subtree s ON g.parent_id = s.id WHERE g.deleted_at IS NULL
+	)
+	SELECT id FROM subtree`
+
+// FindSubtreeIDs возвращает id жанра и всех его поджанров, пустой список - если жанра нет
+func (r *Repository) FindSubtreeIDs(ctx context.Context, id int64) ([]int64, error) {
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, subtreeQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return scanIDs(ctx, rows)
+}
+
+// ReparentChildren переносит прямых потомков жанра к его родителю
+func (r *Repository) ReparentChildren(ctx context.Context, id int64) error {
+	query, args, err := r.pg.Builder.
+		Update("genres").
+		Set("parent_id", squirrel.Expr("(SELECT parent_id FROM genres WHERE id = ?)", id)).
+		Where(squirrel.Eq{"parent_id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return nil
+}
+
+// DeleteSubtree мягко удаляет жанр вместе со всеми поджанрами.
+// Все жанры поддерева получают одинаковый deleted_at, по которому их находит Restore.
+func (r *Repository) DeleteSubtree(ctx context.Context, id int64) error {
+	query := "UPDATE genres SET deleted_at = NOW() WHERE id IN (" + subtreeQuery + ")"
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: genre with id %d not found", repo.ErrGenreNotFound, id)
+	}
+
+	return nil
+}
+
+// restoreQuery восстанавливает жанр и поджанры, удаленные вместе с ним
+const restoreQuery = `
+	WITH RECURSIVE subtree AS (
+		SELECT id, deleted_at FROM genres WHERE id = $1 AND deleted_at IS NOT NULL
+		UNION
+		SELECT g.id, g.deleted_at FROM genres g JOIN subtree s ON g.parent_id = s.id
+		WHERE g.deleted_at = s.deleted_at
+	)
+	UPDATE genres SET deleted_at = NULL WHERE id IN (SELECT id FROM subtree)`
+
+// Restore возвращает мягко удаленный жанр вместе со связями и поджанрами,
+// удаленными каскадно в тот же момент
+func (r *Repository) Restore(ctx context.Context, id int64) error {
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, restoreQuery, id)
 	if err != nil {
 		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			return fmt.Errorf("%w: database error code %s: %v",
-				err, pgErr.Code, pgErr.Message)
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return fmt.Errorf("%w: name of genre %d is taken by another genre", repo.ErrGenreAlreadyExists, id)
 		}
-		return fmt.Errorf("failed to execute delete query: %w", err)
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("%w: genre with id %d not found", repo.ErrGenreNotFound, id)
+		return fmt.Errorf("%w: deleted genre with id %d not found", repo.ErrGenreNotFound, id)
 	}
 
 	return nil
 }
+
+// PurgeDeleted окончательно удаляет жанры, удаленные раньше before.
+// Оставшиеся поджанры удаляемых жанров становятся корневыми. Вызывается в транзакции.
+func (r *Repository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	purged := squirrel.Select("id").From("genres").Where(squirrel.Lt{"deleted_at": before})
+
+	query, args, err := r.pg.Builder.
+		Update("genres").
+		Set("parent_id", nil).
+		Where(squirrel.Expr("parent_id IN (?)", purged)).
+		Where(squirrel.Or{
+			squirrel.Eq{"deleted_at": nil},
+			squirrel.GtOrEq{"deleted_at": before},
+		}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	query, args, err = r.pg.Builder.
+		Delete("genres").
+		Where(squirrel.Lt{"deleted_at": before}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *Repository) RemoveGenresFromAlbum(ctx context.Context, albumID int64) error {
+	query, args, err := r.pg.Builder.
+		Delete("album_genres").
+		Where(squirrel.Eq{"album_id": albumID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	_, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return nil
+}
+
+// withAlbumCount выбирает жанры вместе с количеством привязанных альбомов
+func (r *Repository) withAlbumCount() squirrel.SelectBuilder {
+	return r.pg.Builder.
+		Select("g.id", "g.name", "g.parent_id", "COUNT(a.id)", "g.deleted_at").
+		From("genres g").
+		LeftJoin("album_genres ag ON ag.genre_id = g.id").
+		LeftJoin("albums a ON a.id = ag.album_id AND a.deleted_at IS NULL").
+		GroupBy("g.id")
+}
@@ -0,0 +1,139 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+// Create сохраняет заказ вместе с позициями, вызывать внутри транзакции
+func (r *Repository) Create(ctx context.Context, order entity.Order) (id int64, err error) {
+	query, args, err := r.pg.Builder.
+		Insert("orders").
+		Columns("user_id", "status", "currency", "promo_code").
+		Values(order.UserID, order.Status, order.Currency, order.PromoCode).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if len(order.Items) == 0 {
+		return id, nil
+	}
+
+	builder := r.pg.Builder.
+		Insert("order_items").
+		Columns("order_id", "album_id", "format", "title", "artist", "price", "quantity", "discount")
+
+	for _, item := range order.Items {
+		builder = builder.Values(id, item.AlbumID, item.Format, item.Title, item.Artist, item.Price.Amount, item.Quantity, item.Discount.Amount)
+	}
+
+	query, args, err = builder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return id, nil
+}
+
+func (r *Repository) FindById(ctx context.Context, id int64) (entity.Order, error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "user_id", "status", "currency", "promo_code", "created_at", "updated_at").
+		From("orders").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var order entity.Order
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).
+		Scan(&order.ID, &order.UserID, &order.Status, &order.Currency, &order.PromoCode, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Order{}, fmt.Errorf("%w: order with id %d not found", repo.ErrOrderNotFound, id)
+		}
+		return entity.Order{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	query, args, err = r.pg.Builder.
+		Select("COALESCE(album_id, 0)", "COALESCE(format, '')", "title", "artist", "price", "quantity", "discount").
+		From("order_items").
+		Where(squirrel.Eq{"order_id": id}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	order.Items = make([]entity.OrderItem, 0)
+	for rows.Next() {
+		item := entity.OrderItem{Price: entity.NewMoney(0, order.Currency), Discount: entity.NewMoney(0, order.Currency)}
+		if err := rows.Scan(&item.AlbumID, &item.Format, &item.Title, &item.Artist, &item.Price.Amount, &item.Quantity, &item.Discount.Amount); err != nil {
+			return entity.Order{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+		order.Items = append(order.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entity.Order{}, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return order, nil
+}
+
+// UpdateStatus меняет статус заказа, только если текущий статус равен from
+func (r *Repository) UpdateStatus(ctx context.Context, id int64, from, to entity.OrderStatus) error {
+	query, args, err := r.pg.Builder.
+		Update("orders").
+		Set("status", to).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id, "status": from}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: order %d is no longer %s", repo.ErrOrderStatusConflict, id, from)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/logger"
+	"github.com/jackc/pgx/v5/pgconn"
+	log "github.com/sirupsen/logrus"
+)
+
+var pgErrorClasses = map[string]error{
+	"40P01": ErrDeadlock,             // deadlock_detected
+	"40001": ErrSerializationFailure, // serialization_failure
+	"23503": ErrForeignKeyViolation,  // foreign_key_violation
+	"23514": ErrCheckViolation,       // check_violation
+	"57014": ErrStatementTimeout,     // query_canceled, в т.ч. по statement_timeout
+}
+
+// ClassifyPgError оборачивает ошибку базы в одну из ошибок repo, сохраняя исходную
+// ошибку в цепочке, и пишет её в логгер запроса. Неизвестные ошибки оборачиваются в ErrDatabase.
+func ClassifyPgError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	class := ErrDatabase
+	fields := log.Fields{"error": err.Error()}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		fields["pg_code"] = pgErr.Code
+		if c, ok := pgErrorClasses[pgErr.Code]; ok {
+			class = c
+		}
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		class = ErrStatementTimeout
+	}
+	logger.FromContext(ctx).WithFields(fields).Error(class.Error())
+
+	return fmt.Errorf("%w: %w", class, err)
+}
@@ -0,0 +1,56 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/logger"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/jackc/pgx/v5/pgconn"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyPgError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, want: repo.ErrDeadlock},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, want: repo.ErrSerializationFailure},
+		{name: "foreign key violation", err: &pgconn.PgError{Code: "23503"}, want: repo.ErrForeignKeyViolation},
+		{name: "check violation", err: &pgconn.PgError{Code: "23514"}, want: repo.ErrCheckViolation},
+		{name: "statement timeout", err: &pgconn.PgError{Code: "57014"}, want: repo.ErrStatementTimeout},
+		{name: "context deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: repo.ErrStatementTimeout},
+		{name: "unknown pg error", err: &pgconn.PgError{Code: "42P01"}, want: repo.ErrDatabase},
+		{name: "other error", err: errors.New("connection reset"), want: repo.ErrDatabase},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := repo.ClassifyPgError(context.Background(), tc.err)
+
+			assert.ErrorIs(t, got, tc.want)
+			assert.ErrorIs(t, got, tc.err)
+		})
+	}
+
+	assert.NoError(t, repo.ClassifyPgError(context.Background(), nil))
+}
+
+func TestClassifyPgErrorLogsWithRequestLogger(t *testing.T) {
+	hook := test.NewLocal(log.StandardLogger())
+	defer log.StandardLogger().ReplaceHooks(make(log.LevelHooks))
+	ctx := logger.WithFields(context.Background(), log.Fields{"request_id": "req-1"})
+
+	_ = repo.ClassifyPgError(ctx, &pgconn.PgError{Code: "40P01"})
+
+	entry := hook.LastEntry()
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, "req-1", entry.Data["request_id"])
+		assert.Equal(t, "40P01", entry.Data["pg_code"])
+		assert.Equal(t, repo.ErrDeadlock.Error(), entry.Message)
+	}
+}
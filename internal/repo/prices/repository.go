@@ -0,0 +1,179 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+)
+
+var priceColumns = []string{"id", "album_id", "currency", "price", "effective_from", "effective_until", "created_at"}
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+// scheduleQuery планирует цену, нулевой момент начала заменяется текущим временем
+const scheduleQuery = `
+	SELECT id, album_id, currency, price, effective_from, effective_until, created_at
+	FROM schedule_album_price($1, $2, $3, COALESCE($4::timestamptz, NOW()))`
+
+// Schedule устанавливает цену альбома в ее валюте с момента from, нулевой from - с текущего момента.
+// Периоды соседних цен пересчитываются функцией schedule_album_price.
+func (r *Repository) Schedule(ctx context.Context, albumID int64, price entity.Money, from time.Time) (entity.AlbumPrice, error) {
+	var effectiveFrom *time.Time
+	if !from.IsZero() {
+		effectiveFrom = &from
+	}
+
+	p, err := r.scanOne(ctx, scheduleQuery, albumID, price.Currency, price.Amount, effectiveFrom)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return entity.AlbumPrice{}, fmt.Errorf("%w: album with id '%d' not found", repo.ErrAlbumNotFound, albumID)
+		}
+		return entity.AlbumPrice{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return p, nil
+}
+
+// applyDueQuery переносит наступившие цены в базовой валюте в albums.price. Триггер истории
+// не планирует их повторно: новая цена совпадает с действующей по истории.
+// Цену альбома с SKU задают SKU, ее не трогаем.
+const applyDueQuery = `
+	UPDATE albums a
+	SET price = p.price
+	FROM album_prices p
+	WHERE p.album_id = a.id
+		AND p.currency = $1
+		AND p.effective_from <= NOW()
+		AND (p.effective_until IS NULL OR p.effective_until > NOW())
+		AND a.price <> p.price
+		AND NOT EXISTS (SELECT 1 FROM album_skus s WHERE s.album_id = a.id)`
+
+// ApplyDue выставляет albums.price равной действующей цене альбома, возвращает число
+// обновленных альбомов. Нужна, чтобы фильтры и сортировка по индексу видели запланированные цены.
+func (r *Repository) ApplyDue(ctx context.Context) (int64, error) {
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, applyDueQuery, entity.BaseCurrency)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return result.RowsAffected(), nil
+}
+
+// FindByAlbumID возвращает историю и запланированные цены альбома во всех валютах
+// в порядке действия
+func (r *Repository) FindByAlbumID(ctx context.Context, albumID int64) ([]entity.AlbumPrice, error) {
+	query, args, err := r.pg.Builder.
+		Select(priceColumns...).
+		From("album_prices").
+		Where(squirrel.Eq{"album_id": albumID}).
+		OrderBy("currency", "effective_from").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	prices := make([]entity.AlbumPrice, 0)
+	for rows.Next() {
+		var p entity.AlbumPrice
+		if err := rows.Scan(&p.ID, &p.AlbumID, &p.Price.Currency, &p.Price.Amount, &p.EffectiveFrom, &p.EffectiveUntil, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", repo.ClassifyPgError(ctx, err))
+		}
+		prices = append(prices, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return prices, nil
+}
+
+// FindAt возвращает цену в валюте currency, действовавшую в момент at
+func (r *Repository) FindAt(ctx context.Context, albumID int64, currency string, at time.Time) (entity.AlbumPrice, error) {
+	query, args, err := r.pg.Builder.
+		Select(priceColumns...).
+		From("album_prices").
+		Where(squirrel.Eq{"album_id": albumID, "currency": currency}).
+		Where(squirrel.LtOrEq{"effective_from": at}).
+		OrderBy("effective_from DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return entity.AlbumPrice{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	p, err := r.scanOne(ctx, query, args...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.AlbumPrice{}, fmt.Errorf("%w: album id %d in %s at %s",
+				repo.ErrPriceNotFound, albumID, currency, at.Format(time.RFC3339))
+		}
+		return entity.AlbumPrice{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return p, nil
+}
+
+func (r *Repository) scanOne(ctx context.Context, query string, args ...any) (p entity.AlbumPrice, err error) {
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).
+		Scan(&p.ID, &p.AlbumID, &p.Price.Currency, &p.Price.Amount, &p.EffectiveFrom, &p.EffectiveUntil, &p.CreatedAt)
+	return p, err
+}
+
+// FindCurrent возвращает действующие цены альбомов, явно заданные в валюте currency.
+// Альбомов без цены в этой валюте в результате нет. Альбомов с SKU тоже нет: цена альбома
+// одна на все форматы, а каждый формат продается по цене своего SKU.
+func (r *Repository) FindCurrent(ctx context.Context, albumIDs []int64, currency string) (map[int64]entity.Money, error) {
+	query, args, err := r.pg.Builder.
+		Select("album_id", "price").
+		Options("DISTINCT ON (album_id)").
+		From("album_prices p").
+		Where(squirrel.Eq{"album_id": albumIDs, "currency": currency}).
+		Where("effective_from <= NOW()").
+		Where("NOT EXISTS (SELECT 1 FROM album_skus s WHERE s.album_id = p.album_id)").
+		OrderBy("album_id", "effective_from DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	prices := make(map[int64]entity.Money, len(albumIDs))
+	for rows.Next() {
+		var albumID, amount int64
+		if err := rows.Scan(&albumID, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", repo.ClassifyPgError(ctx, err))
+		}
+		prices[albumID] = entity.NewMoney(amount, currency)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,194 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+)
+
+var promotionColumns = []string{
+	"id", "name", "code", "kind", "percent", "amount", "currency", "buy_quantity", "free_quantity",
+	"target", "target_ids", "starts_at", "ends_at", "usage_limit", "usage_count", "created_at",
+}
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, p entity.Promotion) (entity.Promotion, error) {
+	query, args, err := r.pg.Builder.
+		Insert("promotions").
+		Columns("name", "code", "kind", "percent", "amount", "currency", "buy_quantity", "free_quantity",
+			"target", "target_ids", "starts_at", "ends_at", "usage_limit").
+		Values(p.Name, p.Code, p.Kind, p.Percent, p.Amount.Amount, p.Amount.Currency, p.BuyQuantity, p.FreeQuantity,
+			p.Target, p.TargetIDs, p.StartsAt, p.EndsAt, p.UsageLimit).
+		Suffix("RETURNING id, usage_count, created_at").
+		ToSql()
+	if err != nil {
+		return entity.Promotion{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&p.ID, &p.UsageCount, &p.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return entity.Promotion{}, fmt.Errorf("%w: code '%s'", repo.ErrPromotionCodeExists, *p.Code)
+		}
+		return entity.Promotion{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return p, nil
+}
+
+// FindAll возвращает все акции, сначала новые
+func (r *Repository) FindAll(ctx context.Context) ([]entity.Promotion, error) {
+	query, args, err := r.pg.Builder.
+		Select(promotionColumns...).
+		From("promotions").
+		OrderBy("id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	return r.scanAll(ctx, query, args...)
+}
+
+// FindByCode возвращает акцию по коду независимо от срока действия
+func (r *Repository) FindByCode(ctx context.Context, code string) (entity.Promotion, error) {
+	query, args, err := r.pg.Builder.
+		Select(promotionColumns...).
+		From("promotions").
+		Where(squirrel.Eq{"code": code}).
+		ToSql()
+	if err != nil {
+		return entity.Promotion{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	promotions, err := r.scanAll(ctx, query, args...)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+	if len(promotions) == 0 {
+		return entity.Promotion{}, fmt.Errorf("%w: code '%s'", repo.ErrPromotionNotFound, code)
+	}
+	return promotions[0], nil
+}
+
+// FindAutomatic возвращает акции без кода, действующие в момент at
+func (r *Repository) FindAutomatic(ctx context.Context, at time.Time) ([]entity.Promotion, error) {
+	query, args, err := r.pg.Builder.
+		Select(promotionColumns...).
+		From("promotions").
+		Where(squirrel.Eq{"code": nil}).
+		Where(squirrel.LtOrEq{"starts_at": at}).
+		Where(squirrel.Or{squirrel.Eq{"ends_at": nil}, squirrel.Gt{"ends_at": at}}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	return r.scanAll(ctx, query, args...)
+}
+
+// albumTagsQuery выбирает артиста и жанры альбомов вместе со всеми предками жанров,
+// чтобы акция на жанр распространялась и на поджанры
+const albumTagsQuery = `
+	WITH RECURSIVE tree AS (
+		SELECT ag.album_id, g.id, g.parent_id
+		FROM album_genres ag
+		JOIN genres g ON g.id = ag.genre_id AND g.deleted_at IS NULL
+		WHERE ag.album_id = ANY($1)
+		UNION
+		SELECT t.album_id, g.id, g.parent_id
+		FROM genres g JOIN tree t ON g.id = t.parent_id
+		WHERE g.deleted_at IS NULL
+	)
+	SELECT a.id, a.artist_id, COALESCE(ARRAY_AGG(DISTINCT t.id ORDER BY t.id) FILTER (WHERE t.id IS NOT NULL), '{}')
+	FROM albums a
+	LEFT JOIN tree t ON t.album_id = a.id
+	WHERE a.id = ANY($1)
+	GROUP BY a.id, a.artist_id`
+
+// FindAlbumTags возвращает для альбомов строки расчета без цены и количества
+func (r *Repository) FindAlbumTags(ctx context.Context, albumIDs []int64) (map[int64]entity.PricingLine, error) {
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, albumTagsQuery, albumIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	tags := make(map[int64]entity.PricingLine, len(albumIDs))
+	for rows.Next() {
+		var line entity.PricingLine
+		if err := rows.Scan(&line.AlbumID, &line.ArtistID, &line.GenreIDs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", repo.ClassifyPgError(ctx, err))
+		}
+		tags[line.AlbumID] = line
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return tags, nil
+}
+
+// Redeem засчитывает использование акции. Проверка лимита и увеличение счетчика
+// выполняются одним запросом, поэтому лимит не превышается при одновременных заказах.
+func (r *Repository) Redeem(ctx context.Context, id int64) error {
+	query, args, err := r.pg.Builder.
+		Update("promotions").
+		Set("usage_count", squirrel.Expr("usage_count + 1")).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Or{squirrel.Eq{"usage_limit": nil}, squirrel.Expr("usage_count < usage_limit")}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	result, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute update query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: promotion %d", repo.ErrPromotionExhausted, id)
+	}
+
+	return nil
+}
+
+func (r *Repository) scanAll(ctx context.Context, query string, args ...any) ([]entity.Promotion, error) {
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	promotions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (p entity.Promotion, err error) {
+		err = row.Scan(&p.ID, &p.Name, &p.Code, &p.Kind, &p.Percent, &p.Amount.Amount, &p.Amount.Currency,
+			&p.BuyQuantity, &p.FreeQuantity, &p.Target, &p.TargetIDs, &p.StartsAt, &p.EndsAt,
+			&p.UsageLimit, &p.UsageCount, &p.CreatedAt)
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan rows: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return promotions, nil
+}
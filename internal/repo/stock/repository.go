@@ -0,0 +1,176 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+// UpsertSKU создает позицию альбома в формате или обновляет ее цену
+func (r *Repository) UpsertSKU(ctx context.Context, sku entity.SKU) (entity.SKU, error) {
+	query, args, err := r.pg.Builder.
+		Insert("album_skus").
+		Columns("album_id", "format", "price").
+		Values(sku.AlbumID, sku.Format, sku.Price.Amount).
+		Suffix(`ON CONFLICT (album_id, format) DO UPDATE
+			SET price = EXCLUDED.price, updated_at = NOW()
+			RETURNING id, album_id, format, price, quantity`).
+		ToSql()
+	if err != nil {
+		return entity.SKU{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	// цены SKU хранятся в базовой валюте
+	out := entity.SKU{Price: entity.NewMoney(0, entity.BaseCurrency)}
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).
+		Scan(&out.ID, &out.AlbumID, &out.Format, &out.Price.Amount, &out.Quantity)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return entity.SKU{}, fmt.Errorf("%w: album with id %d not found", repo.ErrAlbumNotFound, sku.AlbumID)
+		}
+		return entity.SKU{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return out, nil
+}
+
+func (r *Repository) FindSKUs(ctx context.Context, albumID int64) ([]entity.SKU, error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "album_id", "format", "price", "quantity").
+		From("album_skus").
+		Where(squirrel.Eq{"album_id": albumID}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	skus := make([]entity.SKU, 0)
+	for rows.Next() {
+		sku := entity.SKU{Price: entity.NewMoney(0, entity.BaseCurrency)}
+		if err := rows.Scan(&sku.ID, &sku.AlbumID, &sku.Format, &sku.Price.Amount, &sku.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		skus = append(skus, sku)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return skus, nil
+}
+
+// FindSKU возвращает позицию и блокирует ее строку до конца транзакции
+func (r *Repository) FindSKU(ctx context.Context, albumID int64, format string) (sku entity.SKU, err error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "album_id", "format", "price", "quantity").
+		From("album_skus").
+		Where(squirrel.Eq{"album_id": albumID, "format": format}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.SKU{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).
+		Scan(&sku.ID, &sku.AlbumID, &sku.Format, &sku.Price.Amount, &sku.Quantity)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.SKU{}, fmt.Errorf("%w: album id %d, format '%s'", repo.ErrSKUNotFound, albumID, format)
+		}
+		return entity.SKU{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	sku.Price.Currency = entity.BaseCurrency
+	return sku, nil
+}
+
+// ChangeQuantity изменяет остаток позиции на delta и возвращает новый остаток
+func (r *Repository) ChangeQuantity(ctx context.Context, skuID int64, delta int) (quantity int, err error) {
+	query, args, err := r.pg.Builder.
+		Update("album_skus").
+		Set("quantity", squirrel.Expr("quantity + ?", delta)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": skuID}).
+		Suffix("RETURNING quantity").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&quantity)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: sku with id %d", repo.ErrSKUNotFound, skuID)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23514" { // check_violation
+			return 0, fmt.Errorf("%w: sku with id %d, delta %d", repo.ErrInsufficientStock, skuID, delta)
+		}
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return quantity, nil
+}
+
+func (r *Repository) AddMovement(ctx context.Context, movement entity.StockMovement) error {
+	query, args, err := r.pg.Builder.
+		Insert("stock_movements").
+		Columns("sku_id", "delta", "reason").
+		Values(movement.SKUID, movement.Delta, movement.Reason).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return nil
+}
+
+// SyncAlbumPrice выставляет цену альбома равной минимальной цене его SKU
+func (r *Repository) SyncAlbumPrice(ctx context.Context, albumID int64) error {
+	query, args, err := r.pg.Builder.
+		Update("albums").
+		Set("price", squirrel.Expr("(SELECT MIN(s.price) FROM album_skus s WHERE s.album_id = albums.id)")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": albumID}).
+		Where("EXISTS (SELECT 1 FROM album_skus s WHERE s.album_id = albums.id)").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return nil
+}
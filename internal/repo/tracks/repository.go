@@ -0,0 +1,128 @@
+package tracks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, albumID int64, tracks ...entity.Track) error {
+	if len(tracks) == 0 {
+		return nil
+	}
+
+	builder := r.pg.Builder.
+		Insert("tracks").
+		Columns("album_id", "disc_number", "position", "title", "duration_seconds", "isrc")
+
+	for _, t := range tracks {
+		var isrc *string
+		if t.ISRC != "" {
+			isrc = &t.ISRC
+		}
+		builder = builder.Values(albumID, t.DiscNumber, t.Position, t.Title, int(t.Duration/time.Second), isrc)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505": // unique_violation
+				return fmt.Errorf("%w: album id %d: %v", repo.ErrDuplicateTrackPosition, albumID, pgErr.Detail)
+			case "23514": // check_violation
+				return fmt.Errorf("%w: album id %d: %v", repo.ErrInvalidTrack, albumID, pgErr.ConstraintName)
+			case "23503": // foreign_key_violation
+				return fmt.Errorf("%w: album with id %d not found", repo.ErrAlbumNotFound, albumID)
+			}
+		}
+		return fmt.Errorf("failed to execute insert query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return nil
+}
+
+func (r *Repository) FindByAlbumID(ctx context.Context, albumID int64) ([]entity.Track, error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "album_id", "disc_number", "position", "title", "duration_seconds", "COALESCE(isrc, '')").
+		From("tracks").
+		Where(squirrel.Eq{"album_id": albumID}).
+		OrderBy("disc_number", "position").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.pg.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	defer rows.Close()
+
+	tracks := make([]entity.Track, 0)
+	for rows.Next() {
+		var (
+			track   entity.Track
+			seconds int
+		)
+		if err := rows.Scan(
+			&track.ID,
+			&track.AlbumID,
+			&track.DiscNumber,
+			&track.Position,
+			&track.Title,
+			&seconds,
+			&track.ISRC,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		track.Duration = time.Duration(seconds) * time.Second
+		tracks = append(tracks, track)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return tracks, nil
+}
+
+func (r *Repository) DeleteByAlbumID(ctx context.Context, albumID int64) error {
+	query, args, err := r.pg.Builder.
+		Delete("tracks").
+		Where(squirrel.Eq{"album_id": albumID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute delete query: %w", repo.ClassifyPgError(ctx, err))
+	}
+
+	return nil
+}
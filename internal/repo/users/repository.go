@@ -0,0 +1,139 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	pg *postgres.Postgres
+}
+
+func New(postgres *postgres.Postgres) *Repository {
+	return &Repository{
+		pg: postgres,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, user entity.User) (id int64, err error) {
+	query, args, err := r.pg.Builder.
+		Insert("users").
+		Columns("email", "password_hash", "role").
+		Values(user.Email, user.PasswordHash, user.Role).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" { // unique_violation
+				return 0, fmt.Errorf("%w: user '%s' already exists",
+					repo.ErrUserAlreadyExists, user.Email)
+			}
+		}
+		return 0, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return id, nil
+}
+
+func (r *Repository) FindByEmail(ctx context.Context, email string) (entity.User, error) {
+	return r.findOne(ctx, squirrel.Eq{"email": email})
+}
+
+func (r *Repository) FindById(ctx context.Context, id int64) (entity.User, error) {
+	return r.findOne(ctx, squirrel.Eq{"id": id})
+}
+
+func (r *Repository) findOne(ctx context.Context, where squirrel.Eq) (user entity.User, err error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "email", "password_hash", "role", "created_at").
+		From("users").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return entity.User{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).
+		Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.User{}, repo.ErrUserNotFound
+		}
+		return entity.User{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return user, nil
+}
+
+func (r *Repository) CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	query, args, err := r.pg.Builder.
+		Insert("refresh_tokens").
+		Columns("user_id", "token_hash", "expires_at").
+		Values(token.UserID, token.TokenHash, token.ExpiresAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err = r.pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return nil
+}
+
+// FindRefreshToken ищет токен по хэшу и блокирует его строку до конца транзакции,
+// чтобы один токен нельзя было обменять дважды
+func (r *Repository) FindRefreshToken(ctx context.Context, hash string) (token entity.RefreshToken, err error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "user_id", "token_hash", "expires_at", "revoked_at").
+		From("refresh_tokens").
+		Where(squirrel.Eq{"token_hash": hash}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.RefreshToken{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = r.pg.GetTxManager(ctx).QueryRow(ctx, query, args...).
+		Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &token.RevokedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.RefreshToken{}, repo.ErrRefreshTokenNotFound
+		}
+		return entity.RefreshToken{}, fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	return token, nil
+}
+
+func (r *Repository) RevokeRefreshToken(ctx context.Context, id int64, at time.Time) error {
+	query, args, err := r.pg.Builder.
+		Update("refresh_tokens").
+		Set("revoked_at", at).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	tag, err := r.pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", repo.ClassifyPgError(ctx, err))
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrRefreshTokenNotFound
+	}
+	return nil
+}
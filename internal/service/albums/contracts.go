@@ -2,20 +2,72 @@ package albums
 
 import (
 	"context"
+	"time"
 
 	"github.com/4udiwe/musicshop/internal/entity"
 )
 
 type AlbumRepository interface {
 	Create(ctx context.Context, album entity.Album) (int64, error)
-	FindAll(ctx context.Context) ([]entity.Album, error)
+	FindAll(ctx context.Context, filter entity.AlbumFilter) ([]entity.Album, error)
+	Count(ctx context.Context, filter entity.AlbumFilter) (int64, error)
+	Search(ctx context.Context, search entity.AlbumSearch) ([]entity.Album, error)
+	CountSearch(ctx context.Context, search entity.AlbumSearch) (int64, error)
 	FindById(ctx context.Context, id int64) (entity.Album, error)
+	Lock(ctx context.Context, id int64) error
+	FindSKUPrice(ctx context.Context, albumID int64) (entity.Money, bool, error)
+	Update(ctx context.Context, album entity.Album) error
+	UpdatePrice(ctx context.Context, id int64, price entity.Money) error
+	Delete(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+type ArtistRepository interface {
+	Create(ctx context.Context, artist entity.Artist) (int64, error)
+	FindOrCreate(ctx context.Context, name string) (entity.Artist, error)
+	FindAll(ctx context.Context) ([]entity.Artist, error)
+	FindById(ctx context.Context, id int64) (entity.Artist, error)
+	Update(ctx context.Context, artist entity.Artist) error
 	Delete(ctx context.Context, id int64) error
 }
 
 type GenreRepository interface {
 	Create(ctx context.Context, genre entity.Genre) (int64, error)
 	AddGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) error
-	FindAll(ctx context.Context) ([]entity.Genre, error)
+	RemoveGenresFromAlbum(ctx context.Context, albumID int64) error
+	LinkGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error)
+	UnlinkGenreFromAlbum(ctx context.Context, albumID, genreID int64) error
+	RetainAlbumGenres(ctx context.Context, albumID int64, genreIDs ...int64) ([]int64, error)
+	FindAll(ctx context.Context, includeDeleted bool) ([]entity.Genre, error)
+	FindById(ctx context.Context, id int64) (entity.Genre, error)
+	Update(ctx context.Context, genre entity.Genre) error
 	Delete(ctx context.Context, id int64) error
+	FindSubtreeIDs(ctx context.Context, id int64) ([]int64, error)
+	ReparentChildren(ctx context.Context, id int64) error
+	DeleteSubtree(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+type TrackRepository interface {
+	Create(ctx context.Context, albumID int64, tracks ...entity.Track) error
+	FindByAlbumID(ctx context.Context, albumID int64) ([]entity.Track, error)
+	DeleteByAlbumID(ctx context.Context, albumID int64) error
+}
+
+// PriceQuoter переводит базовые цены альбомов в другую валюту
+type PriceQuoter interface {
+	Quote(ctx context.Context, currency string, prices map[int64]entity.Money) (map[int64]entity.Money, error)
+}
+
+// AuditWriter пишет журнал изменений каталога в транзакции изменения
+type AuditWriter interface {
+	Write(ctx context.Context, event entity.AuditEvent) error
+}
+
+// EventCounter считает бизнес-события для метрик, вызывается после фиксации изменений
+type EventCounter interface {
+	AlbumCreated()
+	GenresAttached(n int)
 }
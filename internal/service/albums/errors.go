@@ -3,10 +3,20 @@ package albums
 import "errors"
 
 var (
-	ErrAlbumAlreadyExists = errors.New("album already exists")
-	ErrCannotCreateAlbum  = errors.New("cannot create album")
-	ErrCannotFetchAlbums  = errors.New("cannot fetch albums")
-	ErrFindingAlbum       = errors.New("error finding album")
-	ErrAlbumNotFound      = errors.New("album not found")
-	ErrGenreNotExists     = errors.New("genre with given id does not exist")
+	ErrAlbumAlreadyExists   = errors.New("album already exists")
+	ErrCannotCreateAlbum    = errors.New("cannot create album")
+	ErrCannotUpdateAlbum    = errors.New("cannot update album")
+	ErrCannotFetchAlbums    = errors.New("cannot fetch albums")
+	ErrCannotSearchAlbums   = errors.New("cannot search albums")
+	ErrCannotRestoreAlbum   = errors.New("cannot restore album")
+	ErrCannotPurgeAlbums    = errors.New("cannot purge deleted albums")
+	ErrFindingAlbum         = errors.New("error finding album")
+	ErrAlbumNotFound        = errors.New("album not found")
+	ErrGenreNotExists       = errors.New("genre with given id does not exist")
+	ErrArtistNotExists      = errors.New("artist with given id does not exist")
+	ErrPriceDerivedFromSKUs = errors.New("album price is derived from its skus")
+
+	ErrDuplicateTrackPosition = errors.New("duplicate track position on disc")
+	ErrInvalidTrack           = errors.New("invalid track")
+	ErrCannotReplaceTracks    = errors.New("cannot replace tracks")
 )
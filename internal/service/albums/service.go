@@ -3,36 +3,61 @@ package albums
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/4udiwe/musicshop/internal/auth"
 	"github.com/4udiwe/musicshop/internal/entity"
 	repo "github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
 	"github.com/4udiwe/musicshop/pkg/transactor"
 	"github.com/samber/lo"
 )
 
 type Service struct {
-	albumRepository AlbumRepository
-	genreRepository GenreRepository
-	txManager       transactor.Transactor
+	albumRepository  AlbumRepository
+	genreRepository  GenreRepository
+	artistRepository ArtistRepository
+	trackRepository  TrackRepository
+	auditWriter      AuditWriter
+	priceQuoter      PriceQuoter
+	eventCounter     EventCounter
+	txManager        transactor.Transactor
 }
 
 func New(
 	a AlbumRepository,
 	g GenreRepository,
+	ar ArtistRepository,
+	tr TrackRepository,
+	w AuditWriter,
+	q PriceQuoter,
+	m EventCounter,
 	t transactor.Transactor,
 ) *Service {
 	return &Service{
-		albumRepository: a,
-		genreRepository: g,
-		txManager:       t,
+		albumRepository:  a,
+		genreRepository:  g,
+		artistRepository: ar,
+		trackRepository:  tr,
+		auditWriter:      w,
+		priceQuoter:      q,
+		eventCounter:     m,
+		txManager:        t,
 	}
 }
 
 func (s *Service) Create(ctx context.Context, a entity.Album) (int64, error) {
+	ctx, span := tracing.Start(ctx, "albums.Create")
+	defer span.End()
+
 	var id int64
 
 	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
-		var err error
+		a, err := s.resolveArtist(ctx, a)
+		if err != nil {
+			return err
+		}
 		id, err = s.albumRepository.Create(ctx, a)
 		if err != nil {
 			return err
@@ -43,49 +68,462 @@ func (s *Service) Create(ctx context.Context, a entity.Album) (int64, error) {
 				id,
 				lo.Map(a.Genres, func(g entity.Genre, i int) int64 { return g.ID })...,
 			)
+			if err != nil {
+				return err
+			}
 		}
-		return err
+		a.ID = id
+		return s.record(ctx, entity.AuditActionCreate, id, nil, a)
 	})
 
 	if err != nil {
 		if errors.Is(err, repo.ErrAlbumAlreadyExists) {
-			return 0, ErrAlbumAlreadyExists
+			return 0, fmt.Errorf("%w: %w", ErrAlbumAlreadyExists, err)
 		}
 		if errors.Is(err, repo.ErrAddAlbumGenreConstraintFail) {
-			return 0, ErrGenreNotExists
+			return 0, fmt.Errorf("%w: %w", ErrGenreNotExists, err)
+		}
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return 0, fmt.Errorf("%w: %w", ErrArtistNotExists, err)
 		}
-		return 0, ErrCannotCreateAlbum
+		return 0, fmt.Errorf("%w: %w", ErrCannotCreateAlbum, err)
 	}
 
+	s.eventCounter.AlbumCreated()
+	s.eventCounter.GenresAttached(len(a.Genres))
+
 	return id, nil
 }
 
-func (s *Service) FindAll(ctx context.Context) ([]entity.Album, error) {
-	albums, err := s.albumRepository.FindAll(ctx)
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+func (s *Service) FindAll(ctx context.Context, filter entity.AlbumFilter) (entity.AlbumPage, error) {
+	ctx, span := tracing.Start(ctx, "albums.FindAll")
+	defer span.End()
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	limit = min(limit, MaxPageLimit)
+
+	if filter.IncludeSubgenres && len(filter.GenreIDs) > 0 {
+		subtrees, err := s.genreSubtrees(ctx, filter.GenreIDs)
+		if err != nil {
+			return entity.AlbumPage{}, fmt.Errorf("%w: %w", ErrCannotFetchAlbums, err)
+		}
+		filter.GenreSubtrees = subtrees
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	filter.Limit = limit + 1
+	albums, err := s.albumRepository.FindAll(ctx, filter)
+	if err != nil {
+		return entity.AlbumPage{}, fmt.Errorf("%w: %w", ErrCannotFetchAlbums, err)
+	}
+
+	total, err := s.albumRepository.Count(ctx, filter)
+	if err != nil {
+		return entity.AlbumPage{}, fmt.Errorf("%w: %w", ErrCannotFetchAlbums, err)
+	}
+
+	if err = s.localize(ctx, filter.Currency, albums); err != nil {
+		return entity.AlbumPage{}, fmt.Errorf("%w: %w", ErrCannotFetchAlbums, err)
+	}
+
+	page := entity.AlbumPage{Albums: albums, Total: total}
+	if len(albums) > limit {
+		page.Albums = albums[:limit]
+		page.NextCursor = albums[limit-1].ID
+	}
+
+	return page, nil
+}
+
+// genreSubtrees возвращает для каждого жанра его id вместе с id всех поджанров
+func (s *Service) genreSubtrees(ctx context.Context, genreIDs []int64) ([][]int64, error) {
+	subtrees := make([][]int64, 0, len(genreIDs))
+	for _, id := range lo.Uniq(genreIDs) {
+		subtree, err := s.genreRepository.FindSubtreeIDs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		subtrees = append(subtrees, subtree)
+	}
+	return subtrees, nil
+}
+
+// Search ищет альбомы по названию, артисту и жанрам, самые релевантные - первыми
+func (s *Service) Search(ctx context.Context, search entity.AlbumSearch) (entity.AlbumPage, error) {
+	ctx, span := tracing.Start(ctx, "albums.Search")
+	defer span.End()
+
+	limit := search.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	limit = min(limit, MaxPageLimit)
+
+	search.Limit = limit + 1
+	albums, err := s.albumRepository.Search(ctx, search)
 	if err != nil {
-		return nil, ErrCannotFetchAlbums
+		return entity.AlbumPage{}, fmt.Errorf("%w: %w", ErrCannotSearchAlbums, err)
 	}
 
-	return albums, nil
+	total, err := s.albumRepository.CountSearch(ctx, search)
+	if err != nil {
+		return entity.AlbumPage{}, fmt.Errorf("%w: %w", ErrCannotSearchAlbums, err)
+	}
+
+	if err = s.localize(ctx, search.Currency, albums); err != nil {
+		return entity.AlbumPage{}, fmt.Errorf("%w: %w", ErrCannotSearchAlbums, err)
+	}
+
+	page := entity.AlbumPage{Albums: albums, Total: total}
+	if len(albums) > limit {
+		page.Albums = albums[:limit]
+		page.NextCursor = albums[limit-1].ID
+	}
+
+	return page, nil
 }
 
-func (s *Service) FindById(ctx context.Context, id int64) (entity.Album, error) {
+// FindById возвращает альбом с треками и ценой в валюте currency, пустая валюта - базовая
+func (s *Service) FindById(ctx context.Context, id int64, currency string) (entity.Album, error) {
+	ctx, span := tracing.Start(ctx, "albums.FindById")
+	defer span.End()
+
 	album, err := s.albumRepository.FindById(ctx, id)
 	if err != nil {
 		if errors.Is(err, repo.ErrAlbumNotFound) {
-			return entity.Album{}, ErrAlbumNotFound
+			return entity.Album{}, fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
 		}
-		return entity.Album{}, ErrFindingAlbum
+		return entity.Album{}, fmt.Errorf("%w: %w", ErrFindingAlbum, err)
 	}
+
+	albums := []entity.Album{album}
+	if err = s.localize(ctx, currency, albums); err != nil {
+		return entity.Album{}, fmt.Errorf("%w: %w", ErrFindingAlbum, err)
+	}
+	album = albums[0]
+
+	album.Tracks, err = s.trackRepository.FindByAlbumID(ctx, id)
+	if err != nil {
+		return entity.Album{}, fmt.Errorf("%w: %w", ErrFindingAlbum, err)
+	}
+
 	return album, nil
 }
 
+func (s *Service) FindTracks(ctx context.Context, albumID int64) ([]entity.Track, error) {
+	ctx, span := tracing.Start(ctx, "albums.FindTracks")
+	defer span.End()
+
+	album, err := s.FindById(ctx, albumID, entity.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	return album.Tracks, nil
+}
+
+// ReplaceTracks атомарно заменяет треклист альбома
+func (s *Service) ReplaceTracks(ctx context.Context, albumID int64, tracks []entity.Track) error {
+	ctx, span := tracing.Start(ctx, "albums.ReplaceTracks")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.albumRepository.FindById(ctx, albumID); err != nil {
+			return err
+		}
+		before, err := s.trackRepository.FindByAlbumID(ctx, albumID)
+		if err != nil {
+			return err
+		}
+		if err := s.trackRepository.DeleteByAlbumID(ctx, albumID); err != nil {
+			return err
+		}
+		if err := s.trackRepository.Create(ctx, albumID, tracks...); err != nil {
+			return err
+		}
+		return s.record(ctx, entity.AuditActionUpdate, albumID, before, tracks)
+	})
+
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+		}
+		if errors.Is(err, repo.ErrDuplicateTrackPosition) {
+			return fmt.Errorf("%w: %w", ErrDuplicateTrackPosition, err)
+		}
+		if errors.Is(err, repo.ErrInvalidTrack) {
+			return fmt.Errorf("%w: %w", ErrInvalidTrack, err)
+		}
+		return fmt.Errorf("%w: %w", ErrCannotReplaceTracks, err)
+	}
+
+	return nil
+}
+
+// Update заменяет альбом целиком. У альбома с SKU цена выводится из самого
+// дешевого SKU: она принимается без изменений, другая цена - ErrPriceDerivedFromSKUs.
+func (s *Service) Update(ctx context.Context, a entity.Album) error {
+	ctx, span := tracing.Start(ctx, "albums.Update")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.albumRepository.Lock(ctx, a.ID); err != nil {
+			return err
+		}
+		before, err := s.albumRepository.FindById(ctx, a.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.validatePrice(ctx, a.ID, a.Price); err != nil {
+			return err
+		}
+		a, err := s.resolveArtist(ctx, a)
+		if err != nil {
+			return err
+		}
+		if err := s.albumRepository.Update(ctx, a); err != nil {
+			return err
+		}
+		if err := s.albumRepository.UpdatePrice(ctx, a.ID, a.Price); err != nil {
+			return err
+		}
+		if err := s.replaceGenres(ctx, a.ID, a.Genres); err != nil {
+			return err
+		}
+		return s.record(ctx, entity.AuditActionUpdate, a.ID, before, a)
+	})
+
+	if err != nil {
+		return s.mapUpdateError(err)
+	}
+
+	return nil
+}
+
+// Patch меняет переданные поля альбома. Строка альбома блокируется до записи,
+// иначе параллельный PATCH другого поля был бы затерт прочитанным до него значением.
+func (s *Service) Patch(ctx context.Context, id int64, patch entity.AlbumPatch) error {
+	ctx, span := tracing.Start(ctx, "albums.Patch")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.albumRepository.Lock(ctx, id); err != nil {
+			return err
+		}
+		before, err := s.albumRepository.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if patch.Price != nil {
+			if err := s.validatePrice(ctx, id, *patch.Price); err != nil {
+				return err
+			}
+		}
+
+		album := patch.Apply(before)
+		if patch.ChangesArtist() {
+			if album, err = s.resolveArtist(ctx, album); err != nil {
+				return err
+			}
+		}
+
+		if err := s.albumRepository.Update(ctx, album); err != nil {
+			return err
+		}
+		// цена пишется, только если ее передали: иначе PATCH названия завершил бы
+		// действующую запланированную цену
+		if patch.Price != nil {
+			if err := s.albumRepository.UpdatePrice(ctx, id, *patch.Price); err != nil {
+				return err
+			}
+		}
+
+		if patch.Genres != nil {
+			if err := s.replaceGenres(ctx, id, patch.Genres); err != nil {
+				return err
+			}
+		}
+		return s.record(ctx, entity.AuditActionUpdate, id, before, album)
+	})
+
+	if err != nil {
+		return s.mapUpdateError(err)
+	}
+
+	return nil
+}
+
+// validatePrice - правило цены альбома, общее для PUT и PATCH: цена проходит
+// entity.ValidatePrice, а цена альбома с SKU выводится из самого дешевого SKU
+// и меняется через SKU, поэтому принимается только она.
+func (s *Service) validatePrice(ctx context.Context, albumID int64, price entity.Money) error {
+	if err := entity.ValidatePrice(price); err != nil {
+		return err
+	}
+	skuPrice, ok, err := s.albumRepository.FindSKUPrice(ctx, albumID)
+	if err != nil {
+		return err
+	}
+	if ok && price != skuPrice {
+		return fmt.Errorf("%w: album %d costs %s %s as its cheapest sku",
+			ErrPriceDerivedFromSKUs, albumID, skuPrice.Decimal(), skuPrice.Currency)
+	}
+	return nil
+}
+
+// replaceGenres заменяет набор жанров альбома, должен вызываться внутри транзакции
+func (s *Service) replaceGenres(ctx context.Context, albumID int64, genres []entity.Genre) error {
+	if err := s.genreRepository.RemoveGenresFromAlbum(ctx, albumID); err != nil {
+		return err
+	}
+	if len(genres) == 0 {
+		return nil
+	}
+	return s.genreRepository.AddGenresToAlbum(
+		ctx,
+		albumID,
+		lo.Map(genres, func(g entity.Genre, i int) int64 { return g.ID })...,
+	)
+}
+
+// resolveArtist заполняет артиста альбома: по ArtistID, а если он не задан - по имени,
+// создавая артиста при необходимости. Старый формат с именем поддерживается на время перехода.
+func (s *Service) resolveArtist(ctx context.Context, a entity.Album) (entity.Album, error) {
+	var (
+		artist entity.Artist
+		err    error
+	)
+	if a.ArtistID != 0 {
+		artist, err = s.artistRepository.FindById(ctx, a.ArtistID)
+	} else {
+		artist, err = s.artistRepository.FindOrCreate(ctx, a.Artist)
+	}
+	if err != nil {
+		return entity.Album{}, err
+	}
+
+	a.ArtistID = artist.ID
+	a.Artist = artist.Name
+	return a, nil
+}
+
+func (s *Service) mapUpdateError(err error) error {
+	if errors.Is(err, ErrPriceDerivedFromSKUs) || errors.Is(err, entity.ErrInvalidMoney) {
+		return err
+	}
+	if errors.Is(err, repo.ErrAlbumNotFound) {
+		return fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+	}
+	if errors.Is(err, repo.ErrAlbumAlreadyExists) {
+		return fmt.Errorf("%w: %w", ErrAlbumAlreadyExists, err)
+	}
+	if errors.Is(err, repo.ErrAddAlbumGenreConstraintFail) {
+		return fmt.Errorf("%w: %w", ErrGenreNotExists, err)
+	}
+	if errors.Is(err, repo.ErrArtistNotFound) {
+		return fmt.Errorf("%w: %w", ErrArtistNotExists, err)
+	}
+	return fmt.Errorf("%w: %w", ErrCannotUpdateAlbum, err)
+}
+
 func (s *Service) DeleteById(ctx context.Context, id int64) error {
-	if err := s.albumRepository.Delete(ctx, id); err != nil {
+	ctx, span := tracing.Start(ctx, "albums.DeleteById")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.albumRepository.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.albumRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.record(ctx, entity.AuditActionDelete, id, before, nil)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+		}
+		return fmt.Errorf("%w: %w", ErrFindingAlbum, err)
+	}
+	return nil
+}
+
+// Restore возвращает мягко удаленный альбом вместе с жанрами, треками и SKU
+func (s *Service) Restore(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "albums.Restore")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.albumRepository.Restore(ctx, id); err != nil {
+			return err
+		}
+		after, err := s.albumRepository.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, entity.AuditActionRestore, id, nil, after)
+	})
+	if err != nil {
 		if errors.Is(err, repo.ErrAlbumNotFound) {
-			return ErrAlbumNotFound
+			return fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
 		}
-		return ErrFindingAlbum
+		return fmt.Errorf("%w: %w", ErrCannotRestoreAlbum, err)
 	}
 	return nil
 }
+
+// PurgeDeleted окончательно удаляет альбомы, удаленные раньше before.
+// В журнал не пишется: удаление этих альбомов уже записано при мягком удалении.
+func (s *Service) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "albums.PurgeDeleted")
+	defer span.End()
+
+	purged, err := s.albumRepository.PurgeDeleted(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCannotPurgeAlbums, err)
+	}
+	return purged, nil
+}
+
+// localize переводит цены альбомов в валюту currency на месте
+func (s *Service) localize(ctx context.Context, currency string, albums []entity.Album) error {
+	if currency == "" || currency == entity.BaseCurrency || len(albums) == 0 {
+		return nil
+	}
+
+	prices, err := s.priceQuoter.Quote(ctx, currency, lo.SliceToMap(albums, func(a entity.Album) (int64, entity.Money) {
+		return a.ID, a.Price
+	}))
+	if err != nil {
+		return err
+	}
+
+	for i := range albums {
+		albums[i].Price = prices[albums[i].ID]
+	}
+	return nil
+}
+
+// record пишет событие журнала об изменении альбома, должен вызываться внутри транзакции
+func (s *Service) record(ctx context.Context, action string, id int64, before, after any) error {
+	event := entity.AuditEvent{
+		Action:     action,
+		EntityType: entity.AuditEntityAlbum,
+		EntityID:   id,
+		Before:     before,
+		After:      after,
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		event.ActorID = &principal.UserID
+	}
+	return s.auditWriter.Write(ctx, event)
+}
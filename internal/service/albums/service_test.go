@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/4udiwe/musicshop/internal/auth"
 	"github.com/4udiwe/musicshop/internal/entity"
 	"github.com/4udiwe/musicshop/internal/mocks/mock_albums"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_artists"
 	"github.com/4udiwe/musicshop/internal/mocks/mock_genres"
 	"github.com/4udiwe/musicshop/internal/mocks/mock_transactor"
 	repo "github.com/4udiwe/musicshop/internal/repo"
@@ -26,13 +29,15 @@ func TestCreate(t *testing.T) {
 	type MockBehavior func(
 		a *mock_albums.MockAlbumRepository,
 		g *mock_genres.MockGenreRepository,
+		ar *mock_artists.MockArtistRepository,
+		w *mock_albums.MockAuditWriter,
 		t *mock_transactor.MockTransactor,
 	)
 
 	album := entity.Album{
 		Title:  "title",
 		Artist: "artist",
-		Price:  100.0,
+		Price:  entity.NewMoney(10000, "USD"),
 		Genres: []entity.Genre{
 			{ID: 1},
 			{ID: 2},
@@ -40,6 +45,11 @@ func TestCreate(t *testing.T) {
 		},
 	}
 
+	artist := entity.Artist{ID: 7, Name: album.Artist}
+
+	resolved := album
+	resolved.ArtistID = artist.ID
+
 	for _, tc := range []struct {
 		name         string
 		mockBehavior MockBehavior
@@ -48,51 +58,77 @@ func TestCreate(t *testing.T) {
 	}{
 		{
 			name: "success",
-			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, t *mock_transactor.MockTransactor) {
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
 				t.EXPECT().WithinTransaction(ctx, gomock.Any()).
 					DoAndReturn(
 						func(ctx context.Context, fn func(ctx context.Context) error) error {
 							return fn(ctx)
 						})
 
-				a_repo.EXPECT().Create(ctx, album).Return(albumID, nil)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Create(ctx, resolved).Return(albumID, nil)
 
 				g_repo.EXPECT().AddGenresToAlbum(ctx, albumID, genreIDs).Return(nil)
+
+				created := resolved
+				created.ID = albumID
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionCreate,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					After:      created,
+				}).Return(nil)
 			},
 			want:    1,
 			wantErr: nil,
 		},
 		{
 			name: "album already exists",
-			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, t *mock_transactor.MockTransactor) {
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
 				t.EXPECT().WithinTransaction(ctx, gomock.Any()).
 					DoAndReturn(
 						func(ctx context.Context, fn func(ctx context.Context) error) error {
 							return fn(ctx)
 						})
 
-				a_repo.EXPECT().Create(ctx, album).Return(int64(0), repo.ErrAlbumAlreadyExists)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Create(ctx, resolved).Return(int64(0), repo.ErrAlbumAlreadyExists)
 			},
 			want:    0,
 			wantErr: service.ErrAlbumAlreadyExists,
 		},
 		{
 			name: "cannot create album",
-			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, t *mock_transactor.MockTransactor) {
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+					DoAndReturn(
+						func(ctx context.Context, fn func(ctx context.Context) error) error {
+							return fn(ctx)
+						})
+
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Create(ctx, resolved).Return(int64(0), arbitraryErr)
+			},
+			want:    0,
+			wantErr: service.ErrCannotCreateAlbum,
+		},
+		{
+			name: "cannot resolve artist",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
 				t.EXPECT().WithinTransaction(ctx, gomock.Any()).
 					DoAndReturn(
 						func(ctx context.Context, fn func(ctx context.Context) error) error {
 							return fn(ctx)
 						})
 
-				a_repo.EXPECT().Create(ctx, album).Return(int64(0), arbitraryErr)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(entity.Artist{}, arbitraryErr)
 			},
 			want:    0,
 			wantErr: service.ErrCannotCreateAlbum,
 		},
 		{
 			name: "transaction error",
-			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, t *mock_transactor.MockTransactor) {
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
 				t.EXPECT().WithinTransaction(ctx, gomock.Any()).
 					DoAndReturn(
 						func(ctx context.Context, fn func(ctx context.Context) error) error {
@@ -104,14 +140,15 @@ func TestCreate(t *testing.T) {
 		},
 		{
 			name: "cannot add genre to album",
-			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, t *mock_transactor.MockTransactor) {
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
 				t.EXPECT().WithinTransaction(ctx, gomock.Any()).
 					DoAndReturn(
 						func(ctx context.Context, fn func(ctx context.Context) error) error {
 							return fn(ctx)
 						})
 
-				a_repo.EXPECT().Create(ctx, album).Return(albumID, nil)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Create(ctx, resolved).Return(albumID, nil)
 
 				g_repo.EXPECT().AddGenresToAlbum(ctx, albumID, genreIDs).Return(repo.ErrAddAlbumGenreConstraintFail)
 			},
@@ -125,11 +162,20 @@ func TestCreate(t *testing.T) {
 
 			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
 			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
 			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
 
-			tc.mockBehavior(mockAlbumRepository, mockGenresRepository, mockTransactor)
+			mockEventCounter := mock_albums.NewMockEventCounter(ctrl)
+
+			tc.mockBehavior(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockAuditWriter, mockTransactor)
+			if tc.wantErr == nil {
+				mockEventCounter.EXPECT().AlbumCreated()
+				mockEventCounter.EXPECT().GenresAttached(len(genreIDs))
+			}
 
-			s := service.New(mockAlbumRepository, mockGenresRepository, mockTransactor)
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, nil, mockEventCounter, mockTransactor)
 
 			out, err := s.Create(ctx, album)
 
@@ -145,47 +191,190 @@ func TestFindAll(t *testing.T) {
 		ctx          = context.Background()
 	)
 
-	type MockBehavior func(r *mock_albums.MockAlbumRepository)
+	type MockBehavior func(r *mock_albums.MockAlbumRepository, g *mock_genres.MockGenreRepository)
 
 	albums := []entity.Album{
 		{
+			ID:     1,
 			Title:  "title 1",
 			Artist: "artist 1",
-			Price:  100.0,
+			Price:  entity.NewMoney(10000, "USD"),
 		},
 		{
+			ID:     2,
 			Title:  "title 2",
 			Artist: "artist 2",
-			Price:  200.0,
+			Price:  entity.NewMoney(20000, "USD"),
 		},
 		{
+			ID:     3,
 			Title:  "title 3",
 			Artist: "artist 3",
-			Price:  300.0,
+			Price:  entity.NewMoney(30000, "USD"),
 		},
 	}
 
 	for _, tc := range []struct {
 		name         string
+		filter       entity.AlbumFilter
 		mockBehavior MockBehavior
-		want         []entity.Album
+		want         entity.AlbumPage
 		wantErr      error
 	}{
 		{
-			name: "success",
+			name:   "success with default limit",
+			filter: entity.AlbumFilter{},
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, g *mock_genres.MockGenreRepository) {
+				filter := entity.AlbumFilter{Limit: service.DefaultPageLimit + 1}
+				r.EXPECT().FindAll(ctx, filter).Return(albums, nil)
+				r.EXPECT().Count(ctx, filter).Return(int64(3), nil)
+			},
+			want:    entity.AlbumPage{Albums: albums, Total: 3},
+			wantErr: nil,
+		},
+		{
+			name:   "success with next page",
+			filter: entity.AlbumFilter{Limit: 2, Artist: "artist"},
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, g *mock_genres.MockGenreRepository) {
+				filter := entity.AlbumFilter{Limit: 3, Artist: "artist"}
+				r.EXPECT().FindAll(ctx, filter).Return(albums, nil)
+				r.EXPECT().Count(ctx, filter).Return(int64(3), nil)
+			},
+			want:    entity.AlbumPage{Albums: albums[:2], NextCursor: 2, Total: 3},
+			wantErr: nil,
+		},
+		{
+			name:   "limit is capped",
+			filter: entity.AlbumFilter{Limit: service.MaxPageLimit * 2},
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, g *mock_genres.MockGenreRepository) {
+				filter := entity.AlbumFilter{Limit: service.MaxPageLimit + 1}
+				r.EXPECT().FindAll(ctx, filter).Return(albums, nil)
+				r.EXPECT().Count(ctx, filter).Return(int64(3), nil)
+			},
+			want:    entity.AlbumPage{Albums: albums, Total: 3},
+			wantErr: nil,
+		},
+		{
+			name:   "genres expanded with subgenres",
+			filter: entity.AlbumFilter{GenreIDs: []int64{1, 4, 1}, GenreMatch: entity.GenreMatchAll, IncludeSubgenres: true},
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, g *mock_genres.MockGenreRepository) {
+				g.EXPECT().FindSubtreeIDs(ctx, int64(1)).Return([]int64{1, 2, 3}, nil)
+				g.EXPECT().FindSubtreeIDs(ctx, int64(4)).Return([]int64{4}, nil)
+				filter := entity.AlbumFilter{
+					Limit:            service.DefaultPageLimit + 1,
+					GenreIDs:         []int64{1, 4, 1},
+					GenreMatch:       entity.GenreMatchAll,
+					IncludeSubgenres: true,
+					GenreSubtrees:    [][]int64{{1, 2, 3}, {4}},
+				}
+				r.EXPECT().FindAll(ctx, filter).Return(albums, nil)
+				r.EXPECT().Count(ctx, filter).Return(int64(3), nil)
+			},
+			want:    entity.AlbumPage{Albums: albums, Total: 3},
+			wantErr: nil,
+		},
+		{
+			name:   "cannot expand subgenres",
+			filter: entity.AlbumFilter{GenreIDs: []int64{1}, IncludeSubgenres: true},
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, g *mock_genres.MockGenreRepository) {
+				g.EXPECT().FindSubtreeIDs(ctx, int64(1)).Return(nil, arbitraryErr)
+			},
+			want:    entity.AlbumPage{},
+			wantErr: service.ErrCannotFetchAlbums,
+		},
+		{
+			name:   "cannot fetch albums",
+			filter: entity.AlbumFilter{},
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, g *mock_genres.MockGenreRepository) {
+				r.EXPECT().FindAll(ctx, gomock.Any()).Return(nil, arbitraryErr)
+			},
+			want:    entity.AlbumPage{},
+			wantErr: service.ErrCannotFetchAlbums,
+		},
+		{
+			name:   "cannot count albums",
+			filter: entity.AlbumFilter{},
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, g *mock_genres.MockGenreRepository) {
+				r.EXPECT().FindAll(ctx, gomock.Any()).Return(albums, nil)
+				r.EXPECT().Count(ctx, gomock.Any()).Return(int64(0), arbitraryErr)
+			},
+			want:    entity.AlbumPage{},
+			wantErr: service.ErrCannotFetchAlbums,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
+			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockAlbumRepository, mockGenresRepository)
+
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, nil, nil, mockTransactor)
+
+			out, err := s.FindAll(ctx, tc.filter)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+	)
+
+	type MockBehavior func(r *mock_albums.MockAlbumRepository)
+
+	albums := []entity.Album{
+		{ID: 7, Title: "The Dark Side of the Moon", Artist: "Pink Floyd", Price: entity.NewMoney(3000, "USD")},
+		{ID: 3, Title: "Dark Star", Artist: "Grateful Dead", Price: entity.NewMoney(2000, "USD")},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		search       entity.AlbumSearch
+		mockBehavior MockBehavior
+		want         entity.AlbumPage
+		wantErr      error
+	}{
+		{
+			name:   "success",
+			search: entity.AlbumSearch{Query: "dark"},
 			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
-				r.EXPECT().FindAll(ctx).Return(albums, nil)
+				search := entity.AlbumSearch{Query: "dark", Limit: service.DefaultPageLimit + 1}
+				r.EXPECT().Search(ctx, search).Return(albums, nil)
+				r.EXPECT().CountSearch(ctx, search).Return(int64(2), nil)
 			},
-			want:    albums,
+			want:    entity.AlbumPage{Albums: albums, Total: 2},
 			wantErr: nil,
 		},
 		{
-			name: "cannot fetch albums",
+			name:   "success with next page",
+			search: entity.AlbumSearch{Query: "dark", Limit: 1, Cursor: 9},
 			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
-				r.EXPECT().FindAll(ctx).Return(nil, arbitraryErr)
+				search := entity.AlbumSearch{Query: "dark", Limit: 2, Cursor: 9}
+				r.EXPECT().Search(ctx, search).Return(albums, nil)
+				r.EXPECT().CountSearch(ctx, search).Return(int64(5), nil)
 			},
-			want:    nil,
-			wantErr: service.ErrCannotFetchAlbums,
+			want:    entity.AlbumPage{Albums: albums[:1], NextCursor: 7, Total: 5},
+			wantErr: nil,
+		},
+		{
+			name:   "cannot search albums",
+			search: entity.AlbumSearch{Query: "dark"},
+			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
+				r.EXPECT().Search(ctx, gomock.Any()).Return(nil, arbitraryErr)
+			},
+			want:    entity.AlbumPage{},
+			wantErr: service.ErrCannotSearchAlbums,
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -194,13 +383,16 @@ func TestFindAll(t *testing.T) {
 
 			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
 			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
 			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
 
 			tc.mockBehavior(mockAlbumRepository)
 
-			s := service.New(mockAlbumRepository, mockGenresRepository, mockTransactor)
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, nil, nil, mockTransactor)
 
-			out, err := s.FindAll(ctx)
+			out, err := s.Search(ctx, tc.search)
 
 			assert.ErrorIs(t, err, tc.wantErr)
 			assert.Equal(t, tc.want, out)
@@ -216,31 +408,75 @@ func TestFindById(t *testing.T) {
 		emptyAlbum   = entity.Album{}
 	)
 
-	type MockBehavior func(r *mock_albums.MockAlbumRepository)
+	type MockBehavior func(r *mock_albums.MockAlbumRepository, tr *mock_albums.MockTrackRepository, q *mock_albums.MockPriceQuoter)
+
+	tracks := []entity.Track{
+		{AlbumID: id, DiscNumber: 1, Position: 1, Title: "track 1", Duration: 3 * time.Minute},
+		{AlbumID: id, DiscNumber: 1, Position: 2, Title: "track 2", Duration: 4 * time.Minute},
+	}
 
 	album := entity.Album{
 		Title:  "title",
 		Artist: "artist",
-		Price:  100.0,
+		Price:  entity.NewMoney(10000, "USD"),
 	}
 
+	withTracks := album
+	withTracks.Tracks = tracks
+
+	inEUR := withTracks
+	inEUR.Price = entity.NewMoney(9200, "EUR")
+
 	for _, tc := range []struct {
 		name         string
+		currency     string
 		mockBehavior MockBehavior
 		want         entity.Album
 		wantErr      error
 	}{
 		{
 			name: "success",
-			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, tr *mock_albums.MockTrackRepository, q *mock_albums.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
+				tr.EXPECT().FindByAlbumID(ctx, id).Return(tracks, nil)
+			},
+			want:    withTracks,
+			wantErr: nil,
+		},
+		{
+			name:     "success in other currency",
+			currency: "EUR",
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, tr *mock_albums.MockTrackRepository, q *mock_albums.MockPriceQuoter) {
 				r.EXPECT().FindById(ctx, id).Return(album, nil)
+				q.EXPECT().Quote(ctx, "EUR", map[int64]entity.Money{0: entity.NewMoney(10000, "USD")}).
+					Return(map[int64]entity.Money{0: entity.NewMoney(9200, "EUR")}, nil)
+				tr.EXPECT().FindByAlbumID(ctx, id).Return(tracks, nil)
 			},
-			want:    album,
+			want:    inEUR,
 			wantErr: nil,
 		},
+		{
+			name:     "unsupported currency",
+			currency: "XXX",
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, tr *mock_albums.MockTrackRepository, q *mock_albums.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
+				q.EXPECT().Quote(ctx, "XXX", gomock.Any()).Return(nil, entity.ErrUnsupportedCurrency)
+			},
+			want:    emptyAlbum,
+			wantErr: entity.ErrUnsupportedCurrency,
+		},
+		{
+			name: "cannot fetch tracks",
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, tr *mock_albums.MockTrackRepository, q *mock_albums.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
+				tr.EXPECT().FindByAlbumID(ctx, id).Return(nil, arbitraryErr)
+			},
+			want:    emptyAlbum,
+			wantErr: service.ErrFindingAlbum,
+		},
 		{
 			name: "album not found",
-			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, tr *mock_albums.MockTrackRepository, q *mock_albums.MockPriceQuoter) {
 				r.EXPECT().FindById(ctx, id).Return(emptyAlbum, repo.ErrAlbumNotFound)
 			},
 			want:    emptyAlbum,
@@ -248,7 +484,7 @@ func TestFindById(t *testing.T) {
 		},
 		{
 			name: "cannot fetch album",
-			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, tr *mock_albums.MockTrackRepository, q *mock_albums.MockPriceQuoter) {
 				r.EXPECT().FindById(ctx, id).Return(emptyAlbum, arbitraryErr)
 			},
 			want:    emptyAlbum,
@@ -261,13 +497,17 @@ func TestFindById(t *testing.T) {
 
 			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
 			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
+			mockPriceQuoter := mock_albums.NewMockPriceQuoter(ctrl)
 			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
 
-			tc.mockBehavior(mockAlbumRepository)
+			tc.mockBehavior(mockAlbumRepository, mockTrackRepository, mockPriceQuoter)
 
-			s := service.New(mockAlbumRepository, mockGenresRepository, mockTransactor)
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, mockPriceQuoter, nil, mockTransactor)
 
-			out, err := s.FindById(ctx, id)
+			out, err := s.FindById(ctx, id, tc.currency)
 
 			assert.ErrorIs(t, err, tc.wantErr)
 			assert.Equal(t, tc.want, out)
@@ -280,36 +520,90 @@ func TestDeleteById(t *testing.T) {
 		arbitraryErr = errors.New("arbitrary error")
 		ctx          = context.Background()
 		id           = int64(1)
+		actorID      = int64(42)
 	)
 
-	type MockBehavior func(r *mock_albums.MockAlbumRepository)
+	type MockBehavior func(ctx context.Context, r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	album := entity.Album{ID: id, Title: "title", ArtistID: 7, Artist: "artist", Price: entity.NewMoney(10000, "USD")}
+
+	withinTransaction := func(ctx context.Context, t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
 
 	for _, tc := range []struct {
 		name         string
+		ctx          context.Context
 		mockBehavior MockBehavior
 		wantErr      error
 	}{
 		{
 			name: "success",
-			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
+			ctx:  ctx,
+			mockBehavior: func(ctx context.Context, r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(ctx, t)
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
+				r.EXPECT().Delete(ctx, id).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionDelete,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   id,
+					Before:     album,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "records actor",
+			ctx:  auth.WithPrincipal(ctx, auth.Principal{UserID: actorID, Role: entity.RoleCatalogEditor}),
+			mockBehavior: func(ctx context.Context, r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(ctx, t)
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
 				r.EXPECT().Delete(ctx, id).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					ActorID:    &actorID,
+					Action:     entity.AuditActionDelete,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   id,
+					Before:     album,
+				}).Return(nil)
 			},
 			wantErr: nil,
 		},
 		{
 			name: "album not found",
-			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
-				r.EXPECT().Delete(ctx, id).Return(repo.ErrAlbumNotFound)
+			ctx:  ctx,
+			mockBehavior: func(ctx context.Context, r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(ctx, t)
+				r.EXPECT().FindById(ctx, id).Return(entity.Album{}, repo.ErrAlbumNotFound)
 			},
 			wantErr: service.ErrAlbumNotFound,
 		},
 		{
 			name: "cannot fetch album",
-			mockBehavior: func(r *mock_albums.MockAlbumRepository) {
+			ctx:  ctx,
+			mockBehavior: func(ctx context.Context, r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(ctx, t)
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
 				r.EXPECT().Delete(ctx, id).Return(arbitraryErr)
 			},
 			wantErr: service.ErrFindingAlbum,
 		},
+		{
+			name: "cannot write audit event",
+			ctx:  ctx,
+			mockBehavior: func(ctx context.Context, r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(ctx, t)
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
+				r.EXPECT().Delete(ctx, id).Return(nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrFindingAlbum,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -317,13 +611,615 @@ func TestDeleteById(t *testing.T) {
 
 			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
 			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
 			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
 
-			tc.mockBehavior(mockAlbumRepository)
+			tc.mockBehavior(tc.ctx, mockAlbumRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, nil, nil, mockTransactor)
+
+			err := s.DeleteById(tc.ctx, id)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		id           = int64(1)
+	)
+
+	type MockBehavior func(r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	album := entity.Album{ID: id, Title: "title", ArtistID: 7, Artist: "artist", Price: entity.NewMoney(10000, "USD")}
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Restore(ctx, id).Return(nil)
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionRestore,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   id,
+					After:      album,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "album not found",
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Restore(ctx, id).Return(repo.ErrAlbumNotFound)
+			},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name: "cannot restore album",
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Restore(ctx, id).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotRestoreAlbum,
+		},
+		{
+			name: "cannot write audit event",
+			mockBehavior: func(r *mock_albums.MockAlbumRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Restore(ctx, id).Return(nil)
+				r.EXPECT().FindById(ctx, id).Return(album, nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotRestoreAlbum,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
+			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockAlbumRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, nil, nil, mockTransactor)
+
+			err := s.Restore(ctx, id)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+		genreIDs     = []int64{1, 2}
+	)
+
+	type MockBehavior func(
+		a *mock_albums.MockAlbumRepository,
+		g *mock_genres.MockGenreRepository,
+		ar *mock_artists.MockArtistRepository,
+		w *mock_albums.MockAuditWriter,
+		t *mock_transactor.MockTransactor,
+	)
+
+	album := entity.Album{
+		ID:     albumID,
+		Title:  "title",
+		Artist: "artist",
+		Price:  entity.NewMoney(10000, "USD"),
+		Genres: []entity.Genre{
+			{ID: 1},
+			{ID: 2},
+		},
+	}
+
+	artist := entity.Artist{ID: 7, Name: album.Artist}
+
+	resolved := album
+	resolved.ArtistID = artist.ID
+
+	stored := entity.Album{
+		ID:       albumID,
+		Title:    "old title",
+		ArtistID: 3,
+		Artist:   "old artist",
+		Price:    entity.NewMoney(9000, "USD"),
+		Genres:   []entity.Genre{{ID: 1, Name: "rock"}},
+	}
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(stored, nil)
+				a_repo.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Update(ctx, resolved).Return(nil)
+				a_repo.EXPECT().UpdatePrice(ctx, albumID, album.Price).Return(nil)
+				g_repo.EXPECT().RemoveGenresFromAlbum(ctx, albumID).Return(nil)
+				g_repo.EXPECT().AddGenresToAlbum(ctx, albumID, genreIDs).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUpdate,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					Before:     stored,
+					After:      resolved,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "price of cheapest sku",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(stored, nil)
+				a_repo.EXPECT().FindSKUPrice(ctx, albumID).Return(album.Price, true, nil)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Update(ctx, resolved).Return(nil)
+				a_repo.EXPECT().UpdatePrice(ctx, albumID, album.Price).Return(nil)
+				g_repo.EXPECT().RemoveGenresFromAlbum(ctx, albumID).Return(nil)
+				g_repo.EXPECT().AddGenresToAlbum(ctx, albumID, genreIDs).Return(nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "price differs from cheapest sku",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(stored, nil)
+				a_repo.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.NewMoney(9000, "USD"), true, nil)
+			},
+			wantErr: service.ErrPriceDerivedFromSKUs,
+		},
+		{
+			name: "album not found",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(entity.Album{}, repo.ErrAlbumNotFound)
+			},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name: "cannot remove genres",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(stored, nil)
+				a_repo.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Update(ctx, resolved).Return(nil)
+				a_repo.EXPECT().UpdatePrice(ctx, albumID, album.Price).Return(nil)
+				g_repo.EXPECT().RemoveGenresFromAlbum(ctx, albumID).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotUpdateAlbum,
+		},
+		{
+			name: "genre not exists",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(stored, nil)
+				a_repo.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Update(ctx, resolved).Return(nil)
+				a_repo.EXPECT().UpdatePrice(ctx, albumID, album.Price).Return(nil)
+				g_repo.EXPECT().RemoveGenresFromAlbum(ctx, albumID).Return(nil)
+				g_repo.EXPECT().AddGenresToAlbum(ctx, albumID, genreIDs).Return(repo.ErrAddAlbumGenreConstraintFail)
+			},
+			wantErr: service.ErrGenreNotExists,
+		},
+		{
+			name: "cannot write audit event",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(stored, nil)
+				a_repo.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+				ar_repo.EXPECT().FindOrCreate(ctx, album.Artist).Return(artist, nil)
+				a_repo.EXPECT().Update(ctx, resolved).Return(nil)
+				a_repo.EXPECT().UpdatePrice(ctx, albumID, album.Price).Return(nil)
+				g_repo.EXPECT().RemoveGenresFromAlbum(ctx, albumID).Return(nil)
+				g_repo.EXPECT().AddGenresToAlbum(ctx, albumID, genreIDs).Return(nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotUpdateAlbum,
+		},
+		{
+			name: "transaction error",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				t.EXPECT().WithinTransaction(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotUpdateAlbum,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
+			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, nil, nil, mockTransactor)
+
+			err := s.Update(ctx, album)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestPatch(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+		newTitle     = "new title"
+		newPrice     = entity.NewMoney(12000, "USD")
+		negative     = entity.NewMoney(-1, "USD")
+		artistID     = int64(7)
+	)
+
+	type MockBehavior func(
+		a *mock_albums.MockAlbumRepository,
+		g *mock_genres.MockGenreRepository,
+		ar *mock_artists.MockArtistRepository,
+		w *mock_albums.MockAuditWriter,
+		t *mock_transactor.MockTransactor,
+	)
+
+	album := entity.Album{
+		ID:     albumID,
+		Title:  "title",
+		Artist: "artist",
+		Price:  entity.NewMoney(10000, "USD"),
+	}
+
+	patched := album
+	patched.Title = newTitle
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		patch        entity.AlbumPatch
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name:  "success without genres",
+			patch: entity.AlbumPatch{Title: &newTitle},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(album, nil)
+				a_repo.EXPECT().Update(ctx, patched).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUpdate,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					Before:     album,
+					After:      patched,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "success clearing genres",
+			patch: entity.AlbumPatch{Genres: []entity.Genre{}},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(album, nil)
+				cleared := album
+				cleared.Genres = []entity.Genre{}
+				a_repo.EXPECT().Update(ctx, cleared).Return(nil)
+				g_repo.EXPECT().RemoveGenresFromAlbum(ctx, albumID).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUpdate,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					Before:     album,
+					After:      cleared,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "success changing artist",
+			patch: entity.AlbumPatch{ArtistID: &artistID},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(album, nil)
+				ar_repo.EXPECT().FindById(ctx, artistID).Return(entity.Artist{ID: artistID, Name: "new artist"}, nil)
+
+				withArtist := album
+				withArtist.ArtistID = artistID
+				withArtist.Artist = "new artist"
+				a_repo.EXPECT().Update(ctx, withArtist).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUpdate,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					Before:     album,
+					After:      withArtist,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "artist not exists",
+			patch: entity.AlbumPatch{ArtistID: &artistID},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(album, nil)
+				ar_repo.EXPECT().FindById(ctx, artistID).Return(entity.Artist{}, repo.ErrArtistNotFound)
+			},
+			wantErr: service.ErrArtistNotExists,
+		},
+		{
+			name:  "success changing price",
+			patch: entity.AlbumPatch{Price: &newPrice},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(album, nil)
+				a_repo.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+
+				repriced := album
+				repriced.Price = newPrice
+				a_repo.EXPECT().Update(ctx, repriced).Return(nil)
+				a_repo.EXPECT().UpdatePrice(ctx, albumID, newPrice).Return(nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "price of album with skus",
+			patch: entity.AlbumPatch{Price: &newPrice},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(album, nil)
+				a_repo.EXPECT().FindSKUPrice(ctx, albumID).Return(album.Price, true, nil)
+			},
+			wantErr: service.ErrPriceDerivedFromSKUs,
+		},
+		{
+			name:  "negative price",
+			patch: entity.AlbumPatch{Price: &negative},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(album, nil)
+			},
+			wantErr: entity.ErrInvalidMoney,
+		},
+		{
+			name:  "album deleted before lock",
+			patch: entity.AlbumPatch{Title: &newTitle},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(repo.ErrAlbumNotFound)
+			},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name:  "album not found",
+			patch: entity.AlbumPatch{Title: &newTitle},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(entity.Album{}, repo.ErrAlbumNotFound)
+			},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name:  "cannot update album",
+			patch: entity.AlbumPatch{Title: &newTitle},
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, g_repo *mock_genres.MockGenreRepository, ar_repo *mock_artists.MockArtistRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().Lock(ctx, albumID).Return(nil)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(album, nil)
+				a_repo.EXPECT().Update(ctx, patched).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotUpdateAlbum,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
+			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, nil, nil, mockTransactor)
+
+			err := s.Patch(ctx, albumID, tc.patch)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestReplaceTracks(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+	)
+
+	type MockBehavior func(
+		a *mock_albums.MockAlbumRepository,
+		tr *mock_albums.MockTrackRepository,
+		w *mock_albums.MockAuditWriter,
+		t *mock_transactor.MockTransactor,
+	)
+
+	tracks := []entity.Track{
+		{DiscNumber: 1, Position: 1, Title: "track 1", Duration: 3 * time.Minute},
+		{DiscNumber: 1, Position: 2, Title: "track 2", Duration: 4 * time.Minute, ISRC: "USRC17607839"},
+	}
+
+	before := []entity.Track{
+		{ID: 10, AlbumID: albumID, DiscNumber: 1, Position: 1, Title: "old track", Duration: 2 * time.Minute},
+	}
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, tr_repo *mock_albums.MockTrackRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				tr_repo.EXPECT().FindByAlbumID(ctx, albumID).Return(before, nil)
+				tr_repo.EXPECT().DeleteByAlbumID(ctx, albumID).Return(nil)
+				tr_repo.EXPECT().Create(ctx, albumID, tracks).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUpdate,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					Before:     before,
+					After:      tracks,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "album not found",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, tr_repo *mock_albums.MockTrackRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(entity.Album{}, repo.ErrAlbumNotFound)
+			},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name: "duplicate track position",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, tr_repo *mock_albums.MockTrackRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				tr_repo.EXPECT().FindByAlbumID(ctx, albumID).Return(before, nil)
+				tr_repo.EXPECT().DeleteByAlbumID(ctx, albumID).Return(nil)
+				tr_repo.EXPECT().Create(ctx, albumID, tracks).Return(repo.ErrDuplicateTrackPosition)
+			},
+			wantErr: service.ErrDuplicateTrackPosition,
+		},
+		{
+			name: "cannot delete tracks",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, tr_repo *mock_albums.MockTrackRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				tr_repo.EXPECT().FindByAlbumID(ctx, albumID).Return(before, nil)
+				tr_repo.EXPECT().DeleteByAlbumID(ctx, albumID).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotReplaceTracks,
+		},
+		{
+			name: "cannot write audit event",
+			mockBehavior: func(a_repo *mock_albums.MockAlbumRepository, tr_repo *mock_albums.MockTrackRepository, w *mock_albums.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a_repo.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				tr_repo.EXPECT().FindByAlbumID(ctx, albumID).Return(before, nil)
+				tr_repo.EXPECT().DeleteByAlbumID(ctx, albumID).Return(nil)
+				tr_repo.EXPECT().Create(ctx, albumID, tracks).Return(nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotReplaceTracks,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockAlbumRepository := mock_albums.NewMockAlbumRepository(ctrl)
+			mockGenresRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTrackRepository := mock_albums.NewMockTrackRepository(ctrl)
+			mockAuditWriter := mock_albums.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockAlbumRepository, mockTrackRepository, mockAuditWriter, mockTransactor)
 
-			s := service.New(mockAlbumRepository, mockGenresRepository, mockTransactor)
+			s := service.New(mockAlbumRepository, mockGenresRepository, mockArtistRepository, mockTrackRepository, mockAuditWriter, nil, nil, mockTransactor)
 
-			err := s.DeleteById(ctx, id)
+			err := s.ReplaceTracks(ctx, albumID, tracks)
 
 			assert.ErrorIs(t, err, tc.wantErr)
 		})
@@ -0,0 +1,16 @@
+package artists
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type ArtistRepository interface {
+	Create(ctx context.Context, artist entity.Artist) (int64, error)
+	FindOrCreate(ctx context.Context, name string) (entity.Artist, error)
+	FindAll(ctx context.Context) ([]entity.Artist, error)
+	FindById(ctx context.Context, id int64) (entity.Artist, error)
+	Update(ctx context.Context, artist entity.Artist) error
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,13 @@
+package artists
+
+import "errors"
+
+var (
+	ErrCannotCreateArtist  = errors.New("cannot create artist")
+	ErrArtistAlreadyExists = errors.New("artist already exists")
+	ErrCannotFetchArtists  = errors.New("cannot fetch artists")
+	ErrArtistNotFound      = errors.New("artist not found")
+	ErrCannotUpdateArtist  = errors.New("cannot update artist")
+	ErrCannotDeleteArtist  = errors.New("cannot delete artist")
+	ErrArtistHasAlbums     = errors.New("artist has albums")
+)
@@ -0,0 +1,99 @@
+package artists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/4udiwe/musicshop/pkg/transactor"
+)
+
+type Service struct {
+	artistRepository ArtistRepository
+	txManager        transactor.Transactor
+}
+
+func New(r ArtistRepository, t transactor.Transactor) *Service {
+	return &Service{
+		artistRepository: r,
+		txManager:        t,
+	}
+}
+
+func (s *Service) Create(ctx context.Context, artist entity.Artist) (int64, error) {
+	ctx, span := tracing.Start(ctx, "artists.Create")
+	defer span.End()
+
+	id, err := s.artistRepository.Create(ctx, artist)
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistAlreadyExists) {
+			return 0, fmt.Errorf("%w: %w", ErrArtistAlreadyExists, err)
+		}
+		return 0, fmt.Errorf("%w: %w", ErrCannotCreateArtist, err)
+	}
+	return id, nil
+}
+
+func (s *Service) FindAll(ctx context.Context) ([]entity.Artist, error) {
+	ctx, span := tracing.Start(ctx, "artists.FindAll")
+	defer span.End()
+
+	artists, err := s.artistRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotFetchArtists, err)
+	}
+	return artists, nil
+}
+
+func (s *Service) FindById(ctx context.Context, id int64) (entity.Artist, error) {
+	ctx, span := tracing.Start(ctx, "artists.FindById")
+	defer span.End()
+
+	artist, err := s.artistRepository.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return entity.Artist{}, fmt.Errorf("%w: %w", ErrArtistNotFound, err)
+		}
+		return entity.Artist{}, fmt.Errorf("%w: %w", ErrCannotFetchArtists, err)
+	}
+	return artist, nil
+}
+
+func (s *Service) Update(ctx context.Context, artist entity.Artist) error {
+	ctx, span := tracing.Start(ctx, "artists.Update")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.artistRepository.Update(ctx, artist)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return fmt.Errorf("%w: %w", ErrArtistNotFound, err)
+		}
+		if errors.Is(err, repo.ErrArtistAlreadyExists) {
+			return fmt.Errorf("%w: %w", ErrArtistAlreadyExists, err)
+		}
+		return fmt.Errorf("%w: %w", ErrCannotUpdateArtist, err)
+	}
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "artists.Delete")
+	defer span.End()
+
+	err := s.artistRepository.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return fmt.Errorf("%w: %w", ErrArtistNotFound, err)
+		}
+		if errors.Is(err, repo.ErrArtistHasAlbums) {
+			return fmt.Errorf("%w: %w", ErrArtistHasAlbums, err)
+		}
+		return fmt.Errorf("%w: %w", ErrCannotDeleteArtist, err)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package artists_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_artists"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_transactor"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	service "github.com/4udiwe/musicshop/internal/service/artists"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+	)
+
+	type MockBehavior func(r *mock_artists.MockArtistRepository)
+
+	artist := entity.Artist{
+		Name: "artist",
+		Bio:  "bio",
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         int64
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().Create(ctx, artist).Return(int64(1), nil)
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "artist already exists",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().Create(ctx, artist).Return(int64(0), repo.ErrArtistAlreadyExists)
+			},
+			want:    0,
+			wantErr: service.ErrArtistAlreadyExists,
+		},
+		{
+			name: "cannot create artist",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().Create(ctx, artist).Return(int64(0), arbitraryErr)
+			},
+			want:    0,
+			wantErr: service.ErrCannotCreateArtist,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockArtistRepository)
+
+			s := service.New(mockArtistRepository, mockTransactor)
+
+			out, err := s.Create(ctx, artist)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestFindById(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		id           = int64(1)
+	)
+
+	type MockBehavior func(r *mock_artists.MockArtistRepository)
+
+	artist := entity.Artist{
+		ID:   id,
+		Name: "artist",
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         entity.Artist
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().FindById(ctx, id).Return(artist, nil)
+			},
+			want:    artist,
+			wantErr: nil,
+		},
+		{
+			name: "artist not found",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().FindById(ctx, id).Return(entity.Artist{}, repo.ErrArtistNotFound)
+			},
+			want:    entity.Artist{},
+			wantErr: service.ErrArtistNotFound,
+		},
+		{
+			name: "cannot fetch artist",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().FindById(ctx, id).Return(entity.Artist{}, arbitraryErr)
+			},
+			want:    entity.Artist{},
+			wantErr: service.ErrCannotFetchArtists,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockArtistRepository)
+
+			s := service.New(mockArtistRepository, mockTransactor)
+
+			out, err := s.FindById(ctx, id)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+	)
+
+	type MockBehavior func(r *mock_artists.MockArtistRepository, t *mock_transactor.MockTransactor)
+
+	artist := entity.Artist{
+		ID:   1,
+		Name: "artist",
+	}
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_artists.MockArtistRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Update(ctx, artist).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "artist not found",
+			mockBehavior: func(r *mock_artists.MockArtistRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Update(ctx, artist).Return(repo.ErrArtistNotFound)
+			},
+			wantErr: service.ErrArtistNotFound,
+		},
+		{
+			name: "artist already exists",
+			mockBehavior: func(r *mock_artists.MockArtistRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Update(ctx, artist).Return(repo.ErrArtistAlreadyExists)
+			},
+			wantErr: service.ErrArtistAlreadyExists,
+		},
+		{
+			name: "cannot update artist",
+			mockBehavior: func(r *mock_artists.MockArtistRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Update(ctx, artist).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotUpdateArtist,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockArtistRepository, mockTransactor)
+
+			s := service.New(mockArtistRepository, mockTransactor)
+
+			err := s.Update(ctx, artist)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		id           = int64(1)
+	)
+
+	type MockBehavior func(r *mock_artists.MockArtistRepository)
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().Delete(ctx, id).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "artist not found",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().Delete(ctx, id).Return(repo.ErrArtistNotFound)
+			},
+			wantErr: service.ErrArtistNotFound,
+		},
+		{
+			name: "artist has albums",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().Delete(ctx, id).Return(repo.ErrArtistHasAlbums)
+			},
+			wantErr: service.ErrArtistHasAlbums,
+		},
+		{
+			name: "cannot delete artist",
+			mockBehavior: func(r *mock_artists.MockArtistRepository) {
+				r.EXPECT().Delete(ctx, id).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotDeleteArtist,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockArtistRepository := mock_artists.NewMockArtistRepository(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockArtistRepository)
+
+			s := service.New(mockArtistRepository, mockTransactor)
+
+			err := s.Delete(ctx, id)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
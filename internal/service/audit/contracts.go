@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type AuditRepository interface {
+	FindAll(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEvent, error)
+}
@@ -0,0 +1,7 @@
+package audit
+
+import "errors"
+
+var (
+	ErrCannotFetchAuditEvents = errors.New("cannot fetch audit events")
+)
@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/tracing"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+type Service struct {
+	auditRepository AuditRepository
+}
+
+func New(r AuditRepository) *Service {
+	return &Service{
+		auditRepository: r,
+	}
+}
+
+// FindAll возвращает страницу журнала изменений, новые события первыми
+func (s *Service) FindAll(ctx context.Context, filter entity.AuditFilter) (entity.AuditPage, error) {
+	ctx, span := tracing.Start(ctx, "audit.FindAll")
+	defer span.End()
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	limit = min(limit, MaxPageLimit)
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	filter.Limit = limit + 1
+	events, err := s.auditRepository.FindAll(ctx, filter)
+	if err != nil {
+		return entity.AuditPage{}, fmt.Errorf("%w: %w", ErrCannotFetchAuditEvents, err)
+	}
+
+	page := entity.AuditPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextCursor = events[limit-1].ID
+	}
+
+	return page, nil
+}
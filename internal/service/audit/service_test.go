@@ -0,0 +1,98 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_audit"
+	service "github.com/4udiwe/musicshop/internal/service/audit"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindAll(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+	)
+
+	type MockBehavior func(r *mock_audit.MockAuditRepository)
+
+	events := []entity.AuditEvent{
+		{ID: 30, Action: entity.AuditActionDelete, EntityType: entity.AuditEntityAlbum, EntityID: 1},
+		{ID: 20, Action: entity.AuditActionUpdate, EntityType: entity.AuditEntityAlbum, EntityID: 1},
+		{ID: 10, Action: entity.AuditActionCreate, EntityType: entity.AuditEntityAlbum, EntityID: 1},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		filter       entity.AuditFilter
+		mockBehavior MockBehavior
+		want         entity.AuditPage
+		wantErr      error
+	}{
+		{
+			name:   "last page",
+			filter: entity.AuditFilter{EntityType: entity.AuditEntityAlbum, EntityID: 1},
+			mockBehavior: func(r *mock_audit.MockAuditRepository) {
+				r.EXPECT().FindAll(ctx, entity.AuditFilter{
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   1,
+					Limit:      service.DefaultPageLimit + 1,
+				}).Return(events, nil)
+			},
+			want:    entity.AuditPage{Events: events},
+			wantErr: nil,
+		},
+		{
+			name:   "has next page",
+			filter: entity.AuditFilter{EntityType: entity.AuditEntityAlbum, EntityID: 1, Limit: 2, Cursor: 40},
+			mockBehavior: func(r *mock_audit.MockAuditRepository) {
+				r.EXPECT().FindAll(ctx, entity.AuditFilter{
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   1,
+					Limit:      3,
+					Cursor:     40,
+				}).Return(events, nil)
+			},
+			want:    entity.AuditPage{Events: events[:2], NextCursor: 20},
+			wantErr: nil,
+		},
+		{
+			name:   "limit is capped",
+			filter: entity.AuditFilter{Limit: 1000},
+			mockBehavior: func(r *mock_audit.MockAuditRepository) {
+				r.EXPECT().FindAll(ctx, entity.AuditFilter{Limit: service.MaxPageLimit + 1}).Return(events, nil)
+			},
+			want:    entity.AuditPage{Events: events},
+			wantErr: nil,
+		},
+		{
+			name:   "cannot fetch audit events",
+			filter: entity.AuditFilter{},
+			mockBehavior: func(r *mock_audit.MockAuditRepository) {
+				r.EXPECT().FindAll(ctx, gomock.Any()).Return(nil, arbitraryErr)
+			},
+			want:    entity.AuditPage{},
+			wantErr: service.ErrCannotFetchAuditEvents,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockAuditRepository := mock_audit.NewMockAuditRepository(ctrl)
+
+			tc.mockBehavior(mockAuditRepository)
+
+			s := service.New(mockAuditRepository)
+
+			out, err := s.FindAll(ctx, tc.filter)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
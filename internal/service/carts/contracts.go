@@ -0,0 +1,21 @@
+package carts
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type CartRepository interface {
+	Create(ctx context.Context, userID int64) (int64, error)
+	FindById(ctx context.Context, id int64) (entity.Cart, error)
+	FindOwner(ctx context.Context, id int64) (int64, error)
+	SetItem(ctx context.Context, cartID, albumID int64, format string, quantity int) error
+	RemoveItem(ctx context.Context, cartID, albumID int64) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// PriceQuoter переводит базовые цены альбомов в другую валюту
+type PriceQuoter interface {
+	Quote(ctx context.Context, currency string, prices map[int64]entity.Money) (map[int64]entity.Money, error)
+}
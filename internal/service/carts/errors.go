@@ -0,0 +1,12 @@
+package carts
+
+import "errors"
+
+var (
+	ErrCannotCreateCart = errors.New("cannot create cart")
+	ErrCartNotFound     = errors.New("cart not found")
+	ErrCannotFetchCart  = errors.New("cannot fetch cart")
+	ErrAlbumNotFound    = errors.New("album not found")
+	ErrSKUNotFound      = errors.New("album is not sold in given format")
+	ErrCannotUpdateCart = errors.New("cannot update cart")
+)
@@ -0,0 +1,140 @@
+package carts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/samber/lo"
+)
+
+type Service struct {
+	cartRepository CartRepository
+	priceQuoter    PriceQuoter
+}
+
+func New(r CartRepository, q PriceQuoter) *Service {
+	return &Service{
+		cartRepository: r,
+		priceQuoter:    q,
+	}
+}
+
+// Create создает пустую корзину пользователя запроса
+func (s *Service) Create(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "carts.Create")
+	defer span.End()
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return 0, fmt.Errorf("%w: anonymous request", ErrCannotCreateCart)
+	}
+
+	id, err := s.cartRepository.Create(ctx, principal.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCannotCreateCart, err)
+	}
+	return id, nil
+}
+
+// FindById возвращает корзину с ценами в валюте currency, пустая валюта - базовая.
+// Чужая корзина не отличается от несуществующей.
+func (s *Service) FindById(ctx context.Context, id int64, currency string) (entity.Cart, error) {
+	ctx, span := tracing.Start(ctx, "carts.FindById")
+	defer span.End()
+
+	cart, err := s.cartRepository.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrCartNotFound) {
+			return entity.Cart{}, fmt.Errorf("%w: %w", ErrCartNotFound, err)
+		}
+		return entity.Cart{}, fmt.Errorf("%w: %w", ErrCannotFetchCart, err)
+	}
+	if !auth.IsUser(ctx, cart.UserID) {
+		return entity.Cart{}, fmt.Errorf("%w: cart with id %d belongs to another user", ErrCartNotFound, id)
+	}
+
+	cart, err = s.localize(ctx, cart, currency)
+	if err != nil {
+		return entity.Cart{}, fmt.Errorf("%w: %w", ErrCannotFetchCart, err)
+	}
+	return cart, nil
+}
+
+// localize переводит цены позиций корзины в валюту currency
+func (s *Service) localize(ctx context.Context, cart entity.Cart, currency string) (entity.Cart, error) {
+	if currency == "" || currency == cart.Currency {
+		return cart, nil
+	}
+
+	prices, err := s.priceQuoter.Quote(ctx, currency, lo.SliceToMap(cart.Items, func(item entity.CartItem) (int64, entity.Money) {
+		return item.AlbumID, item.Price
+	}))
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	cart.Currency = currency
+	cart.Items = lo.Map(cart.Items, func(item entity.CartItem, _ int) entity.CartItem {
+		item.Price = prices[item.AlbumID]
+		return item
+	})
+	return cart, nil
+}
+
+// SetItem кладет альбом в корзину в формате format и указанном количестве, заменяя прежние.
+// Пустой format - самый дешевый формат альбома.
+func (s *Service) SetItem(ctx context.Context, cartID, albumID int64, format string, quantity int) error {
+	ctx, span := tracing.Start(ctx, "carts.SetItem")
+	defer span.End()
+
+	if err := s.authorize(ctx, cartID); err != nil {
+		return mapItemError(err)
+	}
+	if err := s.cartRepository.SetItem(ctx, cartID, albumID, format, quantity); err != nil {
+		return mapItemError(err)
+	}
+	return nil
+}
+
+func (s *Service) RemoveItem(ctx context.Context, cartID, albumID int64) error {
+	ctx, span := tracing.Start(ctx, "carts.RemoveItem")
+	defer span.End()
+
+	if err := s.authorize(ctx, cartID); err != nil {
+		return mapItemError(err)
+	}
+	if err := s.cartRepository.RemoveItem(ctx, cartID, albumID); err != nil {
+		return mapItemError(err)
+	}
+	return nil
+}
+
+// authorize проверяет, что корзина принадлежит пользователю запроса
+func (s *Service) authorize(ctx context.Context, cartID int64) error {
+	owner, err := s.cartRepository.FindOwner(ctx, cartID)
+	if err != nil {
+		return err
+	}
+	if !auth.IsUser(ctx, owner) {
+		return fmt.Errorf("%w: cart with id %d belongs to another user", repo.ErrCartNotFound, cartID)
+	}
+	return nil
+}
+
+func mapItemError(err error) error {
+	if errors.Is(err, repo.ErrCartNotFound) {
+		return fmt.Errorf("%w: %w", ErrCartNotFound, err)
+	}
+	if errors.Is(err, repo.ErrAlbumNotFound) {
+		return fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+	}
+	if errors.Is(err, repo.ErrSKUNotFound) {
+		return fmt.Errorf("%w: %w", ErrSKUNotFound, err)
+	}
+	return fmt.Errorf("%w: %w", ErrCannotUpdateCart, err)
+}
@@ -0,0 +1,203 @@
+package carts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_carts"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	service "github.com/4udiwe/musicshop/internal/service/carts"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindById(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		userID       = int64(5)
+		ctx          = auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID, Role: entity.RoleCustomer})
+		cartID       = int64(1)
+	)
+
+	type MockBehavior func(r *mock_carts.MockCartRepository, q *mock_carts.MockPriceQuoter)
+
+	cart := entity.Cart{
+		ID:       cartID,
+		UserID:   userID,
+		Currency: entity.BaseCurrency,
+		Items: []entity.CartItem{
+			{AlbumID: 1, Title: "Kind of Blue", Artist: "Miles Davis", Price: entity.NewMoney(2000, "USD"), Quantity: 2},
+		},
+	}
+	eurCart := entity.Cart{
+		ID:       cartID,
+		UserID:   userID,
+		Currency: "EUR",
+		Items: []entity.CartItem{
+			{AlbumID: 1, Title: "Kind of Blue", Artist: "Miles Davis", Price: entity.NewMoney(1840, "EUR"), Quantity: 2},
+		},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		currency     string
+		mockBehavior MockBehavior
+		want         entity.Cart
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_carts.MockCartRepository, q *mock_carts.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+			},
+			want:    cart,
+			wantErr: nil,
+		},
+		{
+			name:     "success in other currency",
+			currency: "EUR",
+			mockBehavior: func(r *mock_carts.MockCartRepository, q *mock_carts.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				q.EXPECT().Quote(ctx, "EUR", map[int64]entity.Money{1: entity.NewMoney(2000, "USD")}).
+					Return(map[int64]entity.Money{1: entity.NewMoney(1840, "EUR")}, nil)
+			},
+			want:    eurCart,
+			wantErr: nil,
+		},
+		{
+			name:     "unsupported currency",
+			currency: "XXX",
+			mockBehavior: func(r *mock_carts.MockCartRepository, q *mock_carts.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				q.EXPECT().Quote(ctx, "XXX", gomock.Any()).Return(nil, entity.ErrUnsupportedCurrency)
+			},
+			want:    entity.Cart{},
+			wantErr: entity.ErrUnsupportedCurrency,
+		},
+		{
+			name: "cart of another user",
+			mockBehavior: func(r *mock_carts.MockCartRepository, q *mock_carts.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, cartID).Return(entity.Cart{ID: cartID, UserID: userID + 1, Currency: entity.BaseCurrency}, nil)
+			},
+			want:    entity.Cart{},
+			wantErr: service.ErrCartNotFound,
+		},
+		{
+			name: "cart not found",
+			mockBehavior: func(r *mock_carts.MockCartRepository, q *mock_carts.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, cartID).Return(entity.Cart{}, repo.ErrCartNotFound)
+			},
+			want:    entity.Cart{},
+			wantErr: service.ErrCartNotFound,
+		},
+		{
+			name: "cannot fetch cart",
+			mockBehavior: func(r *mock_carts.MockCartRepository, q *mock_carts.MockPriceQuoter) {
+				r.EXPECT().FindById(ctx, cartID).Return(entity.Cart{}, arbitraryErr)
+			},
+			want:    entity.Cart{},
+			wantErr: service.ErrCannotFetchCart,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockCartRepository := mock_carts.NewMockCartRepository(ctrl)
+			mockPriceQuoter := mock_carts.NewMockPriceQuoter(ctrl)
+
+			tc.mockBehavior(mockCartRepository, mockPriceQuoter)
+
+			s := service.New(mockCartRepository, mockPriceQuoter)
+
+			out, err := s.FindById(ctx, cartID, tc.currency)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestSetItem(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		userID       = int64(5)
+		ctx          = auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID, Role: entity.RoleCustomer})
+		cartID       = int64(1)
+		albumID      = int64(2)
+		quantity     = 3
+	)
+
+	type MockBehavior func(r *mock_carts.MockCartRepository)
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_carts.MockCartRepository) {
+				r.EXPECT().FindOwner(ctx, cartID).Return(userID, nil)
+				r.EXPECT().SetItem(ctx, cartID, albumID, entity.FormatVinyl, quantity).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "cart not found",
+			mockBehavior: func(r *mock_carts.MockCartRepository) {
+				r.EXPECT().FindOwner(ctx, cartID).Return(int64(0), repo.ErrCartNotFound)
+			},
+			wantErr: service.ErrCartNotFound,
+		},
+		{
+			name: "cart of another user",
+			mockBehavior: func(r *mock_carts.MockCartRepository) {
+				r.EXPECT().FindOwner(ctx, cartID).Return(userID+1, nil)
+			},
+			wantErr: service.ErrCartNotFound,
+		},
+		{
+			name: "album not found",
+			mockBehavior: func(r *mock_carts.MockCartRepository) {
+				r.EXPECT().FindOwner(ctx, cartID).Return(userID, nil)
+				r.EXPECT().SetItem(ctx, cartID, albumID, entity.FormatVinyl, quantity).Return(repo.ErrAlbumNotFound)
+			},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name: "format is not sold",
+			mockBehavior: func(r *mock_carts.MockCartRepository) {
+				r.EXPECT().FindOwner(ctx, cartID).Return(userID, nil)
+				r.EXPECT().SetItem(ctx, cartID, albumID, entity.FormatVinyl, quantity).Return(repo.ErrSKUNotFound)
+			},
+			wantErr: service.ErrSKUNotFound,
+		},
+		{
+			name: "cannot update cart",
+			mockBehavior: func(r *mock_carts.MockCartRepository) {
+				r.EXPECT().FindOwner(ctx, cartID).Return(userID, nil)
+				r.EXPECT().SetItem(ctx, cartID, albumID, entity.FormatVinyl, quantity).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotUpdateCart,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockCartRepository := mock_carts.NewMockCartRepository(ctrl)
+
+			tc.mockBehavior(mockCartRepository)
+
+			s := service.New(mockCartRepository, nil)
+
+			err := s.SetItem(ctx, cartID, albumID, entity.FormatVinyl, quantity)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
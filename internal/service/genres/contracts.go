@@ -2,6 +2,7 @@ package genres
 
 import (
 	"context"
+	"time"
 
 	"github.com/4udiwe/musicshop/internal/entity"
 )
@@ -9,6 +10,27 @@ import (
 type GenreRepository interface {
 	Create(ctx context.Context, genre entity.Genre) (int64, error)
 	AddGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) error
-	FindAll(ctx context.Context) ([]entity.Genre, error)
+	RemoveGenresFromAlbum(ctx context.Context, albumID int64) error
+	LinkGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error)
+	UnlinkGenreFromAlbum(ctx context.Context, albumID, genreID int64) error
+	RetainAlbumGenres(ctx context.Context, albumID int64, genreIDs ...int64) ([]int64, error)
+	FindAll(ctx context.Context, includeDeleted bool) ([]entity.Genre, error)
+	FindById(ctx context.Context, id int64) (entity.Genre, error)
+	Update(ctx context.Context, genre entity.Genre) error
 	Delete(ctx context.Context, id int64) error
+	FindSubtreeIDs(ctx context.Context, id int64) ([]int64, error)
+	ReparentChildren(ctx context.Context, id int64) error
+	DeleteSubtree(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AuditWriter пишет журнал изменений каталога в транзакции изменения
+type AuditWriter interface {
+	Write(ctx context.Context, event entity.AuditEvent) error
+}
+
+// EventCounter считает бизнес-события для метрик, вызывается после фиксации изменений
+type EventCounter interface {
+	GenresAttached(n int)
 }
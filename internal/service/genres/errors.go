@@ -3,11 +3,19 @@ package genres
 import "errors"
 
 var (
-	ErrCannotCreateGenre             = errors.New("cannot create genre")
-	ErrGenreAlreadyExists            = errors.New("genre already exists")
-	ErrCannotFetchGenres             = errors.New("cannot fetch genres")
-	ErrCannotAddConstraintAlbumGenre = errors.New("cannot add constraint benween album and genre")
-	ErrGenreNotFound                 = errors.New("genre not found")
-	ErrCannotDeleteGenre             = errors.New("cannot delete genre")
-	ErrCannotAddEmptyGenres          = errors.New("cannot add empty genres to album")
+	ErrCannotCreateGenre       = errors.New("cannot create genre")
+	ErrGenreAlreadyExists      = errors.New("genre already exists")
+	ErrCannotFetchGenres       = errors.New("cannot fetch genres")
+	ErrGenreNotFound           = errors.New("genre not found")
+	ErrCannotDeleteGenre       = errors.New("cannot delete genre")
+	ErrCannotUpdateGenre       = errors.New("cannot update genre")
+	ErrCannotAddEmptyGenres    = errors.New("cannot add empty genres to album")
+	ErrAlbumNotFound           = errors.New("album not found")
+	ErrAlbumGenreNotFound      = errors.New("genre is not linked to album")
+	ErrCannotUpdateAlbumGenres = errors.New("cannot update album genres")
+	ErrParentGenreNotFound     = errors.New("parent genre not found")
+	ErrGenreCycle              = errors.New("genre cannot be its own ancestor")
+	ErrGenreHasChildren        = errors.New("genre has subgenres")
+	ErrCannotRestoreGenre      = errors.New("cannot restore genre")
+	ErrCannotPurgeGenres       = errors.New("cannot purge deleted genres")
 )
@@ -3,63 +3,393 @@ package genres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/4udiwe/musicshop/internal/auth"
 	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/logger"
 	repo "github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
 	"github.com/4udiwe/musicshop/pkg/transactor"
-	"github.com/sirupsen/logrus"
+	"github.com/samber/lo"
 )
 
 type Service struct {
 	genreRepository GenreRepository
+	auditWriter     AuditWriter
+	eventCounter    EventCounter
 	txManager       transactor.Transactor
 }
 
-func New(r GenreRepository, t transactor.Transactor) *Service {
+func New(r GenreRepository, w AuditWriter, m EventCounter, t transactor.Transactor) *Service {
 	return &Service{
 		genreRepository: r,
+		auditWriter:     w,
+		eventCounter:    m,
 		txManager:       t,
 	}
 }
 
 func (s *Service) Create(ctx context.Context, genre entity.Genre) (int64, error) {
-	id, err := s.genreRepository.Create(ctx, genre)
+	ctx, span := tracing.Start(ctx, "genres.Create")
+	defer span.End()
+
+	var id int64
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if genre.ParentID != nil {
+			// внешний ключ не отличает удаленного родителя от существующего
+			if _, err := s.genreRepository.FindById(ctx, *genre.ParentID); err != nil {
+				if errors.Is(err, repo.ErrGenreNotFound) {
+					return fmt.Errorf("%w: %w", ErrParentGenreNotFound, err)
+				}
+				return err
+			}
+		}
+
+		var err error
+		id, err = s.genreRepository.Create(ctx, genre)
+		if err != nil {
+			return err
+		}
+		genre.ID = id
+		return s.record(ctx, entity.AuditActionCreate, entity.AuditEntityGenre, id, nil, genre)
+	})
 	if err != nil {
+		if errors.Is(err, ErrParentGenreNotFound) {
+			return 0, err
+		}
 		if errors.Is(err, repo.ErrGenreAlreadyExists) {
-			return 0, ErrGenreAlreadyExists
+			return 0, fmt.Errorf("%w: %w", ErrGenreAlreadyExists, err)
+		}
+		if errors.Is(err, repo.ErrParentGenreNotFound) {
+			return 0, fmt.Errorf("%w: %w", ErrParentGenreNotFound, err)
 		}
-		return 0, ErrCannotCreateGenre
+		return 0, fmt.Errorf("%w: %w", ErrCannotCreateGenre, err)
 	}
 	return id, nil
 }
 
-func (s *Service) FindAll(ctx context.Context) (genres []entity.Genre, err error) {
-	genres, err = s.genreRepository.FindAll(ctx)
+func (s *Service) FindAll(ctx context.Context, includeDeleted bool) (genres []entity.Genre, err error) {
+	ctx, span := tracing.Start(ctx, "genres.FindAll")
+	defer span.End()
+
+	genres, err = s.genreRepository.FindAll(ctx, includeDeleted)
 	if err != nil {
-		return nil, ErrCannotFetchGenres
+		return nil, fmt.Errorf("%w: %w", ErrCannotFetchGenres, err)
 	}
 	return genres, nil
 }
 
-func (s *Service) DeleteGenre(ctx context.Context, genreID int64) error {
-	err := s.genreRepository.Delete(ctx, genreID)
+// FindTree возвращает жанры в виде дерева, корни и поджанры упорядочены по id
+func (s *Service) FindTree(ctx context.Context, includeDeleted bool) ([]entity.GenreNode, error) {
+	ctx, span := tracing.Start(ctx, "genres.FindTree")
+	defer span.End()
+
+	genres, err := s.genreRepository.FindAll(ctx, includeDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotFetchGenres, err)
+	}
+	return buildTree(genres), nil
+}
+
+func buildTree(genres []entity.Genre) []entity.GenreNode {
+	known := make(map[int64]bool, len(genres))
+	for _, g := range genres {
+		known[g.ID] = true
+	}
+
+	children := make(map[int64][]entity.Genre)
+	roots := make([]entity.Genre, 0)
+	for _, g := range genres {
+		if g.ParentID == nil || !known[*g.ParentID] {
+			roots = append(roots, g)
+			continue
+		}
+		children[*g.ParentID] = append(children[*g.ParentID], g)
+	}
+
+	var build func(level []entity.Genre) []entity.GenreNode
+	build = func(level []entity.Genre) []entity.GenreNode {
+		nodes := make([]entity.GenreNode, 0, len(level))
+		for _, g := range level {
+			nodes = append(nodes, entity.GenreNode{
+				Genre:    g,
+				Children: build(children[g.ID]),
+			})
+		}
+		return nodes
+	}
+
+	return build(roots)
+}
+
+func (s *Service) FindById(ctx context.Context, id int64) (entity.Genre, error) {
+	ctx, span := tracing.Start(ctx, "genres.FindById")
+	defer span.End()
+
+	genre, err := s.genreRepository.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrGenreNotFound) {
+			return entity.Genre{}, fmt.Errorf("%w: %w", ErrGenreNotFound, err)
+		}
+		return entity.Genre{}, fmt.Errorf("%w: %w", ErrCannotFetchGenres, err)
+	}
+	return genre, nil
+}
+
+// Patch переименовывает жанр и/или переносит его к другому родителю.
+// Родителем не может стать сам жанр или любой из его поджанров.
+func (s *Service) Patch(ctx context.Context, id int64, patch entity.GenrePatch) error {
+	ctx, span := tracing.Start(ctx, "genres.Patch")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.genreRepository.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		genre := patch.Apply(before)
+		if patch.ParentID != nil && genre.ParentID != nil {
+			subtree, err := s.genreRepository.FindSubtreeIDs(ctx, id)
+			if err != nil {
+				return err
+			}
+			if lo.Contains(subtree, *genre.ParentID) {
+				return fmt.Errorf("%w: genre %d cannot be moved under %d", ErrGenreCycle, id, *genre.ParentID)
+			}
+			if _, err := s.genreRepository.FindById(ctx, *genre.ParentID); err != nil {
+				if errors.Is(err, repo.ErrGenreNotFound) {
+					return fmt.Errorf("%w: %w", ErrParentGenreNotFound, err)
+				}
+				return err
+			}
+		}
+
+		if err := s.genreRepository.Update(ctx, genre); err != nil {
+			return err
+		}
+		return s.record(ctx, entity.AuditActionUpdate, entity.AuditEntityGenre, id, before, genre)
+	})
 	if err != nil {
+		if errors.Is(err, ErrGenreCycle) || errors.Is(err, ErrParentGenreNotFound) {
+			return err
+		}
 		if errors.Is(err, repo.ErrGenreNotFound) {
-			return ErrGenreNotFound
+			return fmt.Errorf("%w: %w", ErrGenreNotFound, err)
+		}
+		if errors.Is(err, repo.ErrGenreAlreadyExists) {
+			return fmt.Errorf("%w: %w", ErrGenreAlreadyExists, err)
+		}
+		if errors.Is(err, repo.ErrParentGenreNotFound) {
+			return fmt.Errorf("%w: %w", ErrParentGenreNotFound, err)
 		}
-		return ErrCannotDeleteGenre
+		return fmt.Errorf("%w: %w", ErrCannotUpdateGenre, err)
 	}
 	return nil
 }
 
-func (s *Service) AddGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) error {
-	err := s.genreRepository.AddGenresToAlbum(ctx, albumID, genreIDs...)
+// DeleteGenre удаляет жанр, policy определяет судьбу поджанров (entity.GenreDelete*).
+// В журнал пишется удаление самого жанра, каскадно удаленные поджанры отдельно не записываются.
+func (s *Service) DeleteGenre(ctx context.Context, genreID int64, policy string) error {
+	ctx, span := tracing.Start(ctx, "genres.DeleteGenre")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.genreRepository.FindById(ctx, genreID)
+		if err != nil {
+			return err
+		}
+
+		switch policy {
+		case entity.GenreDeleteReparent:
+			if err := s.genreRepository.ReparentChildren(ctx, genreID); err != nil {
+				return err
+			}
+			err = s.genreRepository.Delete(ctx, genreID)
+		case entity.GenreDeleteCascade:
+			err = s.genreRepository.DeleteSubtree(ctx, genreID)
+		default:
+			err = s.genreRepository.Delete(ctx, genreID)
+		}
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, entity.AuditActionDelete, entity.AuditEntityGenre, genreID, before, nil)
+	})
 	if err != nil {
-		logrus.Errorf("AddGEnres to album error: %v", err)
-		if errors.Is(err, repo.ErrCannotAddEmptyGenres) {
-			return ErrCannotAddEmptyGenres
+		if errors.Is(err, repo.ErrGenreNotFound) {
+			return fmt.Errorf("%w: %w", ErrGenreNotFound, err)
+		}
+		if errors.Is(err, repo.ErrGenreHasChildren) {
+			return fmt.Errorf("%w: %w", ErrGenreHasChildren, err)
 		}
-		return ErrCannotAddConstraintAlbumGenre
+		return fmt.Errorf("%w: %w", ErrCannotDeleteGenre, err)
 	}
 	return nil
 }
+
+// Restore возвращает мягко удаленный жанр вместе со связями с альбомами.
+// Поджанры, удаленные каскадно вместе с ним, восстанавливаются тоже.
+func (s *Service) Restore(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "genres.Restore")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.genreRepository.Restore(ctx, id); err != nil {
+			return err
+		}
+		after, err := s.genreRepository.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, entity.AuditActionRestore, entity.AuditEntityGenre, id, nil, after)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrGenreNotFound) {
+			return fmt.Errorf("%w: %w", ErrGenreNotFound, err)
+		}
+		if errors.Is(err, repo.ErrGenreAlreadyExists) {
+			return fmt.Errorf("%w: %w", ErrGenreAlreadyExists, err)
+		}
+		return fmt.Errorf("%w: %w", ErrCannotRestoreGenre, err)
+	}
+	return nil
+}
+
+// PurgeDeleted окончательно удаляет жанры, удаленные раньше before.
+// В журнал не пишется: удаление этих жанров уже записано при мягком удалении.
+func (s *Service) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "genres.PurgeDeleted")
+	defer span.End()
+
+	var purged int64
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		purged, err = s.genreRepository.PurgeDeleted(ctx, before)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCannotPurgeGenres, err)
+	}
+	return purged, nil
+}
+
+// AddGenresToAlbum идемпотентно привязывает жанры к альбому.
+// Уже привязанные и несуществующие жанры не считаются ошибкой и возвращаются в результате.
+func (s *Service) AddGenresToAlbum(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error) {
+	ctx, span := tracing.Start(ctx, "genres.AddGenresToAlbum")
+	defer span.End()
+
+	if len(genreIDs) == 0 {
+		return entity.AlbumGenreLinks{}, ErrCannotAddEmptyGenres
+	}
+
+	var links entity.AlbumGenreLinks
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		links, err = s.genreRepository.LinkGenresToAlbum(ctx, albumID, lo.Uniq(genreIDs)...)
+		if err != nil {
+			return err
+		}
+		return s.recordLinks(ctx, albumID, links)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return entity.AlbumGenreLinks{}, fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+		}
+		return entity.AlbumGenreLinks{}, fmt.Errorf("%w: %w", ErrCannotUpdateAlbumGenres, err)
+	}
+	if len(links.Missing) > 0 {
+		logger.FromContext(ctx).WithField("album_id", albumID).WithField("missing_genres", links.Missing).
+			Warn("skipped missing genres")
+	}
+	s.eventCounter.GenresAttached(len(links.Added))
+	return links, nil
+}
+
+// SetAlbumGenres заменяет набор жанров альбома ровно на переданный.
+// Если хотя бы одного жанра не существует, набор не меняется.
+func (s *Service) SetAlbumGenres(ctx context.Context, albumID int64, genreIDs ...int64) (entity.AlbumGenreLinks, error) {
+	ctx, span := tracing.Start(ctx, "genres.SetAlbumGenres")
+	defer span.End()
+
+	genreIDs = lo.Uniq(genreIDs)
+
+	var links entity.AlbumGenreLinks
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		links, err = s.genreRepository.LinkGenresToAlbum(ctx, albumID, genreIDs...)
+		if err != nil {
+			return err
+		}
+		if len(links.Missing) > 0 {
+			return fmt.Errorf("%w: genres %v not found", ErrGenreNotFound, links.Missing)
+		}
+
+		links.Removed, err = s.genreRepository.RetainAlbumGenres(ctx, albumID, genreIDs...)
+		if err != nil {
+			return err
+		}
+		return s.recordLinks(ctx, albumID, links)
+	})
+	if err != nil {
+		if errors.Is(err, ErrGenreNotFound) {
+			return entity.AlbumGenreLinks{}, err
+		}
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return entity.AlbumGenreLinks{}, fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+		}
+		return entity.AlbumGenreLinks{}, fmt.Errorf("%w: %w", ErrCannotUpdateAlbumGenres, err)
+	}
+	s.eventCounter.GenresAttached(len(links.Added))
+	return links, nil
+}
+
+func (s *Service) RemoveGenreFromAlbum(ctx context.Context, albumID, genreID int64) error {
+	ctx, span := tracing.Start(ctx, "genres.RemoveGenreFromAlbum")
+	defer span.End()
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.genreRepository.UnlinkGenreFromAlbum(ctx, albumID, genreID); err != nil {
+			return err
+		}
+		return s.recordLinks(ctx, albumID, entity.AlbumGenreLinks{Removed: []int64{genreID}})
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumGenreNotFound) {
+			return fmt.Errorf("%w: %w", ErrAlbumGenreNotFound, err)
+		}
+		return fmt.Errorf("%w: %w", ErrCannotUpdateAlbumGenres, err)
+	}
+	return nil
+}
+
+// recordLinks пишет в журнал альбома привязанные и отвязанные жанры, если набор изменился
+func (s *Service) recordLinks(ctx context.Context, albumID int64, links entity.AlbumGenreLinks) error {
+	if len(links.Added) > 0 {
+		if err := s.record(ctx, entity.AuditActionLinkGenres, entity.AuditEntityAlbum, albumID, nil, links.Added); err != nil {
+			return err
+		}
+	}
+	if len(links.Removed) > 0 {
+		return s.record(ctx, entity.AuditActionUnlinkGenres, entity.AuditEntityAlbum, albumID, links.Removed, nil)
+	}
+	return nil
+}
+
+// record пишет событие журнала, должен вызываться внутри транзакции изменения
+func (s *Service) record(ctx context.Context, action, entityType string, id int64, before, after any) error {
+	event := entity.AuditEvent{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id,
+		Before:     before,
+		After:      after,
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		event.ActorID = &principal.UserID
+	}
+	return s.auditWriter.Write(ctx, event)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/4udiwe/musicshop/internal/entity"
 	"github.com/4udiwe/musicshop/internal/mocks/mock_genres"
@@ -20,37 +21,101 @@ func TestCreate(t *testing.T) {
 		ctx          = context.Background()
 	)
 
-	type MockBehavior func(r *mock_genres.MockGenreRepository)
+	type MockBehavior func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
 
+	parentID := int64(1)
 	genre := entity.Genre{
 		Name: "genre",
 	}
+	child := entity.Genre{
+		Name:     "subgenre",
+		ParentID: &parentID,
+	}
 
 	for _, tc := range []struct {
 		name         string
+		genre        entity.Genre
 		mockBehavior MockBehavior
 		want         int64
 		wantErr      error
 	}{
 		{
-			name: "success",
-			mockBehavior: func(r *mock_genres.MockGenreRepository) {
+			name:  "success",
+			genre: genre,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
 				r.EXPECT().Create(ctx, genre).Return(int64(1), nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionCreate,
+					EntityType: entity.AuditEntityGenre,
+					EntityID:   1,
+					After:      entity.Genre{ID: 1, Name: "genre"},
+				}).Return(nil)
 			},
 			want:    1,
 			wantErr: nil,
 		},
 		{
-			name: "genre already exists",
-			mockBehavior: func(r *mock_genres.MockGenreRepository) {
+			name:  "genre already exists",
+			genre: genre,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
 				r.EXPECT().Create(ctx, genre).Return(int64(0), repo.ErrGenreAlreadyExists)
 			},
 			want:    0,
 			wantErr: service.ErrGenreAlreadyExists,
 		},
 		{
-			name: "cannot create genre",
-			mockBehavior: func(r *mock_genres.MockGenreRepository) {
+			name:  "parent genre not found",
+			genre: child,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, parentID).Return(entity.Genre{ID: parentID, Name: "rock"}, nil)
+				r.EXPECT().Create(ctx, child).Return(int64(0), repo.ErrParentGenreNotFound)
+			},
+			want:    0,
+			wantErr: service.ErrParentGenreNotFound,
+		},
+		{
+			name:  "parent genre deleted",
+			genre: child,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, parentID).Return(entity.Genre{}, repo.ErrGenreNotFound)
+			},
+			want:    0,
+			wantErr: service.ErrParentGenreNotFound,
+		},
+		{
+			name:  "success with parent",
+			genre: child,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, parentID).Return(entity.Genre{ID: parentID, Name: "rock"}, nil)
+				r.EXPECT().Create(ctx, child).Return(int64(2), nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionCreate,
+					EntityType: entity.AuditEntityGenre,
+					EntityID:   2,
+					After:      entity.Genre{ID: 2, Name: "subgenre", ParentID: &parentID},
+				}).Return(nil)
+			},
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name:  "cannot create genre",
+			genre: genre,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
 				r.EXPECT().Create(ctx, genre).Return(int64(0), arbitraryErr)
 			},
 			want:    0,
@@ -62,13 +127,14 @@ func TestCreate(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
 			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
 
-			tc.mockBehavior(mockGenreRepository)
+			tc.mockBehavior(mockGenreRepository, mockAuditWriter, mockTransactor)
 
-			s := service.New(mockGenreRepository, mockTransactor)
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
 
-			out, err := s.Create(ctx, genre)
+			out, err := s.Create(ctx, tc.genre)
 
 			assert.ErrorIs(t, err, tc.wantErr)
 			assert.Equal(t, tc.want, out)
@@ -105,7 +171,7 @@ func TestFindAll(t *testing.T) {
 		{
 			name: "success",
 			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().FindAll(ctx).Return(genres, nil)
+				r.EXPECT().FindAll(ctx, false).Return(genres, nil)
 			},
 			want:    genres,
 			wantErr: nil,
@@ -113,7 +179,7 @@ func TestFindAll(t *testing.T) {
 		{
 			name: "cannot fetch genres",
 			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().FindAll(ctx).Return(nil, arbitraryErr)
+				r.EXPECT().FindAll(ctx, false).Return(nil, arbitraryErr)
 			},
 			want:    nil,
 			wantErr: service.ErrCannotFetchGenres,
@@ -124,13 +190,14 @@ func TestFindAll(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
 			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
 
 			tc.mockBehavior(mockGenreRepository)
 
-			s := service.New(mockGenreRepository, mockTransactor)
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
 
-			out, err := s.FindAll(ctx)
+			out, err := s.FindAll(ctx, false)
 
 			assert.ErrorIs(t, err, tc.wantErr)
 			assert.Equal(t, tc.want, out)
@@ -138,7 +205,7 @@ func TestFindAll(t *testing.T) {
 	}
 }
 
-func TestDeleteById(t *testing.T) {
+func TestFindById(t *testing.T) {
 	var (
 		arbitraryErr = errors.New("arbitrary error")
 		ctx          = context.Background()
@@ -147,31 +214,41 @@ func TestDeleteById(t *testing.T) {
 
 	type MockBehavior func(r *mock_genres.MockGenreRepository)
 
+	genre := entity.Genre{
+		ID:         id,
+		Name:       "metal",
+		AlbumCount: 3,
+	}
+
 	for _, tc := range []struct {
 		name         string
 		mockBehavior MockBehavior
+		want         entity.Genre
 		wantErr      error
 	}{
 		{
 			name: "success",
 			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().Delete(ctx, id).Return(nil)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
 			},
+			want:    genre,
 			wantErr: nil,
 		},
 		{
-			name: "album not found",
+			name: "genre not found",
 			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().Delete(ctx, id).Return(repo.ErrGenreNotFound)
+				r.EXPECT().FindById(ctx, id).Return(entity.Genre{}, repo.ErrGenreNotFound)
 			},
+			want:    entity.Genre{},
 			wantErr: service.ErrGenreNotFound,
 		},
 		{
-			name: "cannot fetch album",
+			name: "cannot fetch genre",
 			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().Delete(ctx, id).Return(arbitraryErr)
+				r.EXPECT().FindById(ctx, id).Return(entity.Genre{}, arbitraryErr)
 			},
-			wantErr: service.ErrCannotDeleteGenre,
+			want:    entity.Genre{},
+			wantErr: service.ErrCannotFetchGenres,
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -179,68 +256,515 @@ func TestDeleteById(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
 			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
 
 			tc.mockBehavior(mockGenreRepository)
 
-			s := service.New(mockGenreRepository, mockTransactor)
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
 
-			err := s.DeleteGenre(ctx, id)
+			out, err := s.FindById(ctx, id)
 
 			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
 		})
 	}
 }
 
-func TestAddGenresToAlbum(t *testing.T) {
+func TestPatch(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		id           = int64(2)
+		rockID       = int64(1)
+		newName      = "progressive rock"
+		root         = int64(0)
+		descendantID = int64(3)
+	)
+
+	type MockBehavior func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	genre := entity.Genre{ID: id, Name: "prog rock"}
+	child := entity.Genre{ID: id, Name: "prog rock", ParentID: &rockID}
+
+	for _, tc := range []struct {
+		name         string
+		patch        entity.GenrePatch
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name:  "rename",
+			patch: entity.GenrePatch{Name: &newName},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().Update(ctx, entity.Genre{ID: id, Name: newName}).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUpdate,
+					EntityType: entity.AuditEntityGenre,
+					EntityID:   id,
+					Before:     genre,
+					After:      entity.Genre{ID: id, Name: newName},
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "move under parent",
+			patch: entity.GenrePatch{ParentID: &rockID},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().FindSubtreeIDs(ctx, id).Return([]int64{id, descendantID}, nil)
+				r.EXPECT().FindById(ctx, rockID).Return(entity.Genre{ID: rockID, Name: "rock"}, nil)
+				r.EXPECT().Update(ctx, child).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUpdate,
+					EntityType: entity.AuditEntityGenre,
+					EntityID:   id,
+					Before:     genre,
+					After:      child,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "make root",
+			patch: entity.GenrePatch{ParentID: &root},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(child, nil)
+				r.EXPECT().Update(ctx, genre).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUpdate,
+					EntityType: entity.AuditEntityGenre,
+					EntityID:   id,
+					Before:     child,
+					After:      genre,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "cannot be own parent",
+			patch: entity.GenrePatch{ParentID: &id},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().FindSubtreeIDs(ctx, id).Return([]int64{id, descendantID}, nil)
+			},
+			wantErr: service.ErrGenreCycle,
+		},
+		{
+			name:  "cannot move under descendant",
+			patch: entity.GenrePatch{ParentID: &descendantID},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().FindSubtreeIDs(ctx, id).Return([]int64{id, descendantID}, nil)
+			},
+			wantErr: service.ErrGenreCycle,
+		},
+		{
+			name:  "genre not found",
+			patch: entity.GenrePatch{Name: &newName},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(entity.Genre{}, repo.ErrGenreNotFound)
+			},
+			wantErr: service.ErrGenreNotFound,
+		},
+		{
+			name:  "parent not found",
+			patch: entity.GenrePatch{ParentID: &rockID},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().FindSubtreeIDs(ctx, id).Return([]int64{id}, nil)
+				r.EXPECT().FindById(ctx, rockID).Return(entity.Genre{}, repo.ErrGenreNotFound)
+			},
+			wantErr: service.ErrParentGenreNotFound,
+		},
+		{
+			name:  "name already taken",
+			patch: entity.GenrePatch{Name: &newName},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().Update(ctx, gomock.Any()).Return(repo.ErrGenreAlreadyExists)
+			},
+			wantErr: service.ErrGenreAlreadyExists,
+		},
+		{
+			name:  "cannot update genre",
+			patch: entity.GenrePatch{Name: &newName},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().Update(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotUpdateGenre,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockGenreRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
+
+			err := s.Patch(ctx, id, tc.patch)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestFindTree(t *testing.T) {
 	var (
-		arbitraryErr   = errors.New("arbitrary error")
-		ctx            = context.Background()
-		albumID        = int64(111)
-		singleGenre    = []int64{1}
-		multipleGenres = []int64{1, 2, 3}
-		emptyGenres    = []int64{}
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		rockID       = int64(1)
+		progID       = int64(2)
 	)
 
 	type MockBehavior func(r *mock_genres.MockGenreRepository)
 
+	rock := entity.Genre{ID: rockID, Name: "rock"}
+	prog := entity.Genre{ID: progID, Name: "progressive rock", ParentID: &rockID}
+	canterbury := entity.Genre{ID: 3, Name: "canterbury scene", ParentID: &progID}
+	jazz := entity.Genre{ID: 4, Name: "jazz"}
+
 	for _, tc := range []struct {
 		name         string
-		genres       []int64
 		mockBehavior MockBehavior
+		want         []entity.GenreNode
 		wantErr      error
 	}{
 		{
-			name:   "success single genre",
-			genres: singleGenre,
+			name: "success",
 			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().AddGenresToAlbum(ctx, albumID, singleGenre).Return(nil)
+				r.EXPECT().FindAll(ctx, false).Return([]entity.Genre{rock, prog, canterbury, jazz}, nil)
+			},
+			want: []entity.GenreNode{
+				{
+					Genre: rock,
+					Children: []entity.GenreNode{
+						{
+							Genre: prog,
+							Children: []entity.GenreNode{
+								{Genre: canterbury, Children: []entity.GenreNode{}},
+							},
+						},
+					},
+				},
+				{Genre: jazz, Children: []entity.GenreNode{}},
 			},
 			wantErr: nil,
 		},
 		{
-			name:   "success multiple genre",
-			genres: multipleGenres,
+			name: "empty catalog",
 			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().AddGenresToAlbum(ctx, albumID, multipleGenres).Return(nil)
+				r.EXPECT().FindAll(ctx, false).Return([]entity.Genre{}, nil)
 			},
+			want:    []entity.GenreNode{},
 			wantErr: nil,
 		},
 		{
-			name:   "cannot add empty genres",
-			genres: emptyGenres,
+			name: "cannot fetch genres",
 			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().AddGenresToAlbum(ctx, albumID, emptyGenres).Return(repo.ErrCannotAddEmptyGenres)
+				r.EXPECT().FindAll(ctx, false).Return(nil, arbitraryErr)
+			},
+			want:    nil,
+			wantErr: service.ErrCannotFetchGenres,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockGenreRepository)
+
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
+
+			out, err := s.FindTree(ctx, false)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestDeleteById(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		id           = int64(1)
+	)
+
+	type MockBehavior func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	genre := entity.Genre{ID: id, Name: "rock"}
+	deleted := func(w *mock_genres.MockAuditWriter) {
+		w.EXPECT().Write(ctx, entity.AuditEvent{
+			Action:     entity.AuditActionDelete,
+			EntityType: entity.AuditEntityGenre,
+			EntityID:   id,
+			Before:     genre,
+		}).Return(nil)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		policy       string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().Delete(ctx, id).Return(nil)
+				deleted(w)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "reject genre with subgenres",
+			policy: entity.GenreDeleteReject,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().Delete(ctx, id).Return(repo.ErrGenreHasChildren)
+			},
+			wantErr: service.ErrGenreHasChildren,
+		},
+		{
+			name:   "reparent subgenres",
+			policy: entity.GenreDeleteReparent,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().ReparentChildren(ctx, id).Return(nil)
+				r.EXPECT().Delete(ctx, id).Return(nil)
+				deleted(w)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "cannot reparent subgenres",
+			policy: entity.GenreDeleteReparent,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().ReparentChildren(ctx, id).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotDeleteGenre,
+		},
+		{
+			name:   "cascade",
+			policy: entity.GenreDeleteCascade,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().DeleteSubtree(ctx, id).Return(nil)
+				deleted(w)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "cascade genre not found",
+			policy: entity.GenreDeleteCascade,
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(entity.Genre{}, repo.ErrGenreNotFound)
+			},
+			wantErr: service.ErrGenreNotFound,
+		},
+		{
+			name: "genre not found",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(entity.Genre{}, repo.ErrGenreNotFound)
+			},
+			wantErr: service.ErrGenreNotFound,
+		},
+		{
+			name: "cannot delete genre",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().Delete(ctx, id).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotDeleteGenre,
+		},
+		{
+			name: "cannot write audit event",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				r.EXPECT().Delete(ctx, id).Return(nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotDeleteGenre,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockGenreRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
+
+			err := s.DeleteGenre(ctx, id, tc.policy)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestAddGenresToAlbum(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(111)
+	)
+
+	type MockBehavior func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	links := entity.AlbumGenreLinks{
+		Added:    []int64{1},
+		Existing: []int64{2},
+		Missing:  []int64{3},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		genres       []int64
+		mockBehavior MockBehavior
+		want         entity.AlbumGenreLinks
+		wantErr      error
+	}{
+		{
+			name:   "success",
+			genres: []int64{1, 2, 3},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1), int64(2), int64(3)).Return(links, nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionLinkGenres,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					After:      []int64{1},
+				}).Return(nil)
+			},
+			want:    links,
+			wantErr: nil,
+		},
+		{
+			name:   "duplicate ids are linked once",
+			genres: []int64{1, 1, 2},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1), int64(2)).Return(links, nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionLinkGenres,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					After:      []int64{1},
+				}).Return(nil)
+			},
+			want:    links,
+			wantErr: nil,
+		},
+		{
+			name:   "cannot add empty genres",
+			genres: []int64{},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
 			},
+			want:    entity.AlbumGenreLinks{},
 			wantErr: service.ErrCannotAddEmptyGenres,
 		},
 		{
-			name:   "cannot add constraint between album and genre",
-			genres: singleGenre,
-			mockBehavior: func(r *mock_genres.MockGenreRepository) {
-				r.EXPECT().AddGenresToAlbum(ctx, albumID, singleGenre).Return(arbitraryErr)
+			name:   "nothing changed",
+			genres: []int64{2},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(2)).Return(entity.AlbumGenreLinks{Existing: []int64{2}}, nil)
+			},
+			want:    entity.AlbumGenreLinks{Existing: []int64{2}},
+			wantErr: nil,
+		},
+		{
+			name:   "album not found",
+			genres: []int64{1},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1)).Return(entity.AlbumGenreLinks{}, repo.ErrAlbumNotFound)
+			},
+			want:    entity.AlbumGenreLinks{},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name:   "cannot write audit event",
+			genres: []int64{1},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1)).Return(entity.AlbumGenreLinks{Added: []int64{1}}, nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			want:    entity.AlbumGenreLinks{},
+			wantErr: service.ErrCannotUpdateAlbumGenres,
+		},
+		{
+			name:   "cannot update album genres",
+			genres: []int64{1},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1)).Return(entity.AlbumGenreLinks{}, arbitraryErr)
 			},
-			wantErr: service.ErrCannotAddConstraintAlbumGenre,
+			want:    entity.AlbumGenreLinks{},
+			wantErr: service.ErrCannotUpdateAlbumGenres,
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -248,15 +772,375 @@ func TestAddGenresToAlbum(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
 			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
 
-			tc.mockBehavior(mockGenreRepository)
+			mockEventCounter := mock_genres.NewMockEventCounter(ctrl)
+
+			tc.mockBehavior(mockGenreRepository, mockAuditWriter, mockTransactor)
+			if tc.wantErr == nil {
+				mockEventCounter.EXPECT().GenresAttached(len(tc.want.Added))
+			}
+
+			s := service.New(mockGenreRepository, mockAuditWriter, mockEventCounter, mockTransactor)
+
+			out, err := s.AddGenresToAlbum(ctx, albumID, tc.genres...)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestSetAlbumGenres(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(111)
+	)
+
+	type MockBehavior func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		genres       []int64
+		mockBehavior MockBehavior
+		want         entity.AlbumGenreLinks
+		wantErr      error
+	}{
+		{
+			name:   "success",
+			genres: []int64{1, 2},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1), int64(2)).Return(entity.AlbumGenreLinks{
+					Added:    []int64{1},
+					Existing: []int64{2},
+					Missing:  []int64{},
+				}, nil)
+				r.EXPECT().RetainAlbumGenres(ctx, albumID, int64(1), int64(2)).Return([]int64{5}, nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionLinkGenres,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					After:      []int64{1},
+				}).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUnlinkGenres,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					Before:     []int64{5},
+				}).Return(nil)
+			},
+			want: entity.AlbumGenreLinks{
+				Added:    []int64{1},
+				Existing: []int64{2},
+				Missing:  []int64{},
+				Removed:  []int64{5},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "clear all genres",
+			genres: []int64{},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID).Return(entity.AlbumGenreLinks{}, nil)
+				r.EXPECT().RetainAlbumGenres(ctx, albumID).Return([]int64{1, 2}, nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUnlinkGenres,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					Before:     []int64{1, 2},
+				}).Return(nil)
+			},
+			want: entity.AlbumGenreLinks{
+				Removed: []int64{1, 2},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "missing genre rolls back",
+			genres: []int64{1, 3},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1), int64(3)).Return(entity.AlbumGenreLinks{
+					Added:   []int64{1},
+					Missing: []int64{3},
+				}, nil)
+			},
+			want:    entity.AlbumGenreLinks{},
+			wantErr: service.ErrGenreNotFound,
+		},
+		{
+			name:   "album not found",
+			genres: []int64{1},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1)).Return(entity.AlbumGenreLinks{}, repo.ErrAlbumNotFound)
+			},
+			want:    entity.AlbumGenreLinks{},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name:   "cannot retain genres",
+			genres: []int64{1},
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().LinkGenresToAlbum(ctx, albumID, int64(1)).Return(entity.AlbumGenreLinks{}, nil)
+				r.EXPECT().RetainAlbumGenres(ctx, albumID, int64(1)).Return(nil, arbitraryErr)
+			},
+			want:    entity.AlbumGenreLinks{},
+			wantErr: service.ErrCannotUpdateAlbumGenres,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			mockEventCounter := mock_genres.NewMockEventCounter(ctrl)
+
+			tc.mockBehavior(mockGenreRepository, mockAuditWriter, mockTransactor)
+			if tc.wantErr == nil {
+				mockEventCounter.EXPECT().GenresAttached(len(tc.want.Added))
+			}
+
+			s := service.New(mockGenreRepository, mockAuditWriter, mockEventCounter, mockTransactor)
+
+			out, err := s.SetAlbumGenres(ctx, albumID, tc.genres...)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestRemoveGenreFromAlbum(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(111)
+		genreID      = int64(1)
+	)
+
+	type MockBehavior func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().UnlinkGenreFromAlbum(ctx, albumID, genreID).Return(nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionUnlinkGenres,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					Before:     []int64{genreID},
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "genre is not linked",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().UnlinkGenreFromAlbum(ctx, albumID, genreID).Return(repo.ErrAlbumGenreNotFound)
+			},
+			wantErr: service.ErrAlbumGenreNotFound,
+		},
+		{
+			name: "cannot update album genres",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().UnlinkGenreFromAlbum(ctx, albumID, genreID).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotUpdateAlbumGenres,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockGenreRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
+
+			err := s.RemoveGenreFromAlbum(ctx, albumID, genreID)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		id           = int64(1)
+	)
+
+	genre := entity.Genre{ID: id, Name: "prog rock"}
+
+	type MockBehavior func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Restore(ctx, id).Return(nil)
+				r.EXPECT().FindById(ctx, id).Return(genre, nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionRestore,
+					EntityType: entity.AuditEntityGenre,
+					EntityID:   id,
+					After:      genre,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "genre not found",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Restore(ctx, id).Return(repo.ErrGenreNotFound)
+			},
+			wantErr: service.ErrGenreNotFound,
+		},
+		{
+			name: "name is taken by active genre",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Restore(ctx, id).Return(repo.ErrGenreAlreadyExists)
+			},
+			wantErr: service.ErrGenreAlreadyExists,
+		},
+		{
+			name: "cannot restore genre",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, w *mock_genres.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().Restore(ctx, id).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotRestoreGenre,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockGenreRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
+
+			err := s.Restore(ctx, id)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		before       = time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	type MockBehavior func(r *mock_genres.MockGenreRepository, t *mock_transactor.MockTransactor)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         int64
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().PurgeDeleted(ctx, before).Return(int64(3), nil)
+			},
+			want:    3,
+			wantErr: nil,
+		},
+		{
+			name: "cannot purge genres",
+			mockBehavior: func(r *mock_genres.MockGenreRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().PurgeDeleted(ctx, before).Return(int64(0), arbitraryErr)
+			},
+			want:    0,
+			wantErr: service.ErrCannotPurgeGenres,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockGenreRepository := mock_genres.NewMockGenreRepository(ctrl)
+			mockAuditWriter := mock_genres.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockGenreRepository, mockTransactor)
 
-			s := service.New(mockGenreRepository, mockTransactor)
+			s := service.New(mockGenreRepository, mockAuditWriter, nil, mockTransactor)
 
-			err := s.AddGenresToAlbum(ctx, albumID, tc.genres...)
+			out, err := s.PurgeDeleted(ctx, before)
 
 			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
 		})
 	}
 }
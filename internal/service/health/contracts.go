@@ -0,0 +1,12 @@
+package health
+
+import "context"
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// SchemaVersioner возвращает версию последней примененной миграции
+type SchemaVersioner interface {
+	Version(ctx context.Context) (int64, error)
+}
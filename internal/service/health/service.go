@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+// CheckTimeout ограничивает каждую проверку готовности
+const CheckTimeout = 2 * time.Second
+
+var ErrShuttingDown = errors.New("server is shutting down")
+
+type check struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type Service struct {
+	checks       []check
+	shuttingDown atomic.Bool
+}
+
+// New создает проверки готовности: соединение с базой и совпадение версии схемы
+// с последней встроенной миграцией latestVersion
+func New(p Pinger, v SchemaVersioner, latestVersion int64) *Service {
+	return &Service{
+		checks: []check{
+			{name: "postgres", fn: p.Ping},
+			{name: "migrations", fn: func(ctx context.Context) error {
+				version, err := v.Version(ctx)
+				if err != nil {
+					return err
+				}
+				if version != latestVersion {
+					return fmt.Errorf("schema version %d, expected %d", version, latestVersion)
+				}
+				return nil
+			}},
+		},
+	}
+}
+
+// Live сообщает, что процесс жив. Зависимости не проверяются, иначе сбой базы
+// приводил бы к перезапуску всех экземпляров.
+func (s *Service) Live() entity.HealthReport {
+	return entity.HealthReport{Status: entity.HealthStatusOK, Checks: []entity.HealthCheck{}}
+}
+
+// Ready выполняет проверки параллельно. После StartShutdown готовность
+// не проверяется и всегда неуспешна.
+func (s *Service) Ready(ctx context.Context) entity.HealthReport {
+	if s.shuttingDown.Load() {
+		return entity.HealthReport{
+			Status: entity.HealthStatusFail,
+			Checks: []entity.HealthCheck{{Name: "shutdown", Status: entity.HealthStatusFail, Error: ErrShuttingDown.Error()}},
+		}
+	}
+
+	report := entity.HealthReport{Status: entity.HealthStatusOK, Checks: make([]entity.HealthCheck, len(s.checks))}
+
+	var wg sync.WaitGroup
+	for i, c := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range report.Checks {
+		if c.Status != entity.HealthStatusOK {
+			report.Status = entity.HealthStatusFail
+		}
+	}
+	return report
+}
+
+// StartShutdown переводит готовность в неуспешную, чтобы балансировщик
+// перестал направлять запросы до остановки сервера
+func (s *Service) StartShutdown() {
+	s.shuttingDown.Store(true)
+}
+
+func run(ctx context.Context, c check) entity.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.fn(ctx)
+	result := entity.HealthCheck{Name: c.name, Status: entity.HealthStatusOK, Latency: time.Since(start)}
+	if err != nil {
+		result.Status = entity.HealthStatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
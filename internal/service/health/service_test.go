@@ -0,0 +1,118 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_health"
+	service "github.com/4udiwe/musicshop/internal/service/health"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const latestVersion int64 = 20
+
+func TestReady(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+	)
+
+	type MockBehavior func(p *mock_health.MockPinger, v *mock_health.MockSchemaVersioner)
+
+	for _, tc := range []struct {
+		name         string
+		shuttingDown bool
+		mockBehavior MockBehavior
+		want         entity.HealthReport
+	}{
+		{
+			name: "all checks pass",
+			mockBehavior: func(p *mock_health.MockPinger, v *mock_health.MockSchemaVersioner) {
+				p.EXPECT().Ping(gomock.Any()).Return(nil)
+				v.EXPECT().Version(gomock.Any()).Return(latestVersion, nil)
+			},
+			want: entity.HealthReport{Status: entity.HealthStatusOK, Checks: []entity.HealthCheck{
+				{Name: "postgres", Status: entity.HealthStatusOK},
+				{Name: "migrations", Status: entity.HealthStatusOK},
+			}},
+		},
+		{
+			name: "ping fails",
+			mockBehavior: func(p *mock_health.MockPinger, v *mock_health.MockSchemaVersioner) {
+				p.EXPECT().Ping(gomock.Any()).Return(arbitraryErr)
+				v.EXPECT().Version(gomock.Any()).Return(latestVersion, nil)
+			},
+			want: entity.HealthReport{Status: entity.HealthStatusFail, Checks: []entity.HealthCheck{
+				{Name: "postgres", Status: entity.HealthStatusFail, Error: arbitraryErr.Error()},
+				{Name: "migrations", Status: entity.HealthStatusOK},
+			}},
+		},
+		{
+			name: "schema is behind",
+			mockBehavior: func(p *mock_health.MockPinger, v *mock_health.MockSchemaVersioner) {
+				p.EXPECT().Ping(gomock.Any()).Return(nil)
+				v.EXPECT().Version(gomock.Any()).Return(latestVersion-1, nil)
+			},
+			want: entity.HealthReport{Status: entity.HealthStatusFail, Checks: []entity.HealthCheck{
+				{Name: "postgres", Status: entity.HealthStatusOK},
+				{Name: "migrations", Status: entity.HealthStatusFail, Error: "schema version 19, expected 20"},
+			}},
+		},
+		{
+			name: "cannot read schema version",
+			mockBehavior: func(p *mock_health.MockPinger, v *mock_health.MockSchemaVersioner) {
+				p.EXPECT().Ping(gomock.Any()).Return(nil)
+				v.EXPECT().Version(gomock.Any()).Return(int64(0), arbitraryErr)
+			},
+			want: entity.HealthReport{Status: entity.HealthStatusFail, Checks: []entity.HealthCheck{
+				{Name: "postgres", Status: entity.HealthStatusOK},
+				{Name: "migrations", Status: entity.HealthStatusFail, Error: arbitraryErr.Error()},
+			}},
+		},
+		{
+			name:         "shutting down",
+			shuttingDown: true,
+			mockBehavior: func(p *mock_health.MockPinger, v *mock_health.MockSchemaVersioner) {},
+			want: entity.HealthReport{Status: entity.HealthStatusFail, Checks: []entity.HealthCheck{
+				{Name: "shutdown", Status: entity.HealthStatusFail, Error: service.ErrShuttingDown.Error()},
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPinger := mock_health.NewMockPinger(ctrl)
+			mockSchemaVersioner := mock_health.NewMockSchemaVersioner(ctrl)
+
+			tc.mockBehavior(mockPinger, mockSchemaVersioner)
+
+			s := service.New(mockPinger, mockSchemaVersioner, latestVersion)
+			if tc.shuttingDown {
+				s.StartShutdown()
+			}
+
+			out := s.Ready(ctx)
+
+			// задержка не детерминирована, сравнивается только ее наличие
+			for i := range out.Checks {
+				assert.GreaterOrEqual(t, out.Checks[i].Latency, time.Duration(0))
+				out.Checks[i].Latency = 0
+			}
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestLive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	s := service.New(mock_health.NewMockPinger(ctrl), mock_health.NewMockSchemaVersioner(ctrl), latestVersion)
+	s.StartShutdown()
+
+	assert.Equal(t, entity.HealthStatusOK, s.Live().Status)
+}
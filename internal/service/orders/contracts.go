@@ -0,0 +1,39 @@
+package orders
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type OrderRepository interface {
+	Create(ctx context.Context, order entity.Order) (int64, error)
+	FindById(ctx context.Context, id int64) (entity.Order, error)
+	UpdateStatus(ctx context.Context, id int64, from, to entity.OrderStatus) error
+}
+
+type CartRepository interface {
+	Create(ctx context.Context, userID int64) (int64, error)
+	FindById(ctx context.Context, id int64) (entity.Cart, error)
+	SetItem(ctx context.Context, cartID, albumID int64, format string, quantity int) error
+	RemoveItem(ctx context.Context, cartID, albumID int64) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// StockRepository списывает проданный товар с остатков SKU
+type StockRepository interface {
+	FindSKU(ctx context.Context, albumID int64, format string) (entity.SKU, error)
+	ChangeQuantity(ctx context.Context, skuID int64, delta int) (int, error)
+	AddMovement(ctx context.Context, movement entity.StockMovement) error
+}
+
+// PriceQuoter переводит базовые цены альбомов в другую валюту
+type PriceQuoter interface {
+	Quote(ctx context.Context, currency string, prices map[int64]entity.Money) (map[int64]entity.Money, error)
+}
+
+// PromotionPricer рассчитывает скидки корзины и засчитывает использование акций
+type PromotionPricer interface {
+	Price(ctx context.Context, cart entity.Cart, code string) (entity.Pricing, error)
+	Redeem(ctx context.Context, pricing entity.Pricing) error
+}
@@ -0,0 +1,15 @@
+package orders
+
+import "errors"
+
+var (
+	ErrCartNotFound            = errors.New("cart not found")
+	ErrCartIsEmpty             = errors.New("cart is empty")
+	ErrSKUNotFound             = errors.New("album is not sold in given format")
+	ErrInsufficientStock       = errors.New("insufficient stock")
+	ErrCannotCheckout          = errors.New("cannot checkout cart")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrCannotFetchOrder        = errors.New("cannot fetch order")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrCannotChangeStatus      = errors.New("cannot change order status")
+)
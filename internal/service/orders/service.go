@@ -0,0 +1,286 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/logger"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/4udiwe/musicshop/pkg/transactor"
+	"github.com/samber/lo"
+)
+
+// Причины движения остатков по заказу, дополняются номером заказа
+const (
+	ReasonSold     = "sold"
+	ReasonReturned = "returned"
+)
+
+type Service struct {
+	orderRepository OrderRepository
+	cartRepository  CartRepository
+	stockRepository StockRepository
+	priceQuoter     PriceQuoter
+	promotionPricer PromotionPricer
+	txManager       transactor.Transactor
+}
+
+func New(o OrderRepository, c CartRepository, st StockRepository, q PriceQuoter, p PromotionPricer, t transactor.Transactor) *Service {
+	return &Service{
+		orderRepository: o,
+		cartRepository:  c,
+		stockRepository: st,
+		priceQuoter:     q,
+		promotionPricer: p,
+		txManager:       t,
+	}
+}
+
+// Checkout оформляет заказ из корзины в валюте currency (пусто - базовая), фиксируя
+// название, артиста, формат, цену альбомов и скидки по акциям, списывает товар с остатков
+// и удаляет корзину. promoCode - необязательный код акции. Все шаги выполняются в одной транзакции.
+// Оформить можно только свою корзину, заказ принадлежит владельцу корзины.
+func (s *Service) Checkout(ctx context.Context, cartID int64, currency, promoCode string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "orders.Checkout")
+	defer span.End()
+
+	var id int64
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		cart, err := s.cartRepository.FindById(ctx, cartID)
+		if err != nil {
+			return err
+		}
+		if !auth.IsUser(ctx, cart.UserID) {
+			return fmt.Errorf("%w: cart with id %d belongs to another user", repo.ErrCartNotFound, cartID)
+		}
+		if len(cart.Items) == 0 {
+			return ErrCartIsEmpty
+		}
+
+		skus, err := s.reserve(ctx, cart.Items)
+		if err != nil {
+			return err
+		}
+
+		if currency != "" && currency != cart.Currency {
+			prices, err := s.priceQuoter.Quote(ctx, currency, lo.SliceToMap(cart.Items, func(item entity.CartItem) (int64, entity.Money) {
+				return item.AlbumID, item.Price
+			}))
+			if err != nil {
+				return err
+			}
+			cart.Currency = currency
+			cart.Items = lo.Map(cart.Items, func(item entity.CartItem, _ int) entity.CartItem {
+				item.Price = prices[item.AlbumID]
+				return item
+			})
+		}
+
+		pricing, err := s.promotionPricer.Price(ctx, cart, promoCode)
+		if err != nil {
+			return err
+		}
+		discounts := lo.SliceToMap(pricing.Lines, func(line entity.PricedLine) (int64, entity.Money) {
+			return line.AlbumID, line.Discount
+		})
+
+		order := entity.Order{UserID: &cart.UserID, Status: entity.OrderStatusPending, Currency: cart.Currency}
+		if coupon, ok := lo.Find(pricing.Applied, func(a entity.AppliedPromotion) bool { return a.Code != nil }); ok {
+			order.PromoCode = coupon.Code
+		}
+		order.Items = lo.Map(cart.Items, func(item entity.CartItem, _ int) entity.OrderItem {
+			return entity.OrderItem{
+				AlbumID:  item.AlbumID,
+				Format:   item.Format,
+				Title:    item.Title,
+				Artist:   item.Artist,
+				Price:    item.Price,
+				Quantity: item.Quantity,
+				Discount: discounts[item.AlbumID],
+			}
+		})
+
+		id, err = s.orderRepository.Create(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		if err = s.writeOff(ctx, id, cart.Items, skus); err != nil {
+			return err
+		}
+
+		if err = s.promotionPricer.Redeem(ctx, pricing); err != nil {
+			return err
+		}
+
+		return s.cartRepository.Delete(ctx, cartID)
+	})
+
+	if err != nil {
+		if errors.Is(err, repo.ErrCartNotFound) {
+			return 0, fmt.Errorf("%w: %w", ErrCartNotFound, err)
+		}
+		if errors.Is(err, ErrCartIsEmpty) {
+			return 0, ErrCartIsEmpty
+		}
+		if errors.Is(err, repo.ErrSKUNotFound) {
+			return 0, fmt.Errorf("%w: %w", ErrSKUNotFound, err)
+		}
+		if errors.Is(err, ErrInsufficientStock) {
+			return 0, err
+		}
+		if errors.Is(err, repo.ErrInsufficientStock) {
+			return 0, fmt.Errorf("%w: %w", ErrInsufficientStock, err)
+		}
+		return 0, fmt.Errorf("%w: %w", ErrCannotCheckout, err)
+	}
+
+	logger.FromContext(ctx).WithField("order_id", id).WithField("cart_id", cartID).Info("order placed")
+	return id, nil
+}
+
+// reserve блокирует SKU позиций до конца транзакции и проверяет остатки.
+// Позиции корзины упорядочены по альбому, поэтому параллельные оформления
+// блокируют строки в одном порядке и не попадают во взаимную блокировку.
+func (s *Service) reserve(ctx context.Context, items []entity.CartItem) ([]entity.SKU, error) {
+	skus := make([]entity.SKU, 0, len(items))
+	for _, item := range items {
+		sku, err := s.stockRepository.FindSKU(ctx, item.AlbumID, item.Format)
+		if err != nil {
+			return nil, err
+		}
+		if sku.Format != entity.FormatDigital && sku.Quantity < item.Quantity {
+			return nil, fmt.Errorf("%w: album id %d, format '%s': requested %d, in stock %d",
+				ErrInsufficientStock, item.AlbumID, item.Format, item.Quantity, sku.Quantity)
+		}
+		skus = append(skus, sku)
+	}
+	return skus, nil
+}
+
+// writeOff списывает позиции заказа orderID с остатков и записывает движения в журнал.
+// Цифровой формат остатка не имеет и не списывается.
+func (s *Service) writeOff(ctx context.Context, orderID int64, items []entity.CartItem, skus []entity.SKU) error {
+	for i, item := range items {
+		if skus[i].Format == entity.FormatDigital {
+			continue
+		}
+		if _, err := s.stockRepository.ChangeQuantity(ctx, skus[i].ID, -item.Quantity); err != nil {
+			return err
+		}
+		err := s.stockRepository.AddMovement(ctx, entity.StockMovement{
+			SKUID:  skus[i].ID,
+			Delta:  -item.Quantity,
+			Reason: fmt.Sprintf("%s: order %d", ReasonSold, orderID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindById возвращает заказ покупателю или персоналу, управляющему заказами.
+// Чужой заказ не отличается от несуществующего.
+func (s *Service) FindById(ctx context.Context, id int64) (entity.Order, error) {
+	ctx, span := tracing.Start(ctx, "orders.FindById")
+	defer span.End()
+
+	order, err := s.orderRepository.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrOrderNotFound) {
+			return entity.Order{}, fmt.Errorf("%w: %w", ErrOrderNotFound, err)
+		}
+		return entity.Order{}, fmt.Errorf("%w: %w", ErrCannotFetchOrder, err)
+	}
+
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if !principal.Can(auth.PermissionOrdersManage) && (order.UserID == nil || !auth.IsUser(ctx, *order.UserID)) {
+		return entity.Order{}, fmt.Errorf("%w: order with id %d belongs to another user", ErrOrderNotFound, id)
+	}
+	return order, nil
+}
+
+// ChangeStatus переводит заказ в новый статус, если переход допустим. При отмене
+// и возврате списанный при оформлении товар возвращается на склад в той же транзакции.
+func (s *Service) ChangeStatus(ctx context.Context, id int64, status entity.OrderStatus) error {
+	ctx, span := tracing.Start(ctx, "orders.ChangeStatus")
+	defer span.End()
+
+	var from entity.OrderStatus
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.orderRepository.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		from = order.Status
+
+		if !order.Status.CanTransitionTo(status) {
+			return ErrInvalidStatusTransition
+		}
+
+		if err := s.orderRepository.UpdateStatus(ctx, id, order.Status, status); err != nil {
+			return err
+		}
+
+		if status.ReturnsStock() {
+			return s.restock(ctx, order)
+		}
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, repo.ErrOrderNotFound) {
+			return fmt.Errorf("%w: %w", ErrOrderNotFound, err)
+		}
+		if errors.Is(err, ErrInvalidStatusTransition) {
+			return ErrInvalidStatusTransition
+		}
+		if errors.Is(err, repo.ErrOrderStatusConflict) {
+			return fmt.Errorf("%w: %w", ErrInvalidStatusTransition, err)
+		}
+		return fmt.Errorf("%w: %w", ErrCannotChangeStatus, err)
+	}
+
+	logger.FromContext(ctx).WithField("order_id", id).WithField("from", from).WithField("to", status).
+		Info("order status changed")
+	return nil
+}
+
+// restock возвращает позиции заказа на склад и записывает движения в журнал.
+// Пропускаются цифровой формат, позиции без формата (оформлены до списания остатков
+// при заказе) и позиции удаленных альбомов и SKU - их некуда вернуть.
+func (s *Service) restock(ctx context.Context, order entity.Order) error {
+	for _, item := range order.Items {
+		if item.AlbumID == 0 || item.Format == "" || item.Format == entity.FormatDigital {
+			continue
+		}
+		sku, err := s.stockRepository.FindSKU(ctx, item.AlbumID, item.Format)
+		if err != nil {
+			if errors.Is(err, repo.ErrSKUNotFound) {
+				logger.FromContext(ctx).WithField("order_id", order.ID).WithField("album_id", item.AlbumID).
+					WithField("format", item.Format).Warn("sku of returned item not found")
+				continue
+			}
+			return err
+		}
+		if _, err := s.stockRepository.ChangeQuantity(ctx, sku.ID, item.Quantity); err != nil {
+			return err
+		}
+		err = s.stockRepository.AddMovement(ctx, entity.StockMovement{
+			SKUID:  sku.ID,
+			Delta:  item.Quantity,
+			Reason: fmt.Sprintf("%s: order %d", ReasonReturned, order.ID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
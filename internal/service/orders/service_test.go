@@ -0,0 +1,515 @@
+package orders_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_orders"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_transactor"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	service "github.com/4udiwe/musicshop/internal/service/orders"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckout(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		userID       = int64(5)
+		ctx          = auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID, Role: entity.RoleCustomer})
+		cartID       = int64(1)
+		orderID      = int64(7)
+		promoCode    = "JAZZ20"
+		sku          = entity.SKU{ID: 10, AlbumID: 1, Format: entity.FormatVinyl, Quantity: 5}
+	)
+
+	type MockBehavior func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor)
+
+	cart := entity.Cart{
+		ID:       cartID,
+		UserID:   userID,
+		Currency: entity.BaseCurrency,
+		Items: []entity.CartItem{
+			{AlbumID: 1, Format: entity.FormatVinyl, Title: "Kind of Blue", Artist: "Miles Davis", Price: entity.NewMoney(2000, "USD"), Quantity: 2},
+		},
+	}
+	eurCart := entity.Cart{
+		ID:       cartID,
+		UserID:   userID,
+		Currency: "EUR",
+		Items: []entity.CartItem{
+			{AlbumID: 1, Format: entity.FormatVinyl, Title: "Kind of Blue", Artist: "Miles Davis", Price: entity.NewMoney(1840, "EUR"), Quantity: 2},
+		},
+	}
+	digitalCart := entity.Cart{
+		ID:       cartID,
+		UserID:   userID,
+		Currency: entity.BaseCurrency,
+		Items: []entity.CartItem{
+			{AlbumID: 1, Format: entity.FormatDigital, Title: "Kind of Blue", Artist: "Miles Davis", Price: entity.NewMoney(2000, "USD"), Quantity: 2},
+		},
+	}
+	order := entity.Order{
+		UserID:   &userID,
+		Status:   entity.OrderStatusPending,
+		Currency: entity.BaseCurrency,
+		Items: []entity.OrderItem{
+			{AlbumID: 1, Format: entity.FormatVinyl, Title: "Kind of Blue", Artist: "Miles Davis", Price: entity.NewMoney(2000, "USD"), Quantity: 2, Discount: entity.NewMoney(0, "USD")},
+		},
+	}
+	eurOrder := entity.Order{
+		UserID:   &userID,
+		Status:   entity.OrderStatusPending,
+		Currency: "EUR",
+		Items: []entity.OrderItem{
+			{AlbumID: 1, Format: entity.FormatVinyl, Title: "Kind of Blue", Artist: "Miles Davis", Price: entity.NewMoney(1840, "EUR"), Quantity: 2, Discount: entity.NewMoney(0, "EUR")},
+		},
+	}
+	discountedOrder := entity.Order{
+		UserID:    &userID,
+		Status:    entity.OrderStatusPending,
+		Currency:  entity.BaseCurrency,
+		PromoCode: &promoCode,
+		Items: []entity.OrderItem{
+			{AlbumID: 1, Format: entity.FormatVinyl, Title: "Kind of Blue", Artist: "Miles Davis", Price: entity.NewMoney(2000, "USD"), Quantity: 2, Discount: entity.NewMoney(800, "USD")},
+		},
+	}
+
+	pricing := func(currency string, price, discount int64, applied ...entity.AppliedPromotion) entity.Pricing {
+		return entity.Pricing{
+			Currency: currency,
+			Lines: []entity.PricedLine{{
+				PricingLine: entity.PricingLine{AlbumID: 1, Price: entity.NewMoney(price, currency), Quantity: 2},
+				Discount:    entity.NewMoney(discount, currency),
+			}},
+			Applied: applied,
+		}
+	}
+	plain := pricing("USD", 2000, 0)
+	discounted := pricing("USD", 2000, 800, entity.AppliedPromotion{PromotionID: 3, Code: &promoCode, Discount: entity.NewMoney(800, "USD")})
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	reserved := func(s *mock_orders.MockStockRepository) {
+		s.EXPECT().FindSKU(ctx, int64(1), entity.FormatVinyl).Return(sku, nil)
+	}
+	writtenOff := func(s *mock_orders.MockStockRepository) {
+		s.EXPECT().ChangeQuantity(ctx, sku.ID, -2).Return(3, nil)
+		s.EXPECT().AddMovement(ctx, entity.StockMovement{SKUID: sku.ID, Delta: -2, Reason: "sold: order 7"}).Return(nil)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		currency     string
+		promoCode    string
+		mockBehavior MockBehavior
+		want         int64
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				reserved(s)
+				p.EXPECT().Price(ctx, cart, "").Return(plain, nil)
+				o.EXPECT().Create(ctx, order).Return(orderID, nil)
+				writtenOff(s)
+				p.EXPECT().Redeem(ctx, plain).Return(nil)
+				c.EXPECT().Delete(ctx, cartID).Return(nil)
+			},
+			want:    orderID,
+			wantErr: nil,
+		},
+		{
+			name:     "success in other currency",
+			currency: "EUR",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				reserved(s)
+				q.EXPECT().Quote(ctx, "EUR", map[int64]entity.Money{1: entity.NewMoney(2000, "USD")}).
+					Return(map[int64]entity.Money{1: entity.NewMoney(1840, "EUR")}, nil)
+				p.EXPECT().Price(ctx, eurCart, "").Return(pricing("EUR", 1840, 0), nil)
+				o.EXPECT().Create(ctx, eurOrder).Return(orderID, nil)
+				writtenOff(s)
+				p.EXPECT().Redeem(ctx, gomock.Any()).Return(nil)
+				c.EXPECT().Delete(ctx, cartID).Return(nil)
+			},
+			want:    orderID,
+			wantErr: nil,
+		},
+		{
+			name:      "success with promo code",
+			promoCode: "jazz20",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				reserved(s)
+				p.EXPECT().Price(ctx, cart, "jazz20").Return(discounted, nil)
+				o.EXPECT().Create(ctx, discountedOrder).Return(orderID, nil)
+				writtenOff(s)
+				p.EXPECT().Redeem(ctx, discounted).Return(nil)
+				c.EXPECT().Delete(ctx, cartID).Return(nil)
+			},
+			want:    orderID,
+			wantErr: nil,
+		},
+		{
+			name:     "unsupported currency",
+			currency: "XXX",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				reserved(s)
+				q.EXPECT().Quote(ctx, "XXX", gomock.Any()).Return(nil, entity.ErrUnsupportedCurrency)
+			},
+			want:    0,
+			wantErr: entity.ErrUnsupportedCurrency,
+		},
+		{
+			name:      "invalid promo code",
+			promoCode: "UNKNOWN",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				reserved(s)
+				p.EXPECT().Price(ctx, cart, "UNKNOWN").Return(entity.Pricing{}, arbitraryErr)
+			},
+			want:    0,
+			wantErr: arbitraryErr,
+		},
+		{
+			name:      "promotion exhausted concurrently",
+			promoCode: promoCode,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				reserved(s)
+				p.EXPECT().Price(ctx, cart, promoCode).Return(discounted, nil)
+				o.EXPECT().Create(ctx, discountedOrder).Return(orderID, nil)
+				writtenOff(s)
+				p.EXPECT().Redeem(ctx, discounted).Return(arbitraryErr)
+			},
+			want:    0,
+			wantErr: service.ErrCannotCheckout,
+		},
+		{
+			name: "digital format is not written off",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(digitalCart, nil)
+				s.EXPECT().FindSKU(ctx, int64(1), entity.FormatDigital).Return(entity.SKU{ID: 11, AlbumID: 1, Format: entity.FormatDigital}, nil)
+				p.EXPECT().Price(ctx, digitalCart, "").Return(plain, nil)
+				o.EXPECT().Create(ctx, gomock.Any()).Return(orderID, nil)
+				p.EXPECT().Redeem(ctx, plain).Return(nil)
+				c.EXPECT().Delete(ctx, cartID).Return(nil)
+			},
+			want:    orderID,
+			wantErr: nil,
+		},
+		{
+			name: "insufficient stock",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				s.EXPECT().FindSKU(ctx, int64(1), entity.FormatVinyl).Return(entity.SKU{ID: sku.ID, AlbumID: 1, Format: entity.FormatVinyl, Quantity: 1}, nil)
+			},
+			want:    0,
+			wantErr: service.ErrInsufficientStock,
+		},
+		{
+			name: "format is no longer sold",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				s.EXPECT().FindSKU(ctx, int64(1), entity.FormatVinyl).Return(entity.SKU{}, repo.ErrSKUNotFound)
+			},
+			want:    0,
+			wantErr: service.ErrSKUNotFound,
+		},
+		{
+			name: "cart not found",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(entity.Cart{}, repo.ErrCartNotFound)
+			},
+			want:    0,
+			wantErr: service.ErrCartNotFound,
+		},
+		{
+			name: "cart of another user",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(entity.Cart{ID: cartID, UserID: userID + 1, Items: cart.Items}, nil)
+			},
+			want:    0,
+			wantErr: service.ErrCartNotFound,
+		},
+		{
+			name: "cart is empty",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(entity.Cart{ID: cartID, UserID: userID}, nil)
+			},
+			want:    0,
+			wantErr: service.ErrCartIsEmpty,
+		},
+		{
+			name: "cannot create order",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				reserved(s)
+				p.EXPECT().Price(ctx, cart, "").Return(plain, nil)
+				o.EXPECT().Create(ctx, order).Return(int64(0), arbitraryErr)
+			},
+			want:    0,
+			wantErr: service.ErrCannotCheckout,
+		},
+		{
+			name: "cannot delete cart",
+			mockBehavior: func(o *mock_orders.MockOrderRepository, c *mock_orders.MockCartRepository, s *mock_orders.MockStockRepository, q *mock_orders.MockPriceQuoter, p *mock_orders.MockPromotionPricer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				reserved(s)
+				p.EXPECT().Price(ctx, cart, "").Return(plain, nil)
+				o.EXPECT().Create(ctx, order).Return(orderID, nil)
+				writtenOff(s)
+				p.EXPECT().Redeem(ctx, plain).Return(nil)
+				c.EXPECT().Delete(ctx, cartID).Return(arbitraryErr)
+			},
+			want:    0,
+			wantErr: service.ErrCannotCheckout,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockOrderRepository := mock_orders.NewMockOrderRepository(ctrl)
+			mockCartRepository := mock_orders.NewMockCartRepository(ctrl)
+			mockPriceQuoter := mock_orders.NewMockPriceQuoter(ctrl)
+			mockStockRepository := mock_orders.NewMockStockRepository(ctrl)
+			mockPromotionPricer := mock_orders.NewMockPromotionPricer(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockOrderRepository, mockCartRepository, mockStockRepository, mockPriceQuoter, mockPromotionPricer, mockTransactor)
+
+			s := service.New(mockOrderRepository, mockCartRepository, mockStockRepository, mockPriceQuoter, mockPromotionPricer, mockTransactor)
+
+			out, err := s.Checkout(ctx, cartID, tc.currency, tc.promoCode)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestFindById(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		orderID      = int64(7)
+		userID       = int64(5)
+		otherID      = int64(6)
+		customer     = auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID, Role: entity.RoleCustomer})
+		admin        = auth.WithPrincipal(context.Background(), auth.Principal{UserID: otherID, Role: entity.RoleAdmin})
+		editor       = auth.WithPrincipal(context.Background(), auth.Principal{UserID: otherID, Role: entity.RoleCatalogEditor})
+	)
+
+	order := entity.Order{ID: orderID, UserID: &userID, Status: entity.OrderStatusPending}
+	legacyOrder := entity.Order{ID: orderID, Status: entity.OrderStatusPending}
+
+	for _, tc := range []struct {
+		name    string
+		ctx     context.Context
+		found   entity.Order
+		findErr error
+		want    entity.Order
+		wantErr error
+	}{
+		{name: "owner", ctx: customer, found: order, want: order},
+		{name: "orders manager", ctx: admin, found: order, want: order},
+		{name: "another user", ctx: editor, found: order, wantErr: service.ErrOrderNotFound},
+		{name: "order without owner", ctx: customer, found: legacyOrder, wantErr: service.ErrOrderNotFound},
+		{name: "order without owner for orders manager", ctx: admin, found: legacyOrder, want: legacyOrder},
+		{name: "order not found", ctx: customer, findErr: repo.ErrOrderNotFound, wantErr: service.ErrOrderNotFound},
+		{name: "cannot fetch order", ctx: customer, findErr: arbitraryErr, wantErr: service.ErrCannotFetchOrder},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockOrderRepository := mock_orders.NewMockOrderRepository(ctrl)
+			mockOrderRepository.EXPECT().FindById(tc.ctx, orderID).Return(tc.found, tc.findErr)
+
+			s := service.New(mockOrderRepository, nil, nil, nil, nil, nil)
+
+			out, err := s.FindById(tc.ctx, orderID)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		orderID      = int64(7)
+		sku          = entity.SKU{ID: 10, AlbumID: 1, Format: entity.FormatVinyl, Quantity: 3}
+	)
+
+	type MockBehavior func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor)
+
+	paid := entity.Order{
+		ID:     orderID,
+		Status: entity.OrderStatusPaid,
+		Items: []entity.OrderItem{
+			{AlbumID: 1, Format: entity.FormatVinyl, Quantity: 2},
+			{AlbumID: 2, Format: entity.FormatDigital, Quantity: 1},
+			{AlbumID: 3, Quantity: 1},
+		},
+	}
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		status       entity.OrderStatus
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name:   "success",
+			status: entity.OrderStatusPaid,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(entity.Order{ID: orderID, Status: entity.OrderStatusPending}, nil)
+				o.EXPECT().UpdateStatus(ctx, orderID, entity.OrderStatusPending, entity.OrderStatusPaid).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "cancel returns stock",
+			status: entity.OrderStatusCancelled,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(paid, nil)
+				o.EXPECT().UpdateStatus(ctx, orderID, entity.OrderStatusPaid, entity.OrderStatusCancelled).Return(nil)
+				s.EXPECT().FindSKU(ctx, int64(1), entity.FormatVinyl).Return(sku, nil)
+				s.EXPECT().ChangeQuantity(ctx, sku.ID, 2).Return(5, nil)
+				s.EXPECT().AddMovement(ctx, entity.StockMovement{SKUID: sku.ID, Delta: 2, Reason: "returned: order 7"}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "refund returns stock",
+			status: entity.OrderStatusRefunded,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(paid, nil)
+				o.EXPECT().UpdateStatus(ctx, orderID, entity.OrderStatusPaid, entity.OrderStatusRefunded).Return(nil)
+				s.EXPECT().FindSKU(ctx, int64(1), entity.FormatVinyl).Return(sku, nil)
+				s.EXPECT().ChangeQuantity(ctx, sku.ID, 2).Return(5, nil)
+				s.EXPECT().AddMovement(ctx, entity.StockMovement{SKUID: sku.ID, Delta: 2, Reason: "returned: order 7"}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "sku of returned item removed",
+			status: entity.OrderStatusCancelled,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(paid, nil)
+				o.EXPECT().UpdateStatus(ctx, orderID, entity.OrderStatusPaid, entity.OrderStatusCancelled).Return(nil)
+				s.EXPECT().FindSKU(ctx, int64(1), entity.FormatVinyl).Return(entity.SKU{}, repo.ErrSKUNotFound)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "cannot return stock",
+			status: entity.OrderStatusCancelled,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(paid, nil)
+				o.EXPECT().UpdateStatus(ctx, orderID, entity.OrderStatusPaid, entity.OrderStatusCancelled).Return(nil)
+				s.EXPECT().FindSKU(ctx, int64(1), entity.FormatVinyl).Return(sku, nil)
+				s.EXPECT().ChangeQuantity(ctx, sku.ID, 2).Return(0, arbitraryErr)
+			},
+			wantErr: service.ErrCannotChangeStatus,
+		},
+		{
+			name:   "order not found",
+			status: entity.OrderStatusPaid,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(entity.Order{}, repo.ErrOrderNotFound)
+			},
+			wantErr: service.ErrOrderNotFound,
+		},
+		{
+			name:   "invalid transition",
+			status: entity.OrderStatusShipped,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(entity.Order{ID: orderID, Status: entity.OrderStatusPending}, nil)
+			},
+			wantErr: service.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "concurrent status change",
+			status: entity.OrderStatusPaid,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(entity.Order{ID: orderID, Status: entity.OrderStatusPending}, nil)
+				o.EXPECT().UpdateStatus(ctx, orderID, entity.OrderStatusPending, entity.OrderStatusPaid).Return(repo.ErrOrderStatusConflict)
+			},
+			wantErr: service.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "cannot change status",
+			status: entity.OrderStatusCancelled,
+			mockBehavior: func(o *mock_orders.MockOrderRepository, s *mock_orders.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				o.EXPECT().FindById(ctx, orderID).Return(paid, nil)
+				o.EXPECT().UpdateStatus(ctx, orderID, entity.OrderStatusPaid, entity.OrderStatusCancelled).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotChangeStatus,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockOrderRepository := mock_orders.NewMockOrderRepository(ctrl)
+			mockCartRepository := mock_orders.NewMockCartRepository(ctrl)
+			mockStockRepository := mock_orders.NewMockStockRepository(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockOrderRepository, mockStockRepository, mockTransactor)
+
+			s := service.New(mockOrderRepository, mockCartRepository, mockStockRepository, nil, nil, mockTransactor)
+
+			err := s.ChangeStatus(ctx, orderID, tc.status)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package prices
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type PriceRepository interface {
+	Schedule(ctx context.Context, albumID int64, price entity.Money, from time.Time) (entity.AlbumPrice, error)
+	FindByAlbumID(ctx context.Context, albumID int64) ([]entity.AlbumPrice, error)
+	FindAt(ctx context.Context, albumID int64, currency string, at time.Time) (entity.AlbumPrice, error)
+	FindCurrent(ctx context.Context, albumIDs []int64, currency string) (map[int64]entity.Money, error)
+	ApplyDue(ctx context.Context) (int64, error)
+}
+
+type AlbumRepository interface {
+	FindById(ctx context.Context, id int64) (entity.Album, error)
+	FindSKUPrice(ctx context.Context, albumID int64) (entity.Money, bool, error)
+}
+
+type AuditWriter interface {
+	Write(ctx context.Context, event entity.AuditEvent) error
+}
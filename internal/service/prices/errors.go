@@ -0,0 +1,14 @@
+package prices
+
+import "errors"
+
+var (
+	ErrAlbumNotFound        = errors.New("album not found")
+	ErrPriceNotFound        = errors.New("album has no price at given time")
+	ErrPriceInPast          = errors.New("price cannot be scheduled in the past")
+	ErrPriceDerivedFromSKUs = errors.New("album price is derived from its skus")
+	ErrCannotSchedulePrice  = errors.New("cannot schedule album price")
+	ErrCannotFetchPrices    = errors.New("cannot fetch album prices")
+	ErrUnsupportedCurrency  = errors.New("currency has no prices and no exchange rate")
+	ErrCannotApplyPrices    = errors.New("cannot apply due album prices")
+)
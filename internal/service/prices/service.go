@@ -0,0 +1,204 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/4udiwe/musicshop/pkg/transactor"
+	"github.com/samber/lo"
+)
+
+type Service struct {
+	priceRepository PriceRepository
+	albumRepository AlbumRepository
+	auditWriter     AuditWriter
+	rates           entity.ExchangeRates
+	txManager       transactor.Transactor
+}
+
+func New(p PriceRepository, a AlbumRepository, w AuditWriter, r entity.ExchangeRates, t transactor.Transactor) *Service {
+	return &Service{
+		priceRepository: p,
+		albumRepository: a,
+		auditWriter:     w,
+		rates:           r,
+		txManager:       t,
+	}
+}
+
+// Schedule устанавливает цену альбома в валюте цены с момента from. Нулевой from - цена
+// действует сразу, будущая цена станет текущей в свое время без дополнительных действий.
+// Явная цена в валюте заменяет пересчет базовой цены по курсу. Альбом с SKU продается
+// по ценам SKU, как и при PUT/PATCH, поэтому цену ему не планируют - ErrPriceDerivedFromSKUs.
+func (s *Service) Schedule(ctx context.Context, albumID int64, price entity.Money, from time.Time) (entity.AlbumPrice, error) {
+	ctx, span := tracing.Start(ctx, "prices.Schedule")
+	defer span.End()
+
+	if !from.IsZero() && from.Before(time.Now()) {
+		return entity.AlbumPrice{}, ErrPriceInPast
+	}
+
+	var scheduled entity.AlbumPrice
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.albumRepository.FindById(ctx, albumID); err != nil {
+			return err
+		}
+		if err := s.checkSKUs(ctx, albumID); err != nil {
+			return err
+		}
+
+		var err error
+		scheduled, err = s.priceRepository.Schedule(ctx, albumID, price, from)
+		if err != nil {
+			return err
+		}
+
+		return s.record(ctx, albumID, scheduled)
+	})
+
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return entity.AlbumPrice{}, fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+		}
+		if errors.Is(err, ErrPriceDerivedFromSKUs) {
+			return entity.AlbumPrice{}, err
+		}
+		return entity.AlbumPrice{}, fmt.Errorf("%w: %w", ErrCannotSchedulePrice, err)
+	}
+
+	return scheduled, nil
+}
+
+// checkSKUs не дает планировать цену альбому с SKU: корзины и заказы берут цену SKU,
+// и запланированная цена альбома показывалась бы в каталоге, но не списывалась бы с покупателя
+func (s *Service) checkSKUs(ctx context.Context, albumID int64) error {
+	skuPrice, ok, err := s.albumRepository.FindSKUPrice(ctx, albumID)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return fmt.Errorf("%w: album %d costs %s %s as its cheapest sku",
+			ErrPriceDerivedFromSKUs, albumID, skuPrice.Decimal(), skuPrice.Currency)
+	}
+	return nil
+}
+
+// FindAll возвращает периоды цен альбома. Запланированные цены видит только персонал
+// с правом на цены, остальным история показывается такой, какой она известна сейчас.
+func (s *Service) FindAll(ctx context.Context, albumID int64) ([]entity.AlbumPrice, error) {
+	ctx, span := tracing.Start(ctx, "prices.FindAll")
+	defer span.End()
+
+	if _, err := s.albumRepository.FindById(ctx, albumID); err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrCannotFetchPrices, err)
+	}
+
+	prices, err := s.priceRepository.FindByAlbumID(ctx, albumID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotFetchPrices, err)
+	}
+
+	if principal, _ := auth.PrincipalFromContext(ctx); !principal.Can(auth.PermissionInventoryWrite) {
+		prices = announced(prices, time.Now())
+	}
+	return prices, nil
+}
+
+// announced убирает цены, вступающие в силу после now, и скрывает, когда закончится текущая
+func announced(prices []entity.AlbumPrice, now time.Time) []entity.AlbumPrice {
+	prices = lo.Filter(prices, func(p entity.AlbumPrice, _ int) bool { return !p.EffectiveFrom.After(now) })
+	return lo.Map(prices, func(p entity.AlbumPrice, _ int) entity.AlbumPrice {
+		if p.EffectiveUntil != nil && p.EffectiveUntil.After(now) {
+			p.EffectiveUntil = nil
+		}
+		return p
+	})
+}
+
+// PriceAt возвращает явно заданную цену альбома в валюте currency, действовавшую в момент at.
+// Используется заказами и отчетами, поэтому работает и для удаленных альбомов.
+func (s *Service) PriceAt(ctx context.Context, albumID int64, currency string, at time.Time) (entity.AlbumPrice, error) {
+	ctx, span := tracing.Start(ctx, "prices.PriceAt")
+	defer span.End()
+
+	price, err := s.priceRepository.FindAt(ctx, albumID, currency, at)
+	if err != nil {
+		if errors.Is(err, repo.ErrPriceNotFound) {
+			return entity.AlbumPrice{}, fmt.Errorf("%w: %w", ErrPriceNotFound, err)
+		}
+		return entity.AlbumPrice{}, fmt.Errorf("%w: %w", ErrCannotFetchPrices, err)
+	}
+
+	return price, nil
+}
+
+// ApplyDue переносит наступившие запланированные цены в текущую цену альбома,
+// по которой работают фильтры и сортировка каталога. Вызывается периодически.
+func (s *Service) ApplyDue(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "prices.ApplyDue")
+	defer span.End()
+
+	applied, err := s.priceRepository.ApplyDue(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCannotApplyPrices, err)
+	}
+	return applied, nil
+}
+
+// Quote переводит текущие базовые цены альбомов (id альбома -> цена) в валюту currency.
+// Явно заданная цена в этой валюте важнее пересчета по курсу. У альбомов с SKU явной цены
+// нет: базовой ценой передается цена SKU позиции, и пересчитывается именно она.
+func (s *Service) Quote(ctx context.Context, currency string, base map[int64]entity.Money) (map[int64]entity.Money, error) {
+	ctx, span := tracing.Start(ctx, "prices.Quote")
+	defer span.End()
+
+	if currency == "" || currency == entity.BaseCurrency || len(base) == 0 {
+		return base, nil
+	}
+
+	ids := lo.Keys(base)
+	slices.Sort(ids)
+	explicit, err := s.priceRepository.FindCurrent(ctx, ids, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotFetchPrices, err)
+	}
+
+	quoted := make(map[int64]entity.Money, len(base))
+	for id, price := range base {
+		if p, ok := explicit[id]; ok {
+			quoted[id] = p
+			continue
+		}
+		converted, err := s.rates.Convert(price, currency)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnsupportedCurrency, err)
+		}
+		quoted[id] = converted
+	}
+
+	return quoted, nil
+}
+
+func (s *Service) record(ctx context.Context, albumID int64, price entity.AlbumPrice) error {
+	event := entity.AuditEvent{
+		Action:     entity.AuditActionSchedulePrice,
+		EntityType: entity.AuditEntityAlbum,
+		EntityID:   albumID,
+		After:      price,
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		event.ActorID = &principal.UserID
+	}
+	return s.auditWriter.Write(ctx, event)
+}
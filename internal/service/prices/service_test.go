@@ -0,0 +1,472 @@
+package prices_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_prices"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_transactor"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	service "github.com/4udiwe/musicshop/internal/service/prices"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSchedule(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+		future       = time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	)
+
+	type MockBehavior func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository, w *mock_prices.MockAuditWriter, t *mock_transactor.MockTransactor)
+
+	price := entity.NewMoney(749, "USD")
+	scheduled := entity.AlbumPrice{ID: 5, AlbumID: albumID, Price: price, EffectiveFrom: future}
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		from         time.Time
+		mockBehavior MockBehavior
+		want         entity.AlbumPrice
+		wantErr      error
+	}{
+		{
+			name: "future price",
+			from: future,
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository, w *mock_prices.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				a.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+				p.EXPECT().Schedule(ctx, albumID, price, future).Return(scheduled, nil)
+				w.EXPECT().Write(ctx, entity.AuditEvent{
+					Action:     entity.AuditActionSchedulePrice,
+					EntityType: entity.AuditEntityAlbum,
+					EntityID:   albumID,
+					After:      scheduled,
+				}).Return(nil)
+			},
+			want:    scheduled,
+			wantErr: nil,
+		},
+		{
+			name: "immediate price",
+			from: time.Time{},
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository, w *mock_prices.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				a.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+				p.EXPECT().Schedule(ctx, albumID, price, time.Time{}).Return(scheduled, nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(nil)
+			},
+			want:    scheduled,
+			wantErr: nil,
+		},
+		{
+			name: "price in the past",
+			from: time.Now().Add(-time.Hour),
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository, w *mock_prices.MockAuditWriter, t *mock_transactor.MockTransactor) {
+			},
+			want:    entity.AlbumPrice{},
+			wantErr: service.ErrPriceInPast,
+		},
+		{
+			name: "album not found",
+			from: future,
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository, w *mock_prices.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{}, repo.ErrAlbumNotFound)
+			},
+			want:    entity.AlbumPrice{},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name: "album priced by skus",
+			from: future,
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository, w *mock_prices.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				a.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.NewMoney(999, "USD"), true, nil)
+			},
+			want:    entity.AlbumPrice{},
+			wantErr: service.ErrPriceDerivedFromSKUs,
+		},
+		{
+			name: "cannot schedule",
+			from: future,
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository, w *mock_prices.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				a.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+				p.EXPECT().Schedule(ctx, albumID, price, future).Return(entity.AlbumPrice{}, arbitraryErr)
+			},
+			want:    entity.AlbumPrice{},
+			wantErr: service.ErrCannotSchedulePrice,
+		},
+		{
+			name: "cannot write audit",
+			from: future,
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository, w *mock_prices.MockAuditWriter, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				a.EXPECT().FindSKUPrice(ctx, albumID).Return(entity.Money{}, false, nil)
+				p.EXPECT().Schedule(ctx, albumID, price, future).Return(scheduled, nil)
+				w.EXPECT().Write(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			want:    entity.AlbumPrice{},
+			wantErr: service.ErrCannotSchedulePrice,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPriceRepository := mock_prices.NewMockPriceRepository(ctrl)
+			mockAlbumRepository := mock_prices.NewMockAlbumRepository(ctrl)
+			mockAuditWriter := mock_prices.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockPriceRepository, mockAlbumRepository, mockAuditWriter, mockTransactor)
+
+			s := service.New(mockPriceRepository, mockAlbumRepository, mockAuditWriter, nil, mockTransactor)
+
+			out, err := s.Schedule(ctx, albumID, price, tc.from)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+		from         = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		until        = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	type MockBehavior func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository)
+
+	prices := []entity.AlbumPrice{
+		{ID: 1, AlbumID: albumID, Price: entity.NewMoney(999, "USD"), EffectiveFrom: from, EffectiveUntil: &until},
+		{ID: 2, AlbumID: albumID, Price: entity.NewMoney(749, "USD"), EffectiveFrom: until},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         []entity.AlbumPrice
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository) {
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				p.EXPECT().FindByAlbumID(ctx, albumID).Return(prices, nil)
+			},
+			want:    prices,
+			wantErr: nil,
+		},
+		{
+			name: "album not found",
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository) {
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{}, repo.ErrAlbumNotFound)
+			},
+			want:    nil,
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name: "cannot fetch prices",
+			mockBehavior: func(p *mock_prices.MockPriceRepository, a *mock_prices.MockAlbumRepository) {
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				p.EXPECT().FindByAlbumID(ctx, albumID).Return(nil, arbitraryErr)
+			},
+			want:    nil,
+			wantErr: service.ErrCannotFetchPrices,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPriceRepository := mock_prices.NewMockPriceRepository(ctrl)
+			mockAlbumRepository := mock_prices.NewMockAlbumRepository(ctrl)
+			mockAuditWriter := mock_prices.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockPriceRepository, mockAlbumRepository)
+
+			s := service.New(mockPriceRepository, mockAlbumRepository, mockAuditWriter, nil, mockTransactor)
+
+			out, err := s.FindAll(ctx, albumID)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestFindAllHidesScheduledPrices(t *testing.T) {
+	var (
+		albumID = int64(1)
+		now     = time.Now()
+		past    = now.Add(-24 * time.Hour)
+		future  = now.Add(24 * time.Hour)
+		editor  = auth.WithPrincipal(context.Background(), auth.Principal{UserID: 1, Role: entity.RoleCatalogEditor})
+	)
+
+	prices := []entity.AlbumPrice{
+		{ID: 1, AlbumID: albumID, Price: entity.NewMoney(999, "USD"), EffectiveFrom: past, EffectiveUntil: &future},
+		{ID: 2, AlbumID: albumID, Price: entity.NewMoney(749, "USD"), EffectiveFrom: future},
+	}
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want []entity.AlbumPrice
+	}{
+		{name: "anonymous", ctx: context.Background(), want: []entity.AlbumPrice{
+			{ID: 1, AlbumID: albumID, Price: entity.NewMoney(999, "USD"), EffectiveFrom: past},
+		}},
+		{name: "customer", ctx: auth.WithPrincipal(context.Background(), auth.Principal{UserID: 2, Role: entity.RoleCustomer}), want: []entity.AlbumPrice{
+			{ID: 1, AlbumID: albumID, Price: entity.NewMoney(999, "USD"), EffectiveFrom: past},
+		}},
+		{name: "staff", ctx: editor, want: prices},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPriceRepository := mock_prices.NewMockPriceRepository(ctrl)
+			mockAlbumRepository := mock_prices.NewMockAlbumRepository(ctrl)
+			mockAlbumRepository.EXPECT().FindById(gomock.Any(), albumID).Return(entity.Album{ID: albumID}, nil)
+			mockPriceRepository.EXPECT().FindByAlbumID(gomock.Any(), albumID).Return(prices, nil)
+
+			s := service.New(mockPriceRepository, mockAlbumRepository, nil, nil, nil)
+
+			out, err := s.FindAll(tc.ctx, albumID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestPriceAt(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+		at           = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	type MockBehavior func(p *mock_prices.MockPriceRepository)
+
+	price := entity.AlbumPrice{ID: 1, AlbumID: albumID, Price: entity.NewMoney(999, "USD"), EffectiveFrom: at.Add(-time.Hour)}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         entity.AlbumPrice
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().FindAt(ctx, albumID, "USD", at).Return(price, nil)
+			},
+			want:    price,
+			wantErr: nil,
+		},
+		{
+			name: "no price at given time",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().FindAt(ctx, albumID, "USD", at).Return(entity.AlbumPrice{}, repo.ErrPriceNotFound)
+			},
+			want:    entity.AlbumPrice{},
+			wantErr: service.ErrPriceNotFound,
+		},
+		{
+			name: "cannot fetch price",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().FindAt(ctx, albumID, "USD", at).Return(entity.AlbumPrice{}, arbitraryErr)
+			},
+			want:    entity.AlbumPrice{},
+			wantErr: service.ErrCannotFetchPrices,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPriceRepository := mock_prices.NewMockPriceRepository(ctrl)
+			mockAlbumRepository := mock_prices.NewMockAlbumRepository(ctrl)
+			mockAuditWriter := mock_prices.NewMockAuditWriter(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockPriceRepository)
+
+			s := service.New(mockPriceRepository, mockAlbumRepository, mockAuditWriter, nil, mockTransactor)
+
+			out, err := s.PriceAt(ctx, albumID, "USD", at)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+	)
+
+	type MockBehavior func(p *mock_prices.MockPriceRepository)
+
+	rates, err := entity.ParseExchangeRates(map[string]string{"EUR": "0.92", "JPY": "150"})
+	assert.NoError(t, err)
+
+	base := map[int64]entity.Money{
+		1: entity.NewMoney(999, "USD"),
+		2: entity.NewMoney(2000, "USD"),
+	}
+
+	for _, tc := range []struct {
+		name         string
+		currency     string
+		mockBehavior MockBehavior
+		want         map[int64]entity.Money
+		wantErr      error
+	}{
+		{
+			name:         "base currency",
+			currency:     "USD",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {},
+			want:         base,
+			wantErr:      nil,
+		},
+		{
+			name:     "explicit price wins over exchange rate",
+			currency: "EUR",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().FindCurrent(ctx, []int64{1, 2}, "EUR").
+					Return(map[int64]entity.Money{1: entity.NewMoney(899, "EUR")}, nil)
+			},
+			want: map[int64]entity.Money{
+				1: entity.NewMoney(899, "EUR"),
+				2: entity.NewMoney(1840, "EUR"),
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "currency without minor units",
+			currency: "JPY",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().FindCurrent(ctx, []int64{1, 2}, "JPY").Return(map[int64]entity.Money{}, nil)
+			},
+			want: map[int64]entity.Money{
+				1: entity.NewMoney(1499, "JPY"),
+				2: entity.NewMoney(3000, "JPY"),
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "no exchange rate",
+			currency: "CHF",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().FindCurrent(ctx, []int64{1, 2}, "CHF").Return(map[int64]entity.Money{}, nil)
+			},
+			want:    nil,
+			wantErr: service.ErrUnsupportedCurrency,
+		},
+		{
+			name:     "cannot fetch explicit prices",
+			currency: "EUR",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().FindCurrent(ctx, []int64{1, 2}, "EUR").Return(nil, arbitraryErr)
+			},
+			want:    nil,
+			wantErr: service.ErrCannotFetchPrices,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPriceRepository := mock_prices.NewMockPriceRepository(ctrl)
+
+			tc.mockBehavior(mockPriceRepository)
+
+			s := service.New(mockPriceRepository, nil, nil, rates, nil)
+
+			out, err := s.Quote(ctx, tc.currency, base)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestApplyDue(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+	)
+
+	type MockBehavior func(p *mock_prices.MockPriceRepository)
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         int64
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().ApplyDue(ctx).Return(int64(3), nil)
+			},
+			want:    3,
+			wantErr: nil,
+		},
+		{
+			name: "cannot apply",
+			mockBehavior: func(p *mock_prices.MockPriceRepository) {
+				p.EXPECT().ApplyDue(ctx).Return(int64(0), arbitraryErr)
+			},
+			want:    0,
+			wantErr: service.ErrCannotApplyPrices,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockPriceRepository := mock_prices.NewMockPriceRepository(ctrl)
+
+			tc.mockBehavior(mockPriceRepository)
+
+			s := service.New(mockPriceRepository, nil, nil, nil, nil)
+
+			out, err := s.ApplyDue(ctx)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package promotions
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, p entity.Promotion) (entity.Promotion, error)
+	FindAll(ctx context.Context) ([]entity.Promotion, error)
+	FindByCode(ctx context.Context, code string) (entity.Promotion, error)
+	FindAutomatic(ctx context.Context, at time.Time) ([]entity.Promotion, error)
+	FindAlbumTags(ctx context.Context, albumIDs []int64) (map[int64]entity.PricingLine, error)
+	Redeem(ctx context.Context, id int64) error
+}
+
+type CartRepository interface {
+	FindById(ctx context.Context, id int64) (entity.Cart, error)
+}
+
+// PriceQuoter переводит базовые цены альбомов в другую валюту
+type PriceQuoter interface {
+	Quote(ctx context.Context, currency string, prices map[int64]entity.Money) (map[int64]entity.Money, error)
+}
@@ -0,0 +1,204 @@
+package promotions
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+// Evaluate рассчитывает скидки корзины в валюте currency по акциям, действующим в момент at.
+// Функция чистая: одинаковые данные в любом порядке дают одинаковый результат.
+//
+// Правила применения:
+//   - процентные акции и "купи X получи Y" не суммируются: каждая позиция получает
+//     скидку не более чем от одной из них. Жадно выбирается акция с наибольшей скидкой
+//     по еще не затронутым позициям, при равенстве - с меньшим id;
+//   - фиксированные скидки применяются после них по возрастанию id к остатку стоимости
+//     подходящих позиций и распределяются между позициями пропорционально остатку.
+//     Скидка в другой валюте не применяется.
+func Evaluate(currency string, lines []entity.PricingLine, promotions []entity.Promotion, at time.Time) entity.Pricing {
+	priced := make([]entity.PricedLine, len(lines))
+	for i, line := range lines {
+		priced[i] = entity.PricedLine{PricingLine: line, Discount: entity.NewMoney(0, currency)}
+	}
+	slices.SortFunc(priced, func(a, b entity.PricedLine) int { return cmp.Compare(a.AlbumID, b.AlbumID) })
+
+	eligible := make([]entity.Promotion, 0, len(promotions))
+	for _, p := range promotions {
+		if p.ActiveAt(at) && !p.Exhausted() {
+			eligible = append(eligible, p)
+		}
+	}
+	slices.SortFunc(eligible, func(a, b entity.Promotion) int { return cmp.Compare(a.ID, b.ID) })
+
+	pricing := entity.Pricing{Currency: currency, Lines: priced, Applied: make([]entity.AppliedPromotion, 0)}
+
+	exclusive := make([]entity.Promotion, 0, len(eligible))
+	fixed := make([]entity.Promotion, 0, len(eligible))
+	for _, p := range eligible {
+		if p.Kind == entity.PromotionFixed {
+			fixed = append(fixed, p)
+		} else {
+			exclusive = append(exclusive, p)
+		}
+	}
+
+	taken := make([]bool, len(priced))
+	for len(exclusive) > 0 {
+		best, bestTotal := -1, int64(0)
+		var bestDiscounts map[int]int64
+		for i, p := range exclusive {
+			discounts := exclusiveDiscounts(p, priced, taken)
+			if total := sum(discounts); total > bestTotal {
+				best, bestTotal, bestDiscounts = i, total, discounts
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		for i := range bestDiscounts {
+			taken[i] = true
+		}
+		pricing.Applied = append(pricing.Applied, apply(exclusive[best], priced, bestDiscounts))
+		exclusive = slices.Delete(exclusive, best, best+1)
+	}
+
+	for _, p := range fixed {
+		if p.Amount.Currency != currency {
+			continue
+		}
+		if discounts := fixedDiscounts(p, priced); sum(discounts) > 0 {
+			pricing.Applied = append(pricing.Applied, apply(p, priced, discounts))
+		}
+	}
+
+	return pricing
+}
+
+// exclusiveDiscounts считает скидку процентной акции или "купи X получи Y"
+// по свободным позициям: индекс позиции -> скидка
+func exclusiveDiscounts(p entity.Promotion, lines []entity.PricedLine, taken []bool) map[int]int64 {
+	discounts := make(map[int]int64)
+
+	switch p.Kind {
+	case entity.PromotionPercent:
+		for i, line := range lines {
+			if taken[i] || !p.Covers(line.PricingLine) {
+				continue
+			}
+			// округление половины вверх: 15% от 0.10 - 0.02
+			if d := (line.Subtotal().Amount*int64(p.Percent) + 50) / 100; d > 0 {
+				discounts[i] = d
+			}
+		}
+
+	case entity.PromotionBuyXGetY:
+		group := p.BuyQuantity + p.FreeQuantity
+		if p.BuyQuantity <= 0 || p.FreeQuantity <= 0 {
+			return discounts
+		}
+
+		// единицы товара от дорогих к дешевым, в каждой полной группе бесплатны последние
+		var units []int
+		for i, line := range lines {
+			if taken[i] || !p.Covers(line.PricingLine) {
+				continue
+			}
+			for range line.Quantity {
+				units = append(units, i)
+			}
+		}
+		slices.SortStableFunc(units, func(a, b int) int {
+			return cmp.Compare(lines[b].Price.Amount, lines[a].Price.Amount)
+		})
+
+		for start := 0; start+group <= len(units); start += group {
+			for _, i := range units[start+p.BuyQuantity : start+group] {
+				if lines[i].Price.Amount > 0 {
+					discounts[i] += lines[i].Price.Amount
+				}
+			}
+		}
+	}
+
+	return discounts
+}
+
+// fixedDiscounts распределяет фиксированную скидку между подходящими позициями
+// пропорционально их остатку методом наибольшего остатка
+func fixedDiscounts(p entity.Promotion, lines []entity.PricedLine) map[int]int64 {
+	var (
+		indexes   []int
+		remaining int64
+	)
+	for i, line := range lines {
+		if p.Covers(line.PricingLine) && line.Total().Amount > 0 {
+			indexes = append(indexes, i)
+			remaining += line.Total().Amount
+		}
+	}
+
+	discounts := make(map[int]int64)
+	amount := min(p.Amount.Amount, remaining)
+	if amount <= 0 {
+		return discounts
+	}
+
+	fractions := make(map[int]int64, len(indexes))
+	var allocated int64
+	for _, i := range indexes {
+		share := amount * lines[i].Total().Amount
+		discounts[i] = share / remaining
+		fractions[i] = share % remaining
+		allocated += discounts[i]
+	}
+
+	slices.SortStableFunc(indexes, func(a, b int) int { return cmp.Compare(fractions[b], fractions[a]) })
+	for _, i := range indexes[:amount-allocated] {
+		discounts[i]++
+	}
+
+	for i, d := range discounts {
+		if d == 0 {
+			delete(discounts, i)
+		}
+	}
+	return discounts
+}
+
+// apply записывает скидки в позиции и возвращает объяснение
+func apply(p entity.Promotion, lines []entity.PricedLine, discounts map[int]int64) entity.AppliedPromotion {
+	applied := entity.AppliedPromotion{
+		PromotionID: p.ID,
+		Name:        p.Name,
+		Code:        p.Code,
+		Description: p.Describe(),
+		AlbumIDs:    make([]int64, 0, len(discounts)),
+	}
+
+	var total int64
+	for i := range lines {
+		d, ok := discounts[i]
+		if !ok {
+			continue
+		}
+		lines[i].Discount.Amount += d
+		lines[i].PromotionIDs = append(lines[i].PromotionIDs, p.ID)
+		applied.AlbumIDs = append(applied.AlbumIDs, lines[i].AlbumID)
+		total += d
+	}
+	applied.Discount = entity.NewMoney(total, lines[0].Discount.Currency)
+
+	return applied
+}
+
+func sum(discounts map[int]int64) int64 {
+	var total int64
+	for _, d := range discounts {
+		total += d
+	}
+	return total
+}
@@ -0,0 +1,211 @@
+package promotions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	service "github.com/4udiwe/musicshop/internal/service/promotions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	var (
+		now      = time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+		started  = now.Add(-time.Hour)
+		jazz     = int64(1)
+		bebop    = int64(5)
+		rock     = int64(2)
+		coupon   = "SAVE5"
+		limitOne = 1
+	)
+
+	usd := func(amount int64) entity.Money { return entity.NewMoney(amount, "USD") }
+	line := func(albumID int64, price int64, quantity int, genres ...int64) entity.PricingLine {
+		return entity.PricingLine{AlbumID: albumID, ArtistID: albumID * 10, GenreIDs: genres, Price: usd(price), Quantity: quantity}
+	}
+	percent := func(id int64, value int, target entity.PromotionTarget, ids ...int64) entity.Promotion {
+		return entity.Promotion{ID: id, Name: "percent", Kind: entity.PromotionPercent, Percent: value, Target: target, TargetIDs: ids, StartsAt: started}
+	}
+	fixed := func(id int64, amount entity.Money, target entity.PromotionTarget, ids ...int64) entity.Promotion {
+		return entity.Promotion{ID: id, Name: "fixed", Kind: entity.PromotionFixed, Amount: amount, Target: target, TargetIDs: ids, StartsAt: started}
+	}
+	buyGet := func(id int64, buy, free int, target entity.PromotionTarget, ids ...int64) entity.Promotion {
+		return entity.Promotion{ID: id, Name: "bundle", Kind: entity.PromotionBuyXGetY, BuyQuantity: buy, FreeQuantity: free, Target: target, TargetIDs: ids, StartsAt: started}
+	}
+
+	// discounts - ожидаемая скидка по альбомам, applied - id сработавших акций по порядку
+	for _, tc := range []struct {
+		name       string
+		lines      []entity.PricingLine
+		promotions []entity.Promotion
+		discounts  map[int64]int64
+		applied    []int64
+	}{
+		{
+			name:      "no promotions",
+			lines:     []entity.PricingLine{line(1, 1000, 2)},
+			discounts: map[int64]int64{1: 0},
+			applied:   []int64{},
+		},
+		{
+			name:       "genre percent includes subgenres",
+			lines:      []entity.PricingLine{line(1, 1000, 2, jazz, bebop), line(2, 1500, 1, rock)},
+			promotions: []entity.Promotion{percent(1, 20, entity.PromotionTargetGenre, jazz)},
+			discounts:  map[int64]int64{1: 400, 2: 0},
+			applied:    []int64{1},
+		},
+		{
+			name:       "artist target",
+			lines:      []entity.PricingLine{line(1, 1000, 1), line(2, 1000, 1)},
+			promotions: []entity.Promotion{percent(1, 10, entity.PromotionTargetArtist, 20)},
+			discounts:  map[int64]int64{1: 0, 2: 100},
+			applied:    []int64{1},
+		},
+		{
+			name:       "percent rounds half up",
+			lines:      []entity.PricingLine{line(1, 10, 1)},
+			promotions: []entity.Promotion{percent(1, 15, entity.PromotionTargetAll)},
+			discounts:  map[int64]int64{1: 2},
+			applied:    []int64{1},
+		},
+		{
+			name:       "buy two get cheapest free across albums",
+			lines:      []entity.PricingLine{line(1, 1000, 2), line(2, 500, 1), line(3, 800, 1)},
+			promotions: []entity.Promotion{buyGet(1, 2, 1, entity.PromotionTargetAll)},
+			discounts:  map[int64]int64{1: 0, 2: 0, 3: 800},
+			applied:    []int64{1},
+		},
+		{
+			name:       "buy two get one needs full group",
+			lines:      []entity.PricingLine{line(1, 1000, 2)},
+			promotions: []entity.Promotion{buyGet(1, 2, 1, entity.PromotionTargetAll)},
+			discounts:  map[int64]int64{1: 0},
+			applied:    []int64{},
+		},
+		{
+			name:  "best exclusive promotion wins, the rest apply to untouched lines",
+			lines: []entity.PricingLine{line(1, 1000, 3), line(2, 2000, 1)},
+			promotions: []entity.Promotion{
+				percent(1, 10, entity.PromotionTargetAll),
+				buyGet(2, 2, 1, entity.PromotionTargetAlbum, 1),
+			},
+			discounts: map[int64]int64{1: 1000, 2: 200},
+			applied:   []int64{2, 1},
+		},
+		{
+			name:  "equal discounts prefer lower id",
+			lines: []entity.PricingLine{line(1, 1000, 1)},
+			promotions: []entity.Promotion{
+				percent(7, 10, entity.PromotionTargetAll),
+				percent(3, 10, entity.PromotionTargetAlbum, 1),
+			},
+			discounts: map[int64]int64{1: 100},
+			applied:   []int64{3},
+		},
+		{
+			name:  "fixed stacks after percent and splits proportionally",
+			lines: []entity.PricingLine{line(1, 1000, 1), line(2, 1000, 1)},
+			promotions: []entity.Promotion{
+				percent(1, 50, entity.PromotionTargetAlbum, 1),
+				fixed(2, usd(300), entity.PromotionTargetAll),
+			},
+			discounts: map[int64]int64{1: 600, 2: 200},
+			applied:   []int64{1, 2},
+		},
+		{
+			name:       "fixed remainder cents go to lower album ids",
+			lines:      []entity.PricingLine{line(3, 100, 1), line(1, 100, 1), line(2, 100, 1)},
+			promotions: []entity.Promotion{fixed(1, usd(100), entity.PromotionTargetAll)},
+			discounts:  map[int64]int64{1: 34, 2: 33, 3: 33},
+			applied:    []int64{1},
+		},
+		{
+			name:       "fixed is capped by line totals",
+			lines:      []entity.PricingLine{line(1, 1000, 1)},
+			promotions: []entity.Promotion{fixed(1, usd(5000), entity.PromotionTargetAll)},
+			discounts:  map[int64]int64{1: 1000},
+			applied:    []int64{1},
+		},
+		{
+			name:       "fixed in other currency is skipped",
+			lines:      []entity.PricingLine{line(1, 1000, 1)},
+			promotions: []entity.Promotion{fixed(1, entity.NewMoney(500, "EUR"), entity.PromotionTargetAll)},
+			discounts:  map[int64]int64{1: 0},
+			applied:    []int64{},
+		},
+		{
+			name:  "inactive and exhausted promotions are skipped",
+			lines: []entity.PricingLine{line(1, 1000, 1)},
+			promotions: func() []entity.Promotion {
+				future := percent(1, 50, entity.PromotionTargetAll)
+				future.StartsAt = now.Add(time.Hour)
+				expired := percent(2, 50, entity.PromotionTargetAll)
+				expired.EndsAt = &now
+				exhausted := percent(3, 50, entity.PromotionTargetAll)
+				exhausted.UsageLimit, exhausted.UsageCount = &limitOne, 1
+				return []entity.Promotion{future, expired, exhausted}
+			}(),
+			discounts: map[int64]int64{1: 0},
+			applied:   []int64{},
+		},
+		{
+			name:  "coupon is explained with its code",
+			lines: []entity.PricingLine{line(1, 1000, 1)},
+			promotions: func() []entity.Promotion {
+				p := fixed(4, usd(500), entity.PromotionTargetAll)
+				p.Code = &coupon
+				return []entity.Promotion{p}
+			}(),
+			discounts: map[int64]int64{1: 500},
+			applied:   []int64{4},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := service.Evaluate("USD", tc.lines, tc.promotions, now)
+
+			discounts := make(map[int64]int64, len(out.Lines))
+			var total int64
+			for _, l := range out.Lines {
+				discounts[l.AlbumID] = l.Discount.Amount
+				total += l.Discount.Amount
+			}
+			applied := make([]int64, 0, len(out.Applied))
+			var explained int64
+			for _, a := range out.Applied {
+				applied = append(applied, a.PromotionID)
+				explained += a.Discount.Amount
+			}
+
+			assert.Equal(t, tc.discounts, discounts)
+			assert.Equal(t, tc.applied, applied)
+			assert.Equal(t, total, out.Discount().Amount)
+			assert.Equal(t, total, explained)
+			assert.Equal(t, out.Subtotal().Amount-total, out.Total().Amount)
+		})
+	}
+}
+
+func TestEvaluateIsDeterministic(t *testing.T) {
+	now := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	lines := []entity.PricingLine{
+		{AlbumID: 1, GenreIDs: []int64{1}, Price: entity.NewMoney(1999, "USD"), Quantity: 3},
+		{AlbumID: 2, GenreIDs: []int64{2}, Price: entity.NewMoney(999, "USD"), Quantity: 1},
+		{AlbumID: 3, GenreIDs: []int64{1}, Price: entity.NewMoney(1499, "USD"), Quantity: 2},
+	}
+	promotions := []entity.Promotion{
+		{ID: 1, Kind: entity.PromotionPercent, Percent: 20, Target: entity.PromotionTargetGenre, TargetIDs: []int64{1}},
+		{ID: 2, Kind: entity.PromotionBuyXGetY, BuyQuantity: 2, FreeQuantity: 1, Target: entity.PromotionTargetAll},
+		{ID: 3, Kind: entity.PromotionFixed, Amount: entity.NewMoney(1000, "USD"), Target: entity.PromotionTargetAll},
+	}
+
+	want := service.Evaluate("USD", lines, promotions, now)
+
+	reversedLines := []entity.PricingLine{lines[2], lines[1], lines[0]}
+	reversedPromotions := []entity.Promotion{promotions[2], promotions[1], promotions[0]}
+	for range 10 {
+		assert.Equal(t, want, service.Evaluate("USD", reversedLines, reversedPromotions, now))
+	}
+}
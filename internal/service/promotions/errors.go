@@ -0,0 +1,16 @@
+package promotions
+
+import "errors"
+
+var (
+	ErrPromotionNotFound     = errors.New("promotion not found")
+	ErrPromotionCodeExists   = errors.New("promotion code already exists")
+	ErrInvalidPromotion      = errors.New("invalid promotion")
+	ErrPromotionNotActive    = errors.New("promotion is not active")
+	ErrPromotionExhausted    = errors.New("promotion usage limit reached")
+	ErrCartNotFound          = errors.New("cart not found")
+	ErrCannotCreatePromotion = errors.New("cannot create promotion")
+	ErrCannotFetchPromotions = errors.New("cannot fetch promotions")
+	ErrCannotPriceCart       = errors.New("cannot price cart")
+	ErrCannotRedeemPromotion = errors.New("cannot redeem promotion")
+)
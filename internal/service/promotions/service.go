@@ -0,0 +1,202 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/samber/lo"
+)
+
+type Service struct {
+	promotionRepository PromotionRepository
+	cartRepository      CartRepository
+	priceQuoter         PriceQuoter
+}
+
+func New(p PromotionRepository, c CartRepository, q PriceQuoter) *Service {
+	return &Service{
+		promotionRepository: p,
+		cartRepository:      c,
+		priceQuoter:         q,
+	}
+}
+
+// Create сохраняет акцию. Код приводится к верхнему регистру,
+// нулевое начало действия заменяется текущим моментом.
+func (s *Service) Create(ctx context.Context, p entity.Promotion) (entity.Promotion, error) {
+	ctx, span := tracing.Start(ctx, "promotions.Create")
+	defer span.End()
+
+	if p.Code != nil {
+		p.Code = lo.ToPtr(normalizeCode(*p.Code))
+	}
+	if p.StartsAt.IsZero() {
+		p.StartsAt = time.Now()
+	}
+	if p.TargetIDs == nil {
+		p.TargetIDs = []int64{}
+	}
+	if err := validate(p); err != nil {
+		return entity.Promotion{}, err
+	}
+
+	created, err := s.promotionRepository.Create(ctx, p)
+	if err != nil {
+		if errors.Is(err, repo.ErrPromotionCodeExists) {
+			return entity.Promotion{}, fmt.Errorf("%w: %w", ErrPromotionCodeExists, err)
+		}
+		return entity.Promotion{}, fmt.Errorf("%w: %w", ErrCannotCreatePromotion, err)
+	}
+
+	return created, nil
+}
+
+func (s *Service) FindAll(ctx context.Context) ([]entity.Promotion, error) {
+	ctx, span := tracing.Start(ctx, "promotions.FindAll")
+	defer span.End()
+
+	promotions, err := s.promotionRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotFetchPromotions, err)
+	}
+	return promotions, nil
+}
+
+// PriceCart рассчитывает скидки корзины в валюте currency (пусто - базовая)
+// с автоматическими акциями и акцией по коду code, если он указан
+func (s *Service) PriceCart(ctx context.Context, cartID int64, code, currency string) (entity.Pricing, error) {
+	ctx, span := tracing.Start(ctx, "promotions.PriceCart")
+	defer span.End()
+
+	cart, err := s.cartRepository.FindById(ctx, cartID)
+	if err != nil {
+		if errors.Is(err, repo.ErrCartNotFound) {
+			return entity.Pricing{}, fmt.Errorf("%w: %w", ErrCartNotFound, err)
+		}
+		return entity.Pricing{}, fmt.Errorf("%w: %w", ErrCannotPriceCart, err)
+	}
+	if !auth.IsUser(ctx, cart.UserID) {
+		return entity.Pricing{}, fmt.Errorf("%w: cart with id %d belongs to another user", ErrCartNotFound, cartID)
+	}
+
+	if currency != "" && currency != cart.Currency {
+		prices, err := s.priceQuoter.Quote(ctx, currency, lo.SliceToMap(cart.Items, func(item entity.CartItem) (int64, entity.Money) {
+			return item.AlbumID, item.Price
+		}))
+		if err != nil {
+			return entity.Pricing{}, fmt.Errorf("%w: %w", ErrCannotPriceCart, err)
+		}
+		cart.Currency = currency
+		cart.Items = lo.Map(cart.Items, func(item entity.CartItem, _ int) entity.CartItem {
+			item.Price = prices[item.AlbumID]
+			return item
+		})
+	}
+
+	return s.Price(ctx, cart, code)
+}
+
+// Price рассчитывает скидки корзины с ценами в ее валюте, см. Evaluate.
+// Недействующий или неизвестный код - ошибка, а не расчет без скидки.
+func (s *Service) Price(ctx context.Context, cart entity.Cart, code string) (entity.Pricing, error) {
+	ctx, span := tracing.Start(ctx, "promotions.Price")
+	defer span.End()
+
+	now := time.Now()
+
+	promotions, err := s.promotionRepository.FindAutomatic(ctx, now)
+	if err != nil {
+		return entity.Pricing{}, fmt.Errorf("%w: %w", ErrCannotPriceCart, err)
+	}
+
+	if code = normalizeCode(code); code != "" {
+		coupon, err := s.promotionRepository.FindByCode(ctx, code)
+		if err != nil {
+			if errors.Is(err, repo.ErrPromotionNotFound) {
+				return entity.Pricing{}, fmt.Errorf("%w: %w", ErrPromotionNotFound, err)
+			}
+			return entity.Pricing{}, fmt.Errorf("%w: %w", ErrCannotPriceCart, err)
+		}
+		if !coupon.ActiveAt(now) {
+			return entity.Pricing{}, fmt.Errorf("%w: code '%s'", ErrPromotionNotActive, code)
+		}
+		if coupon.Exhausted() {
+			return entity.Pricing{}, fmt.Errorf("%w: code '%s'", ErrPromotionExhausted, code)
+		}
+		promotions = append(promotions, coupon)
+	}
+
+	// артист и жанры нужны только для подбора акций
+	tags := map[int64]entity.PricingLine{}
+	if len(cart.Items) > 0 && len(promotions) > 0 {
+		tags, err = s.promotionRepository.FindAlbumTags(ctx, lo.Map(cart.Items, func(item entity.CartItem, _ int) int64 {
+			return item.AlbumID
+		}))
+		if err != nil {
+			return entity.Pricing{}, fmt.Errorf("%w: %w", ErrCannotPriceCart, err)
+		}
+	}
+
+	lines := lo.Map(cart.Items, func(item entity.CartItem, _ int) entity.PricingLine {
+		line := tags[item.AlbumID]
+		line.AlbumID, line.Price, line.Quantity = item.AlbumID, item.Price, item.Quantity
+		return line
+	})
+
+	return Evaluate(cart.Currency, lines, promotions, now), nil
+}
+
+// Redeem засчитывает использование примененных акций, вызывать в транзакции оформления заказа
+func (s *Service) Redeem(ctx context.Context, pricing entity.Pricing) error {
+	ctx, span := tracing.Start(ctx, "promotions.Redeem")
+	defer span.End()
+
+	for _, applied := range pricing.Applied {
+		if err := s.promotionRepository.Redeem(ctx, applied.PromotionID); err != nil {
+			if errors.Is(err, repo.ErrPromotionExhausted) {
+				return fmt.Errorf("%w: %w", ErrPromotionExhausted, err)
+			}
+			return fmt.Errorf("%w: %w", ErrCannotRedeemPromotion, err)
+		}
+	}
+	return nil
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// validate проверяет параметры, обязательные для вида акции и цели
+func validate(p entity.Promotion) error {
+	switch p.Kind {
+	case entity.PromotionPercent:
+		if p.Percent < 1 || p.Percent > 100 {
+			return fmt.Errorf("%w: percent must be between 1 and 100", ErrInvalidPromotion)
+		}
+	case entity.PromotionFixed:
+		if p.Amount.Amount <= 0 {
+			return fmt.Errorf("%w: amount must be positive", ErrInvalidPromotion)
+		}
+	case entity.PromotionBuyXGetY:
+		if p.BuyQuantity < 1 || p.FreeQuantity < 1 {
+			return fmt.Errorf("%w: buy and free quantities must be positive", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind '%s'", ErrInvalidPromotion, p.Kind)
+	}
+
+	if (p.Target == entity.PromotionTargetAll) != (len(p.TargetIDs) == 0) {
+		return fmt.Errorf("%w: target ids are required for target '%s' only", ErrInvalidPromotion, p.Target)
+	}
+	if p.EndsAt != nil && !p.EndsAt.After(p.StartsAt) {
+		return fmt.Errorf("%w: promotion must end after it starts", ErrInvalidPromotion)
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package promotions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_promotions"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	service "github.com/4udiwe/musicshop/internal/service/promotions"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		startsAt     = time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)
+		lowerCode    = " jazz20 "
+		upperCode    = "JAZZ20"
+	)
+
+	type MockBehavior func(p *mock_promotions.MockPromotionRepository)
+
+	percent := entity.Promotion{
+		Name:      "Jazz week",
+		Kind:      entity.PromotionPercent,
+		Percent:   20,
+		Target:    entity.PromotionTargetGenre,
+		TargetIDs: []int64{1},
+		StartsAt:  startsAt,
+	}
+	withCode := func(p entity.Promotion, code string) entity.Promotion {
+		p.Code = &code
+		return p
+	}
+
+	for _, tc := range []struct {
+		name         string
+		in           entity.Promotion
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			in:   percent,
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {
+				p.EXPECT().Create(ctx, percent).Return(entity.Promotion{ID: 1}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "code is normalized",
+			in:   withCode(percent, lowerCode),
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {
+				p.EXPECT().Create(ctx, withCode(percent, upperCode)).Return(entity.Promotion{ID: 1}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "code exists",
+			in:   withCode(percent, upperCode),
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {
+				p.EXPECT().Create(ctx, gomock.Any()).Return(entity.Promotion{}, repo.ErrPromotionCodeExists)
+			},
+			wantErr: service.ErrPromotionCodeExists,
+		},
+		{
+			name:         "percent out of range",
+			in:           entity.Promotion{Kind: entity.PromotionPercent, Percent: 120, Target: entity.PromotionTargetAll},
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {},
+			wantErr:      service.ErrInvalidPromotion,
+		},
+		{
+			name:         "fixed without amount",
+			in:           entity.Promotion{Kind: entity.PromotionFixed, Target: entity.PromotionTargetAll},
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {},
+			wantErr:      service.ErrInvalidPromotion,
+		},
+		{
+			name:         "target without ids",
+			in:           entity.Promotion{Kind: entity.PromotionBuyXGetY, BuyQuantity: 2, FreeQuantity: 1, Target: entity.PromotionTargetAlbum},
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {},
+			wantErr:      service.ErrInvalidPromotion,
+		},
+		{
+			name: "ends before start",
+			in: func() entity.Promotion {
+				p := percent
+				p.EndsAt = &startsAt
+				return p
+			}(),
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {},
+			wantErr:      service.ErrInvalidPromotion,
+		},
+		{
+			name: "cannot create",
+			in:   percent,
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {
+				p.EXPECT().Create(ctx, percent).Return(entity.Promotion{}, arbitraryErr)
+			},
+			wantErr: service.ErrCannotCreatePromotion,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPromotionRepository := mock_promotions.NewMockPromotionRepository(ctrl)
+
+			tc.mockBehavior(mockPromotionRepository)
+
+			s := service.New(mockPromotionRepository, nil, nil)
+
+			_, err := s.Create(ctx, tc.in)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestPriceCart(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		userID       = int64(5)
+		ctx          = auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID, Role: entity.RoleCustomer})
+		cartID       = int64(1)
+		code         = "JAZZ20"
+		past         = time.Now().Add(-time.Hour)
+		future       = time.Now().Add(time.Hour)
+		limitOne     = 1
+	)
+
+	type MockBehavior func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter)
+
+	cart := entity.Cart{
+		ID:       cartID,
+		UserID:   userID,
+		Currency: entity.BaseCurrency,
+		Items: []entity.CartItem{
+			{AlbumID: 1, Price: entity.NewMoney(2000, "USD"), Quantity: 2},
+		},
+	}
+	tags := map[int64]entity.PricingLine{1: {AlbumID: 1, ArtistID: 10, GenreIDs: []int64{1, 5}}}
+	jazz := entity.Promotion{
+		ID:        3,
+		Code:      &code,
+		Kind:      entity.PromotionPercent,
+		Percent:   20,
+		Target:    entity.PromotionTargetGenre,
+		TargetIDs: []int64{1},
+		StartsAt:  past,
+	}
+
+	for _, tc := range []struct {
+		name         string
+		code         string
+		currency     string
+		mockBehavior MockBehavior
+		wantDiscount int64
+		wantErr      error
+	}{
+		{
+			name: "no promotions",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				p.EXPECT().FindAutomatic(ctx, gomock.Any()).Return(nil, nil)
+			},
+			wantDiscount: 0,
+			wantErr:      nil,
+		},
+		{
+			name: "coupon applies to subgenre",
+			code: "jazz20",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				p.EXPECT().FindAutomatic(ctx, gomock.Any()).Return(nil, nil)
+				p.EXPECT().FindByCode(ctx, code).Return(jazz, nil)
+				p.EXPECT().FindAlbumTags(ctx, []int64{1}).Return(tags, nil)
+			},
+			wantDiscount: 800,
+			wantErr:      nil,
+		},
+		{
+			name:     "priced in other currency",
+			currency: "EUR",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				q.EXPECT().Quote(ctx, "EUR", map[int64]entity.Money{1: entity.NewMoney(2000, "USD")}).
+					Return(map[int64]entity.Money{1: entity.NewMoney(1840, "EUR")}, nil)
+				p.EXPECT().FindAutomatic(ctx, gomock.Any()).Return([]entity.Promotion{{ID: 1, Kind: entity.PromotionPercent, Percent: 50, Target: entity.PromotionTargetAll, StartsAt: past}}, nil)
+				p.EXPECT().FindAlbumTags(ctx, []int64{1}).Return(tags, nil)
+			},
+			wantDiscount: 1840,
+			wantErr:      nil,
+		},
+		{
+			name: "cart not found",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				c.EXPECT().FindById(ctx, cartID).Return(entity.Cart{}, repo.ErrCartNotFound)
+			},
+			wantErr: service.ErrCartNotFound,
+		},
+		{
+			name: "cart of another user",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				c.EXPECT().FindById(ctx, cartID).Return(entity.Cart{ID: cartID, UserID: userID + 1, Currency: entity.BaseCurrency}, nil)
+			},
+			wantErr: service.ErrCartNotFound,
+		},
+		{
+			name: "unknown code",
+			code: "UNKNOWN",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				p.EXPECT().FindAutomatic(ctx, gomock.Any()).Return(nil, nil)
+				p.EXPECT().FindByCode(ctx, "UNKNOWN").Return(entity.Promotion{}, repo.ErrPromotionNotFound)
+			},
+			wantErr: service.ErrPromotionNotFound,
+		},
+		{
+			name: "code not active yet",
+			code: code,
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				upcoming := jazz
+				upcoming.StartsAt = future
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				p.EXPECT().FindAutomatic(ctx, gomock.Any()).Return(nil, nil)
+				p.EXPECT().FindByCode(ctx, code).Return(upcoming, nil)
+			},
+			wantErr: service.ErrPromotionNotActive,
+		},
+		{
+			name: "code exhausted",
+			code: code,
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				exhausted := jazz
+				exhausted.UsageLimit, exhausted.UsageCount = &limitOne, 1
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				p.EXPECT().FindAutomatic(ctx, gomock.Any()).Return(nil, nil)
+				p.EXPECT().FindByCode(ctx, code).Return(exhausted, nil)
+			},
+			wantErr: service.ErrPromotionExhausted,
+		},
+		{
+			name: "cannot fetch tags",
+			code: code,
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository, c *mock_promotions.MockCartRepository, q *mock_promotions.MockPriceQuoter) {
+				c.EXPECT().FindById(ctx, cartID).Return(cart, nil)
+				p.EXPECT().FindAutomatic(ctx, gomock.Any()).Return(nil, nil)
+				p.EXPECT().FindByCode(ctx, code).Return(jazz, nil)
+				p.EXPECT().FindAlbumTags(ctx, []int64{1}).Return(nil, arbitraryErr)
+			},
+			wantErr: service.ErrCannotPriceCart,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPromotionRepository := mock_promotions.NewMockPromotionRepository(ctrl)
+			mockCartRepository := mock_promotions.NewMockCartRepository(ctrl)
+			mockPriceQuoter := mock_promotions.NewMockPriceQuoter(ctrl)
+
+			tc.mockBehavior(mockPromotionRepository, mockCartRepository, mockPriceQuoter)
+
+			s := service.New(mockPromotionRepository, mockCartRepository, mockPriceQuoter)
+
+			out, err := s.PriceCart(ctx, cartID, tc.code, tc.currency)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantDiscount, out.Discount().Amount)
+		})
+	}
+}
+
+func TestRedeem(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+	)
+
+	type MockBehavior func(p *mock_promotions.MockPromotionRepository)
+
+	pricing := entity.Pricing{Applied: []entity.AppliedPromotion{{PromotionID: 1}, {PromotionID: 2}}}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {
+				p.EXPECT().Redeem(ctx, int64(1)).Return(nil)
+				p.EXPECT().Redeem(ctx, int64(2)).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "limit reached",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {
+				p.EXPECT().Redeem(ctx, int64(1)).Return(repo.ErrPromotionExhausted)
+			},
+			wantErr: service.ErrPromotionExhausted,
+		},
+		{
+			name: "cannot redeem",
+			mockBehavior: func(p *mock_promotions.MockPromotionRepository) {
+				p.EXPECT().Redeem(ctx, int64(1)).Return(nil)
+				p.EXPECT().Redeem(ctx, int64(2)).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotRedeemPromotion,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockPromotionRepository := mock_promotions.NewMockPromotionRepository(ctrl)
+
+			tc.mockBehavior(mockPromotionRepository)
+
+			s := service.New(mockPromotionRepository, nil, nil)
+
+			err := s.Redeem(ctx, pricing)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
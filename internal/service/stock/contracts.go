@@ -0,0 +1,20 @@
+package stock
+
+import (
+	"context"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type StockRepository interface {
+	UpsertSKU(ctx context.Context, sku entity.SKU) (entity.SKU, error)
+	FindSKUs(ctx context.Context, albumID int64) ([]entity.SKU, error)
+	FindSKU(ctx context.Context, albumID int64, format string) (entity.SKU, error)
+	ChangeQuantity(ctx context.Context, skuID int64, delta int) (int, error)
+	AddMovement(ctx context.Context, movement entity.StockMovement) error
+	SyncAlbumPrice(ctx context.Context, albumID int64) error
+}
+
+type AlbumRepository interface {
+	FindById(ctx context.Context, id int64) (entity.Album, error)
+}
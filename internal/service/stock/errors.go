@@ -0,0 +1,13 @@
+package stock
+
+import "errors"
+
+var (
+	ErrAlbumNotFound           = errors.New("album not found")
+	ErrSKUNotFound             = errors.New("album is not sold in given format")
+	ErrInsufficientStock       = errors.New("insufficient stock")
+	ErrDigitalStock            = errors.New("digital format has no stock")
+	ErrCannotSetPrice          = errors.New("cannot set sku price")
+	ErrCannotAdjustStock       = errors.New("cannot adjust stock")
+	ErrCannotFetchAvailability = errors.New("cannot fetch availability")
+)
@@ -0,0 +1,133 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/4udiwe/musicshop/pkg/transactor"
+)
+
+const ReasonReceived = "received"
+
+type Service struct {
+	stockRepository StockRepository
+	albumRepository AlbumRepository
+	txManager       transactor.Transactor
+}
+
+func New(s StockRepository, a AlbumRepository, t transactor.Transactor) *Service {
+	return &Service{
+		stockRepository: s,
+		albumRepository: a,
+		txManager:       t,
+	}
+}
+
+// SetPrice создает SKU альбома в формате или меняет его цену, пересчитывая цену альбома
+func (s *Service) SetPrice(ctx context.Context, albumID int64, format string, price entity.Money) (entity.SKU, error) {
+	ctx, span := tracing.Start(ctx, "stock.SetPrice")
+	defer span.End()
+
+	var sku entity.SKU
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		sku, err = s.stockRepository.UpsertSKU(ctx, entity.SKU{
+			AlbumID: albumID,
+			Format:  format,
+			Price:   price,
+		})
+		if err != nil {
+			return err
+		}
+		return s.stockRepository.SyncAlbumPrice(ctx, albumID)
+	})
+
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return entity.SKU{}, fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+		}
+		return entity.SKU{}, fmt.Errorf("%w: %w", ErrCannotSetPrice, err)
+	}
+
+	return sku, nil
+}
+
+// Receive оприходует поступление товара
+func (s *Service) Receive(ctx context.Context, albumID int64, format string, quantity int) (entity.SKU, error) {
+	ctx, span := tracing.Start(ctx, "stock.Receive")
+	defer span.End()
+
+	return s.Adjust(ctx, albumID, format, quantity, ReasonReceived)
+}
+
+// Adjust изменяет остаток на delta и записывает движение в журнал
+func (s *Service) Adjust(ctx context.Context, albumID int64, format string, delta int, reason string) (entity.SKU, error) {
+	ctx, span := tracing.Start(ctx, "stock.Adjust")
+	defer span.End()
+
+	if format == entity.FormatDigital {
+		return entity.SKU{}, ErrDigitalStock
+	}
+
+	var sku entity.SKU
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		sku, err = s.stockRepository.FindSKU(ctx, albumID, format)
+		if err != nil {
+			return err
+		}
+
+		sku.Quantity, err = s.stockRepository.ChangeQuantity(ctx, sku.ID, delta)
+		if err != nil {
+			return err
+		}
+
+		return s.stockRepository.AddMovement(ctx, entity.StockMovement{
+			SKUID:  sku.ID,
+			Delta:  delta,
+			Reason: reason,
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, repo.ErrSKUNotFound) {
+			return entity.SKU{}, fmt.Errorf("%w: %w", ErrSKUNotFound, err)
+		}
+		if errors.Is(err, repo.ErrInsufficientStock) {
+			return entity.SKU{}, fmt.Errorf("%w: %w", ErrInsufficientStock, err)
+		}
+		return entity.SKU{}, fmt.Errorf("%w: %w", ErrCannotAdjustStock, err)
+	}
+
+	return sku, nil
+}
+
+func (s *Service) Availability(ctx context.Context, albumID int64) (entity.Availability, error) {
+	ctx, span := tracing.Start(ctx, "stock.Availability")
+	defer span.End()
+
+	album, err := s.albumRepository.FindById(ctx, albumID)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return entity.Availability{}, fmt.Errorf("%w: %w", ErrAlbumNotFound, err)
+		}
+		return entity.Availability{}, fmt.Errorf("%w: %w", ErrCannotFetchAvailability, err)
+	}
+
+	skus, err := s.stockRepository.FindSKUs(ctx, albumID)
+	if err != nil {
+		return entity.Availability{}, fmt.Errorf("%w: %w", ErrCannotFetchAvailability, err)
+	}
+
+	return entity.Availability{
+		AlbumID: albumID,
+		Price:   album.Price,
+		SKUs:    skus,
+	}, nil
+}
@@ -0,0 +1,267 @@
+package stock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_stock"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_transactor"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	service "github.com/4udiwe/musicshop/internal/service/stock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetPrice(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+	)
+
+	type MockBehavior func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor)
+
+	sku := entity.SKU{AlbumID: albumID, Format: entity.FormatVinyl, Price: entity.NewMoney(3000, "USD")}
+	saved := entity.SKU{ID: 10, AlbumID: albumID, Format: entity.FormatVinyl, Price: entity.NewMoney(3000, "USD"), Quantity: 5}
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         entity.SKU
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				s.EXPECT().UpsertSKU(ctx, sku).Return(saved, nil)
+				s.EXPECT().SyncAlbumPrice(ctx, albumID).Return(nil)
+			},
+			want:    saved,
+			wantErr: nil,
+		},
+		{
+			name: "album not found",
+			mockBehavior: func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				s.EXPECT().UpsertSKU(ctx, sku).Return(entity.SKU{}, repo.ErrAlbumNotFound)
+			},
+			want:    entity.SKU{},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name: "cannot sync album price",
+			mockBehavior: func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				s.EXPECT().UpsertSKU(ctx, sku).Return(saved, nil)
+				s.EXPECT().SyncAlbumPrice(ctx, albumID).Return(arbitraryErr)
+			},
+			want:    entity.SKU{},
+			wantErr: service.ErrCannotSetPrice,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockStockRepository := mock_stock.NewMockStockRepository(ctrl)
+			mockAlbumRepository := mock_stock.NewMockAlbumRepository(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockStockRepository, mockTransactor)
+
+			s := service.New(mockStockRepository, mockAlbumRepository, mockTransactor)
+
+			out, err := s.SetPrice(ctx, albumID, entity.FormatVinyl, entity.NewMoney(3000, "USD"))
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+		reason       = "damaged"
+	)
+
+	type MockBehavior func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor)
+
+	sku := entity.SKU{ID: 10, AlbumID: albumID, Format: entity.FormatCD, Price: entity.NewMoney(1500, "USD"), Quantity: 5}
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		format       string
+		delta        int
+		mockBehavior MockBehavior
+		want         entity.SKU
+		wantErr      error
+	}{
+		{
+			name:   "success",
+			format: entity.FormatCD,
+			delta:  -2,
+			mockBehavior: func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				s.EXPECT().FindSKU(ctx, albumID, entity.FormatCD).Return(sku, nil)
+				s.EXPECT().ChangeQuantity(ctx, sku.ID, -2).Return(3, nil)
+				s.EXPECT().AddMovement(ctx, entity.StockMovement{SKUID: sku.ID, Delta: -2, Reason: reason}).Return(nil)
+			},
+			want:    entity.SKU{ID: 10, AlbumID: albumID, Format: entity.FormatCD, Price: entity.NewMoney(1500, "USD"), Quantity: 3},
+			wantErr: nil,
+		},
+		{
+			name:   "sku not found",
+			format: entity.FormatCD,
+			delta:  -2,
+			mockBehavior: func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				s.EXPECT().FindSKU(ctx, albumID, entity.FormatCD).Return(entity.SKU{}, repo.ErrSKUNotFound)
+			},
+			want:    entity.SKU{},
+			wantErr: service.ErrSKUNotFound,
+		},
+		{
+			name:   "insufficient stock",
+			format: entity.FormatCD,
+			delta:  -10,
+			mockBehavior: func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				s.EXPECT().FindSKU(ctx, albumID, entity.FormatCD).Return(sku, nil)
+				s.EXPECT().ChangeQuantity(ctx, sku.ID, -10).Return(0, repo.ErrInsufficientStock)
+			},
+			want:    entity.SKU{},
+			wantErr: service.ErrInsufficientStock,
+		},
+		{
+			name:   "cannot write movement",
+			format: entity.FormatCD,
+			delta:  -2,
+			mockBehavior: func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				s.EXPECT().FindSKU(ctx, albumID, entity.FormatCD).Return(sku, nil)
+				s.EXPECT().ChangeQuantity(ctx, sku.ID, -2).Return(3, nil)
+				s.EXPECT().AddMovement(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			want:    entity.SKU{},
+			wantErr: service.ErrCannotAdjustStock,
+		},
+		{
+			name:         "digital format",
+			format:       entity.FormatDigital,
+			delta:        1,
+			mockBehavior: func(s *mock_stock.MockStockRepository, t *mock_transactor.MockTransactor) {},
+			want:         entity.SKU{},
+			wantErr:      service.ErrDigitalStock,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockStockRepository := mock_stock.NewMockStockRepository(ctrl)
+			mockAlbumRepository := mock_stock.NewMockAlbumRepository(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockStockRepository, mockTransactor)
+
+			s := service.New(mockStockRepository, mockAlbumRepository, mockTransactor)
+
+			out, err := s.Adjust(ctx, albumID, tc.format, tc.delta, reason)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestAvailability(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		albumID      = int64(1)
+	)
+
+	type MockBehavior func(s *mock_stock.MockStockRepository, a *mock_stock.MockAlbumRepository)
+
+	skus := []entity.SKU{
+		{ID: 1, AlbumID: albumID, Format: entity.FormatCD, Price: entity.NewMoney(1500, "USD"), Quantity: 0},
+		{ID: 2, AlbumID: albumID, Format: entity.FormatDigital, Price: entity.NewMoney(900, "USD")},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		mockBehavior  MockBehavior
+		want          entity.Availability
+		wantAvailable bool
+		wantErr       error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(s *mock_stock.MockStockRepository, a *mock_stock.MockAlbumRepository) {
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID, Price: entity.NewMoney(900, "USD")}, nil)
+				s.EXPECT().FindSKUs(ctx, albumID).Return(skus, nil)
+			},
+			want:          entity.Availability{AlbumID: albumID, Price: entity.NewMoney(900, "USD"), SKUs: skus},
+			wantAvailable: true,
+			wantErr:       nil,
+		},
+		{
+			name: "album not found",
+			mockBehavior: func(s *mock_stock.MockStockRepository, a *mock_stock.MockAlbumRepository) {
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{}, repo.ErrAlbumNotFound)
+			},
+			want:    entity.Availability{},
+			wantErr: service.ErrAlbumNotFound,
+		},
+		{
+			name: "cannot fetch skus",
+			mockBehavior: func(s *mock_stock.MockStockRepository, a *mock_stock.MockAlbumRepository) {
+				a.EXPECT().FindById(ctx, albumID).Return(entity.Album{ID: albumID}, nil)
+				s.EXPECT().FindSKUs(ctx, albumID).Return(nil, arbitraryErr)
+			},
+			want:    entity.Availability{},
+			wantErr: service.ErrCannotFetchAvailability,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockStockRepository := mock_stock.NewMockStockRepository(ctrl)
+			mockAlbumRepository := mock_stock.NewMockAlbumRepository(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockStockRepository, mockAlbumRepository)
+
+			s := service.New(mockStockRepository, mockAlbumRepository, mockTransactor)
+
+			out, err := s.Availability(ctx, albumID)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, out)
+			assert.Equal(t, tc.wantAvailable, out.Available())
+		})
+	}
+}
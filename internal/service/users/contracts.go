@@ -0,0 +1,21 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/entity"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user entity.User) (int64, error)
+	FindByEmail(ctx context.Context, email string) (entity.User, error)
+	FindById(ctx context.Context, id int64) (entity.User, error)
+	CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error
+	FindRefreshToken(ctx context.Context, hash string) (entity.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id int64, at time.Time) error
+}
+
+type TokenIssuer interface {
+	Issue(user entity.User) (string, time.Time, error)
+}
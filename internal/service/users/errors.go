@@ -0,0 +1,12 @@
+package users
+
+import "errors"
+
+var (
+	ErrCannotRegister      = errors.New("cannot register user")
+	ErrUserAlreadyExists   = errors.New("user already exists")
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrCannotLogin         = errors.New("cannot login")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrCannotRefreshTokens = errors.New("cannot refresh tokens")
+)
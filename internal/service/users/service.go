@@ -0,0 +1,157 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/logger"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/4udiwe/musicshop/pkg/transactor"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Service struct {
+	userRepository UserRepository
+	tokenIssuer    TokenIssuer
+	txManager      transactor.Transactor
+	refreshTTL     time.Duration
+}
+
+func New(r UserRepository, ti TokenIssuer, t transactor.Transactor, refreshTTL time.Duration) *Service {
+	return &Service{
+		userRepository: r,
+		tokenIssuer:    ti,
+		txManager:      t,
+		refreshTTL:     refreshTTL,
+	}
+}
+
+func (s *Service) Register(ctx context.Context, email, password string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "users.Register")
+	defer span.End()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCannotRegister, err)
+	}
+
+	id, err := s.userRepository.Create(ctx, entity.User{
+		Email:        normalizeEmail(email),
+		PasswordHash: string(hash),
+		Role:         entity.RoleCustomer,
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrUserAlreadyExists) {
+			return 0, fmt.Errorf("%w: %w", ErrUserAlreadyExists, err)
+		}
+		return 0, fmt.Errorf("%w: %w", ErrCannotRegister, err)
+	}
+
+	logger.FromContext(ctx).WithField("user_id", id).Info("user registered")
+	return id, nil
+}
+
+func (s *Service) Login(ctx context.Context, email, password string) (entity.TokenPair, error) {
+	ctx, span := tracing.Start(ctx, "users.Login")
+	defer span.End()
+
+	user, err := s.userRepository.FindByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, repo.ErrUserNotFound) {
+			return entity.TokenPair{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+		}
+		return entity.TokenPair{}, fmt.Errorf("%w: %w", ErrCannotLogin, err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		logger.FromContext(ctx).WithField("user_id", user.ID).Warn("login with wrong password")
+		return entity.TokenPair{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	pair, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return entity.TokenPair{}, fmt.Errorf("%w: %w", ErrCannotLogin, err)
+	}
+	return pair, nil
+}
+
+// Refresh обменивает refresh-токен на новую пару токенов. Старый токен отзывается,
+// поэтому повторно использовать его нельзя.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (entity.TokenPair, error) {
+	ctx, span := tracing.Start(ctx, "users.Refresh")
+	defer span.End()
+
+	var pair entity.TokenPair
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.userRepository.FindRefreshToken(ctx, auth.HashRefreshToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, repo.ErrRefreshTokenNotFound) {
+				return fmt.Errorf("%w: %w", ErrInvalidRefreshToken, err)
+			}
+			return err
+		}
+
+		now := time.Now()
+		if !token.Active(now) {
+			return ErrInvalidRefreshToken
+		}
+
+		if err := s.userRepository.RevokeRefreshToken(ctx, token.ID, now); err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.FindById(ctx, token.UserID)
+		if err != nil {
+			return err
+		}
+
+		pair, err = s.issueTokens(ctx, user)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrInvalidRefreshToken) {
+			return entity.TokenPair{}, ErrInvalidRefreshToken
+		}
+		return entity.TokenPair{}, fmt.Errorf("%w: %w", ErrCannotRefreshTokens, err)
+	}
+	return pair, nil
+}
+
+func (s *Service) issueTokens(ctx context.Context, user entity.User) (entity.TokenPair, error) {
+	accessToken, expiresAt, err := s.tokenIssuer.Issue(user)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	refreshToken, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	err = s.userRepository.CreateRefreshToken(ctx, entity.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+	})
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	return entity.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,207 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/musicshop/internal/auth"
+	"github.com/4udiwe/musicshop/internal/entity"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_transactor"
+	"github.com/4udiwe/musicshop/internal/mocks/mock_users"
+	repo "github.com/4udiwe/musicshop/internal/repo"
+	service "github.com/4udiwe/musicshop/internal/service/users"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLogin(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		email        = "user@example.com"
+		password     = "correct-horse"
+		expiresAt    = time.Date(2025, 8, 25, 12, 0, 0, 0, time.UTC)
+	)
+
+	type MockBehavior func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	user := entity.User{ID: 1, Email: email, PasswordHash: string(hash)}
+
+	for _, tc := range []struct {
+		name         string
+		email        string
+		password     string
+		mockBehavior MockBehavior
+		wantAccess   string
+		wantErr      error
+	}{
+		{
+			name:     "success",
+			email:    " User@Example.com ",
+			password: password,
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer) {
+				r.EXPECT().FindByEmail(ctx, email).Return(user, nil)
+				ti.EXPECT().Issue(user).Return("access", expiresAt, nil)
+				r.EXPECT().CreateRefreshToken(ctx, gomock.Any()).Return(nil)
+			},
+			wantAccess: "access",
+			wantErr:    nil,
+		},
+		{
+			name:     "user not found",
+			email:    email,
+			password: password,
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer) {
+				r.EXPECT().FindByEmail(ctx, email).Return(entity.User{}, repo.ErrUserNotFound)
+			},
+			wantErr: service.ErrInvalidCredentials,
+		},
+		{
+			name:     "wrong password",
+			email:    email,
+			password: "wrong-password",
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer) {
+				r.EXPECT().FindByEmail(ctx, email).Return(user, nil)
+			},
+			wantErr: service.ErrInvalidCredentials,
+		},
+		{
+			name:     "cannot store refresh token",
+			email:    email,
+			password: password,
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer) {
+				r.EXPECT().FindByEmail(ctx, email).Return(user, nil)
+				ti.EXPECT().Issue(user).Return("access", expiresAt, nil)
+				r.EXPECT().CreateRefreshToken(ctx, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotLogin,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockUserRepository := mock_users.NewMockUserRepository(ctrl)
+			mockTokenIssuer := mock_users.NewMockTokenIssuer(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockUserRepository, mockTokenIssuer)
+
+			s := service.New(mockUserRepository, mockTokenIssuer, mockTransactor, time.Hour)
+
+			out, err := s.Login(ctx, tc.email, tc.password)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantAccess, out.AccessToken)
+			if tc.wantErr == nil {
+				assert.NotEmpty(t, out.RefreshToken)
+				assert.Equal(t, expiresAt, out.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	var (
+		arbitraryErr = errors.New("arbitrary error")
+		ctx          = context.Background()
+		refreshToken = "refresh-token"
+		hash         = auth.HashRefreshToken(refreshToken)
+		expiresAt    = time.Date(2025, 8, 25, 12, 0, 0, 0, time.UTC)
+	)
+
+	type MockBehavior func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer, t *mock_transactor.MockTransactor)
+
+	user := entity.User{ID: 1, Email: "user@example.com"}
+	active := entity.RefreshToken{ID: 5, UserID: user.ID, TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour)}
+	revokedAt := time.Now().Add(-time.Minute)
+
+	withinTransaction := func(t *mock_transactor.MockTransactor) {
+		t.EXPECT().WithinTransaction(ctx, gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+	}
+
+	for _, tc := range []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantAccess   string
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindRefreshToken(ctx, hash).Return(active, nil)
+				r.EXPECT().RevokeRefreshToken(ctx, active.ID, gomock.Any()).Return(nil)
+				r.EXPECT().FindById(ctx, user.ID).Return(user, nil)
+				ti.EXPECT().Issue(user).Return("access", expiresAt, nil)
+				r.EXPECT().CreateRefreshToken(ctx, gomock.Any()).Return(nil)
+			},
+			wantAccess: "access",
+			wantErr:    nil,
+		},
+		{
+			name: "unknown token",
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindRefreshToken(ctx, hash).Return(entity.RefreshToken{}, repo.ErrRefreshTokenNotFound)
+			},
+			wantErr: service.ErrInvalidRefreshToken,
+		},
+		{
+			name: "revoked token",
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				revoked := active
+				revoked.RevokedAt = &revokedAt
+				r.EXPECT().FindRefreshToken(ctx, hash).Return(revoked, nil)
+			},
+			wantErr: service.ErrInvalidRefreshToken,
+		},
+		{
+			name: "expired token",
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				expired := active
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				r.EXPECT().FindRefreshToken(ctx, hash).Return(expired, nil)
+			},
+			wantErr: service.ErrInvalidRefreshToken,
+		},
+		{
+			name: "cannot revoke token",
+			mockBehavior: func(r *mock_users.MockUserRepository, ti *mock_users.MockTokenIssuer, t *mock_transactor.MockTransactor) {
+				withinTransaction(t)
+				r.EXPECT().FindRefreshToken(ctx, hash).Return(active, nil)
+				r.EXPECT().RevokeRefreshToken(ctx, active.ID, gomock.Any()).Return(arbitraryErr)
+			},
+			wantErr: service.ErrCannotRefreshTokens,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockUserRepository := mock_users.NewMockUserRepository(ctrl)
+			mockTokenIssuer := mock_users.NewMockTokenIssuer(ctrl)
+			mockTransactor := mock_transactor.NewMockTransactor(ctrl)
+
+			tc.mockBehavior(mockUserRepository, mockTokenIssuer, mockTransactor)
+
+			s := service.New(mockUserRepository, mockTokenIssuer, mockTransactor, time.Hour)
+
+			out, err := s.Refresh(ctx, refreshToken)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantAccess, out.AccessToken)
+		})
+	}
+}
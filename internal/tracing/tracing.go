@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/4udiwe/musicshop"
+
+// Экспортеры трейсов
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type Config struct {
+	ServiceName    string
+	ServiceVersion string
+	// Exporter - ExporterNone, ExporterStdout или ExporterOTLP
+	Exporter string
+	// Endpoint - адрес OTLP/HTTP коллектора, host:port
+	Endpoint string
+	Insecure bool
+}
+
+// NewExporter создает экспортер по конфигурации, для ExporterNone возвращает nil
+func NewExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+	return nil, fmt.Errorf("tracing - unknown exporter '%s'", cfg.Exporter)
+}
+
+// NewProvider создает провайдер, отправляющий спаны в exporter. В тестах сюда
+// передается tracetest.NewInMemoryExporter, спаны отдаются синхронно.
+func NewProvider(exporter sdktrace.SpanExporter, serviceName, serviceVersion string, batch bool) *sdktrace.TracerProvider {
+	processor := sdktrace.NewSimpleSpanProcessor(exporter)
+	if batch {
+		processor = sdktrace.NewBatchSpanProcessor(exporter)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(serviceVersion),
+		)),
+	)
+}
+
+// Propagator - W3C trace-context и baggage
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// Setup делает провайдер и W3C trace-context глобальными. Без экспортера
+// остается noop провайдер: спаны не пишутся, но контекст трассировки передается дальше.
+// Возвращает функцию, выгружающую накопленные спаны при остановке.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(Propagator())
+
+	exporter, err := NewExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	provider := NewProvider(exporter, cfg.ServiceName, cfg.ServiceVersion, true)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start открывает спан метода сервиса, name - "пакет.Метод".
+// Незаписываемый спан (трассировка выключена) не попадает в контекст:
+// вложенные спаны продолжают родительский, а контекст вызова не меняется.
+func Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	spanCtx, span := otel.Tracer(tracerName).Start(ctx, name)
+	if !span.IsRecording() {
+		return ctx, span
+	}
+	return spanCtx, span
+}
@@ -0,0 +1,66 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4udiwe/musicshop/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewExporter(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name         string
+		exporter     string
+		wantExporter bool
+		wantErr      bool
+	}{
+		{name: "disabled by default", exporter: "", wantExporter: false},
+		{name: "none", exporter: tracing.ExporterNone, wantExporter: false},
+		{name: "stdout", exporter: tracing.ExporterStdout, wantExporter: true},
+		{name: "otlp", exporter: tracing.ExporterOTLP, wantExporter: true},
+		{name: "unknown", exporter: "zipkin", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			exporter, err := tracing.NewExporter(ctx, tracing.Config{Exporter: tc.exporter, Endpoint: "localhost:4318"})
+
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.wantExporter, exporter != nil)
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	ctx := context.Background()
+
+	// без провайдера контекст не меняется
+	got, span := tracing.Start(ctx, "albums.FindAll")
+	span.End()
+	assert.Equal(t, ctx, got)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(exporter, "musicshop", "test", false)
+	defer func() { _ = provider.Shutdown(ctx) }()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	parentCtx, parent := provider.Tracer("test").Start(ctx, "GET /albums")
+	childCtx, child := tracing.Start(parentCtx, "albums.FindAll")
+	child.End()
+	parent.End()
+
+	assert.NotEqual(t, parentCtx, childCtx)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "albums.FindAll", spans[0].Name)
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+		assert.Equal(t, spans[1].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	}
+}
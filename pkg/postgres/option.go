@@ -1,6 +1,10 @@
 package postgres
 
-import "time"
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
 
 type Option func(*Postgres)
 
@@ -15,3 +19,18 @@ func TimeOut(t time.Duration) Option {
 		p.connTimeout = t
 	}
 }
+
+// OnTransaction задает обработчик завершения транзакций WithinTransaction,
+// получающий TxCommit или TxRollback
+func OnTransaction(fn func(outcome string)) Option {
+	return func(p *Postgres) {
+		p.onTransaction = fn
+	}
+}
+
+// TracerProvider включает трассировку SQL запросов через провайдер provider
+func TracerProvider(provider trace.TracerProvider) Option {
+	return func(p *Postgres) {
+		p.tracerProvider = provider
+	}
+}
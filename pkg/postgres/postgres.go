@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -17,18 +18,28 @@ const (
 	defaultConnAttempts = 10
 )
 
+// Исходы транзакции для OnTransaction
+const (
+	TxCommit   = "commit"
+	TxRollback = "rollback"
+)
+
 type Postgres struct {
 	connTimeout  time.Duration
 	connAttempts int
 
+	onTransaction  func(outcome string)
+	tracerProvider trace.TracerProvider
+
 	Pool    *pgxpool.Pool
 	Builder squirrel.StatementBuilderType
 }
 
 func New(url string, opts ...Option) (*Postgres, error) {
 	pg := &Postgres{
-		connAttempts: defaultConnAttempts,
-		connTimeout:  defaultConnTimeout,
+		connAttempts:  defaultConnAttempts,
+		connTimeout:   defaultConnTimeout,
+		onTransaction: func(string) {},
 	}
 
 	// Custom options
@@ -42,6 +53,9 @@ func New(url string, opts ...Option) (*Postgres, error) {
 	if err != nil {
 		return nil, fmt.Errorf("postgres - NewPostgres - pgxpool.ParseConfig: %w", err)
 	}
+	if pg.tracerProvider != nil {
+		poolConfig.ConnConfig.Tracer = newQueryTracer(pg.tracerProvider)
+	}
 
 	for pg.connAttempts > 0 {
 		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
@@ -71,6 +85,11 @@ func (pg *Postgres) Close() {
 	}
 }
 
+// Ping проверяет соединение с базой
+func (pg *Postgres) Ping(ctx context.Context) error {
+	return pg.Pool.Ping(ctx)
+}
+
 // Transaction management
 
 type txKey struct{}
@@ -109,8 +128,15 @@ func (pg *Postgres) WithinTransaction(ctx context.Context, fn func(context.Conte
 
 	if err := fn(ctxTx); err != nil {
 		_ = tx.Rollback(ctx)
+		pg.onTransaction(TxRollback)
 		return err
 	}
 
-	return tx.Commit(ctx)
+	// неудачный коммит откатывает транзакцию
+	if err := tx.Commit(ctx); err != nil {
+		pg.onTransaction(TxRollback)
+		return err
+	}
+	pg.onTransaction(TxCommit)
+	return nil
 }
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/4udiwe/musicshop/pkg/postgres"
+
+// queryTracer открывает спан на каждый запрос соединения пула, в том числе внутри транзакций.
+// Для SELECT число строк известно только после закрытия pgx.Rows, тогда же закрывается спан.
+type queryTracer struct {
+	tracer trace.Tracer
+}
+
+func newQueryTracer(provider trace.TracerProvider) *queryTracer {
+	return &queryTracer{tracer: provider.Tracer(tracerName)}
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "postgres.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", data.SQL),
+			attribute.Int("db.args_count", len(data.Args)),
+		),
+	)
+	return ctx
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+	span.SetAttributes(
+		attribute.String("db.operation", data.CommandTag.String()),
+		attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()),
+	)
+}
@@ -4,25 +4,61 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// isrcPattern совпадает с ограничением tracks.isrc в БД
+var isrcPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$`)
+
 type CustomValidator struct {
 	v *validator.Validate
 }
 
+// FieldError - нарушение одного правила валидации
+type FieldError struct {
+	Field   string
+	Tag     string
+	Param   string
+	Message string
+}
+
+// ValidationError содержит все нарушения, найденные в запросе
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		messages = append(messages, f.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
 func NewCustomValidator() *CustomValidator {
 	v := validator.New()
 	cv := &CustomValidator{v: v}
 
+	// Поле называется так же, как в запросе: json, query или path-параметр
 	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
-		if name == "-" {
-			return ""
+		for _, tag := range []string{"json", "query", "param"} {
+			name := strings.SplitN(fld.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
 		}
-		return name
+		return ""
+	})
+
+	// isrc - код записи в любом регистре, в БД хранится в верхнем
+	_ = v.RegisterValidation("isrc", func(fl validator.FieldLevel) bool {
+		return isrcPattern.MatchString(strings.ToUpper(fl.Field().String()))
 	})
 
 	return cv
@@ -33,28 +69,84 @@ func (cv *CustomValidator) Validate(i any) error {
 	if err != nil {
 		var validationErr validator.ValidationErrors
 		if errors.As(err, &validationErr) {
-			return cv.newValidationError(validationErr[0].Field(), validationErr[0].Tag(), validationErr[0].Param())
+			fields := make([]FieldError, 0, len(validationErr))
+			for _, fe := range validationErr {
+				// путь без имени корневой структуры: tracks[0].title
+				field := fe.Field()
+				if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+					field = path
+				}
+				fields = append(fields, FieldError{
+					Field:   field,
+					Tag:     fe.Tag(),
+					Param:   fe.Param(),
+					Message: cv.newValidationError(field, fe.Tag(), fe.Param(), fe.Kind()).Error(),
+				})
+			}
+			return &ValidationError{Fields: fields}
 		}
 		return err
 	}
 	return nil
 }
 
-func (cv *CustomValidator) newValidationError(field string, tag string, param string) error {
+// newValidationError формулирует нарушение правила. Правила сравнения у строк
+// ограничивают число символов, у списков - число элементов, у чисел - значение.
+func (cv *CustomValidator) newValidationError(field string, tag string, param string, kind reflect.Kind) error {
+	if bound, ok := comparisons[tag]; ok {
+		switch kind {
+		case reflect.String:
+			return fmt.Errorf("field %s must have %s %s %s", field, lengthBounds[tag], param, plural("character", param))
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Errorf("field %s must have %s %s %s", field, lengthBounds[tag], param, plural("item", param))
+		default:
+			return fmt.Errorf("field %s must be %s %s", field, bound, param)
+		}
+	}
+
 	switch tag {
 	case "required":
 		return fmt.Errorf("field %s is required", field)
-	case "len":
-		return fmt.Errorf("field %s must be %s characters length", field, param)
+	case "required_without":
+		return fmt.Errorf("field %s is required when %s is not set", field, param)
 	case "uri":
 		return fmt.Errorf("field %s must be a valid URI", field)
 	case "email":
 		return fmt.Errorf("field %s must be a valid email address", field)
-	case "min":
-		return fmt.Errorf("field %s must be at least %s characters", field, param)
-	case "max":
-		return fmt.Errorf("field %s must be at most %s characters", field, param)
+	case "isrc":
+		return fmt.Errorf("field %s must be a valid ISRC, e.g. USRC17607839", field)
+	case "oneof":
+		return fmt.Errorf("field %s must be one of: %s", field, param)
 	default:
 		return fmt.Errorf("field %s is invalid", field)
 	}
 }
+
+func plural(unit, n string) string {
+	if n == "1" {
+		return unit
+	}
+	return unit + "s"
+}
+
+// comparisons - формулировки правил сравнения для чисел
+var comparisons = map[string]string{
+	"len": "equal to",
+	"min": "greater than or equal to",
+	"max": "less than or equal to",
+	"gt":  "greater than",
+	"gte": "greater than or equal to",
+	"lt":  "less than",
+	"lte": "less than or equal to",
+}
+
+// lengthBounds - формулировки тех же правил для длины строк и списков
+var lengthBounds = map[string]string{
+	"len": "exactly",
+	"min": "at least",
+	"max": "at most",
+	"gt":  "more than",
+	"gte": "at least",
+	"lt":  "fewer than",
+	"lte": "at most",
+}
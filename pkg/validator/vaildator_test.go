@@ -0,0 +1,49 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/4udiwe/musicshop/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateISRC(t *testing.T) {
+	type Request struct {
+		ISRC string `json:"isrc" validate:"omitempty,isrc"`
+	}
+
+	v := validator.NewCustomValidator()
+
+	for _, tc := range []struct {
+		name    string
+		isrc    string
+		wantErr bool
+	}{
+		{name: "upper case", isrc: "USRC17607839", wantErr: false},
+		{name: "lower case", isrc: "usrc17607839", wantErr: false},
+		{name: "mixed case", isrc: "UsRc17607839", wantErr: false},
+		{name: "empty", isrc: "", wantErr: false},
+		{name: "letter in year and number", isrc: "USRC1760783X", wantErr: true},
+		{name: "digit in country", isrc: "1SRC17607839", wantErr: true},
+		{name: "too short", isrc: "USRC1760783", wantErr: true},
+		{name: "hyphenated", isrc: "US-RC1-76-07839", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.Validate(Request{ISRC: tc.isrc})
+
+			if !tc.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *validator.ValidationError
+			if assert.ErrorAs(t, err, &validationErr) {
+				assert.Equal(t, []validator.FieldError{{
+					Field:   "isrc",
+					Tag:     "isrc",
+					Message: "field isrc must be a valid ISRC, e.g. USRC17607839",
+				}}, validationErr.Fields)
+			}
+		})
+	}
+}